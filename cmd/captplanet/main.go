@@ -26,6 +26,7 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/inspector"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/miniogw"
@@ -60,20 +61,21 @@ type Captplanet struct {
 
 // Satellite configuration
 type Satellite struct {
-	Server      server.Config
-	Kademlia    kademlia.SatelliteConfig
-	PointerDB   pointerdb.Config
-	Overlay     overlay.Config
-	Inspector   inspector.Config
-	Checker     checker.Config
-	Repairer    repairer.Config
-	Audit       audit.Config
-	BwAgreement bwagreement.Config
-	Web         satelliteweb.Config
-	Discovery   discovery.Config
-	Tally       tally.Config
-	Rollup      rollup.Config
-	Database    string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
+	Server       server.Config
+	Kademlia     kademlia.SatelliteConfig
+	PointerDB    pointerdb.Config
+	Overlay      overlay.Config
+	Inspector    inspector.Config
+	Checker      checker.Config
+	Repairer     repairer.Config
+	Audit        audit.Config
+	BwAgreement  bwagreement.Config
+	Web          satelliteweb.Config
+	Discovery    discovery.Config
+	GracefulExit gracefulexit.Config
+	Tally        tally.Config
+	Rollup       rollup.Config
+	Database     string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 }
 
 // StorageNode configuration
@@ -92,6 +92,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 			runCfg.Satellite.Checker,
 			runCfg.Satellite.Repairer,
 			runCfg.Satellite.BwAgreement,
+			runCfg.Satellite.GracefulExit,
 			runCfg.Satellite.Web,
 			runCfg.Satellite.Tally,
 			runCfg.Satellite.Rollup,
@@ -23,6 +23,7 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/overlay"
@@ -40,16 +41,17 @@ type Satellite struct {
 	Identity  identity.SetupConfig   `setup:"true"`
 	Overwrite bool                   `default:"false" help:"whether to overwrite pre-existing configuration files" setup:"true"`
 
-	Server      server.Config
-	Kademlia    kademlia.SatelliteConfig
-	PointerDB   pointerdb.Config
-	Overlay     overlay.Config
-	Checker     checker.Config
-	Repairer    repairer.Config
-	Audit       audit.Config
-	BwAgreement bwagreement.Config
-	Discovery   discovery.Config
-	Database    string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
+	Server       server.Config
+	Kademlia     kademlia.SatelliteConfig
+	PointerDB    pointerdb.Config
+	Overlay      overlay.Config
+	Checker      checker.Config
+	Repairer     repairer.Config
+	Audit        audit.Config
+	BwAgreement  bwagreement.Config
+	Discovery    discovery.Config
+	GracefulExit gracefulexit.Config
+	Database     string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 }
 
 var (
@@ -141,6 +143,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		runCfg.Audit,
 		runCfg.BwAgreement,
 		runCfg.Discovery,
+		runCfg.GracefulExit,
 	)
 }
 
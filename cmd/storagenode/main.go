@@ -11,17 +11,21 @@ import (
 	"regexp"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/fpath"
 	"storj.io/storj/pkg/certificates"
 	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/gracefulexit"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/piecestore/psserver"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/process"
@@ -68,11 +72,22 @@ var (
 		Short: "Diagnostic Tool support",
 		RunE:  cmdDiag,
 	}
+	exitCmd = &cobra.Command{
+		Use:   "exit",
+		Short: "Exit from a satellite, transferring the stored pieces to other nodes",
+		RunE:  cmdExit,
+	}
 
 	runCfg   StorageNode
 	setupCfg StorageNode
 
 	diagCfg struct {
+		VerifyPieces bool `default:"false" help:"re-read all the stored pieces, quarantining the corrupt ones"`
+	}
+	exitCfg struct {
+		Server    server.Config
+		Storage   psserver.Config
+		Satellite string `help:"address of the satellite to exit from"`
 	}
 
 	defaultConfDir string
@@ -100,9 +115,11 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(diagCmd)
+	rootCmd.AddCommand(exitCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.BindSetup(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(exitCmd.Flags(), &exitCfg, cfgstruct.ConfDir(defaultConfDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -215,6 +232,10 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if diagCfg.VerifyPieces {
+		return verifyPieces(process.Ctx(cmd), db, filepath.Join(diagDir, "storage", "piece-store-data"))
+	}
+
 	//get all bandwidth aggrements entries already ordered
 	bwAgreements, err := db.GetBandwidthAllocations()
 	if err != nil {
@@ -285,6 +306,66 @@ func cmdDiag(cmd *cobra.Command, args []string) (err error) {
 	return err
 }
 
+// verifyPieces re-reads all the pieces stored in dataDir and indexed in db,
+// printing the ones found corrupted
+func verifyPieces(ctx context.Context, db *psdb.DB, dataDir string) error {
+	scrubber := psserver.NewScrubber(zap.L(), db, pstore.NewStorage(dataDir), 0, 0)
+	verified, corrupted, err := scrubber.VerifyAll(ctx)
+	fmt.Printf("Verified %d pieces, %d corrupted\n", verified, corrupted)
+	if err != nil {
+		return err
+	}
+
+	quarantined, err := db.GetQuarantinedPieces()
+	if err != nil {
+		fmt.Println("storage node 'quarantine' table read error")
+		return err
+	}
+	if len(quarantined) == 0 {
+		return nil
+	}
+
+	// list all the quarantined pieces, including the ones of the previous runs
+	const padding = 3
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintln(w, "PieceID\tDetected\tReason\t")
+	for _, piece := range quarantined {
+		fmt.Fprint(w, piece.ID, "\t", piece.Detected.Format(time.RFC3339), "\t", piece.Reason, "\t\n")
+	}
+	return w.Flush()
+}
+
+func cmdExit(cmd *cobra.Command, args []string) (err error) {
+	if exitCfg.Satellite == "" {
+		return errs.New("the address of the satellite to exit from is required")
+	}
+	ctx := process.Ctx(cmd)
+
+	identity, err := exitCfg.Server.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	storagePath := exitCfg.Storage.Path
+	db, err := psdb.Open(ctx, filepath.Join(storagePath, "piece-store-data"), filepath.Join(storagePath, "piecestore.db"))
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	pieces := psserver.New(zap.L(), filepath.Join(storagePath, "piece-store-data"), db, exitCfg.Storage, identity.Key)
+	exiter := gracefulexit.NewExiter(zap.L(), identity, pieces)
+
+	fmt.Printf("Exiting from satellite %s...\n", exitCfg.Satellite)
+	transferred, failed, err := exiter.Exit(ctx, exitCfg.Satellite)
+	fmt.Printf("Transferred pieces: %d, failed transfers: %d\n", transferred, failed)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Exit completed")
+	return nil
+}
+
 func isOperatorEmailValid(email string) error {
 	if email == "" {
 		return fmt.Errorf("Operator mail address isn't specified")
@@ -37,6 +37,10 @@ func catMain(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("No bucket specified, use format sj://bucket/")
 	}
 
+	if err := checkBucket(src); err != nil {
+		return err
+	}
+
 	dst, err := fpath.New("-")
 	if err != nil {
 		return err
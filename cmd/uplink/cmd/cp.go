@@ -23,7 +23,10 @@ import (
 )
 
 var (
-	progress *bool
+	progress        *bool
+	resumeUpload    *bool
+	recursiveCopy   *bool
+	copyParallelism *int
 )
 
 func init() {
@@ -32,11 +35,14 @@ func init() {
 		Short: "Copies a local file or Storj object to another location locally or in Storj",
 		RunE:  copyMain,
 	}, CLICmd)
-	progress = cpCmd.Flags().Bool("progress", true, "if true, show progress")
+	progress = cpCmd.Flags().Bool("progress", true, "if true, show progress (not shown for recursive copies)")
+	resumeUpload = cpCmd.Flags().Bool("resume", false, "if true, continue a previously interrupted upload from its last stored segment")
+	recursiveCopy = cpCmd.Flags().Bool("recursive", false, "if true, copy directories and prefixes recursively")
+	copyParallelism = cpCmd.Flags().Int("parallelism", 4, "number of files or objects to transfer concurrently when copying recursively")
 }
 
 // upload transfers src from local machine to s3 compatible object dst
-func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool) error {
+func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool, resume bool) error {
 	if !src.IsLocal() {
 		return fmt.Errorf("source must be local path: %s", src)
 	}
@@ -50,9 +56,24 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		dst = dst.Join(src.Base())
 	}
 
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return uploadFile(ctx, metainfo, streams, src, dst, showProgress, resume)
+}
+
+// uploadFile uploads the local file src to the s3 compatible object dst.
+// If resume is true, an interrupted upload of src to dst is continued
+// after the segments it already stored.
+func uploadFile(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, showProgress bool, resume bool) error {
 	var file *os.File
 	var err error
 	if src.Base() == "-" {
+		if resume {
+			return fmt.Errorf("cannot resume an upload from stdin")
+		}
 		file = os.Stdin
 	} else {
 		file, err = os.Open(src.Path())
@@ -71,29 +92,63 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		return fmt.Errorf("source cannot be a directory: %s", src)
 	}
 
-	metainfo, streams, err := cfg.Metainfo(ctx)
-	if err != nil {
-		return err
-	}
-
 	createInfo := storj.CreateObject{
 		RedundancyScheme: cfg.GetRedundancyScheme(),
 		EncryptionScheme: cfg.GetEncryptionScheme(),
 	}
+	if file != os.Stdin {
+		createInfo.Metadata = map[string]string{
+			mtimeKey: formatMtime(fileInfo.ModTime()),
+		}
+	}
 	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
 	if err != nil {
 		return convertError(err, dst)
 	}
 
+	if resume {
+		// nothing to continue if the previous upload completed
+		_, err = metainfo.GetObject(ctx, dst.Bucket(), dst.Path())
+		if err == nil {
+			resume = false
+		} else if !storj.ErrObjectNotFound.Has(err) {
+			return convertError(err, dst)
+		}
+	}
+
+	var offset int64
+	var mutableStream storj.MutableStream
+	if resume {
+		mutableStream, err = obj.ContinueStream(ctx)
+		if err != nil {
+			return err
+		}
+
+		offset = mutableStream.Info().Size
+		if offset > fileInfo.Size() {
+			return fmt.Errorf("the interrupted upload to %s is larger than %s", dst, src)
+		}
+
+		_, err = file.Seek(offset, io.SeekStart)
+		if err != nil {
+			return err
+		}
+	}
+
 	reader := io.Reader(file)
 	var bar *progressbar.ProgressBar
 	if showProgress {
 		bar = progressbar.New(int(fileInfo.Size())).SetUnits(progressbar.U_BYTES)
+		bar.Set(int(offset))
 		bar.Start()
 		reader = bar.NewProxyReader(reader)
 	}
 
-	err = uploadStream(ctx, streams, obj, reader)
+	if resume {
+		err = commitUpload(ctx, obj, stream.NewContinuedUpload(ctx, mutableStream, streams), reader)
+	} else {
+		err = uploadStream(ctx, streams, obj, reader)
+	}
 	if err != nil {
 		return err
 	}
@@ -113,11 +168,19 @@ func uploadStream(ctx context.Context, streams streams.Store, mutableObject stor
 		return err
 	}
 
-	upload := stream.NewUpload(ctx, mutableStream, streams)
+	return commitUpload(ctx, mutableObject, stream.NewUpload(ctx, mutableStream, streams), reader)
+}
+
+// commitUpload copies reader to upload and commits mutableObject
+func commitUpload(ctx context.Context, mutableObject storj.MutableObject, upload *stream.Upload, reader io.Reader) error {
+	_, err := io.Copy(upload, reader)
 
-	_, err = io.Copy(upload, reader)
+	err = utils.CombineErrors(err, upload.Close())
+	if err != nil {
+		return err
+	}
 
-	return utils.CombineErrors(err, upload.Close())
+	return mutableObject.Commit(ctx)
 }
 
 // download transfers s3 compatible object src to dst on local machine
@@ -135,6 +198,11 @@ func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgres
 		return err
 	}
 
+	return downloadObject(ctx, metainfo, streams, src, dst, showProgress)
+}
+
+// downloadObject downloads the s3 compatible object src to the local file dst
+func downloadObject(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, showProgress bool) error {
 	readOnlyStream, err := metainfo.GetObjectStream(ctx, src.Bucket(), src.Path())
 	if err != nil {
 		return convertError(err, src)
@@ -194,11 +262,21 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
 		return fmt.Errorf("destination must be Storj URL: %s", dst)
 	}
 
+	// if destination object name not specified, default to source object name
+	if strings.HasSuffix(dst.Path(), "/") {
+		dst = dst.Join(src.Base())
+	}
+
 	metainfo, streams, err := cfg.Metainfo(ctx)
 	if err != nil {
 		return err
 	}
 
+	return copyObject(ctx, metainfo, streams, src, dst, *progress)
+}
+
+// copyObject copies the s3 compatible object src to the s3 compatible object dst
+func copyObject(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, showProgress bool) error {
 	readOnlyStream, err := metainfo.GetObjectStream(ctx, src.Bucket(), src.Path())
 	if err != nil {
 		return convertError(err, src)
@@ -209,7 +287,7 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
 
 	var bar *progressbar.ProgressBar
 	var reader io.Reader
-	if *progress {
+	if showProgress {
 		bar = progressbar.New(int(readOnlyStream.Info().Size)).SetUnits(progressbar.U_BYTES)
 		bar.Start()
 		reader = bar.NewProxyReader(download)
@@ -217,11 +295,6 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
 		reader = download
 	}
 
-	// if destination object name not specified, default to source object name
-	if strings.HasSuffix(dst.Path(), "/") {
-		dst = dst.Join(src.Base())
-	}
-
 	createInfo := storj.CreateObject{
 		RedundancyScheme: cfg.GetRedundancyScheme(),
 		EncryptionScheme: cfg.GetEncryptionScheme(),
@@ -271,9 +344,23 @@ func copyMain(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("At least one of the source or the desination must be a Storj URL")
 	}
 
+	if err := checkBucket(src, dst); err != nil {
+		return err
+	}
+
+	if *recursiveCopy {
+		// the files are copied concurrently, with no single progress to show
+		if *progress && cmd.Flags().Changed("progress") {
+			return fmt.Errorf("Progress is not supported for recursive copies")
+		}
+		return syncTrees(ctx, src, dst, syncOptions{
+			Parallelism: *copyParallelism,
+		})
+	}
+
 	// if uploading
 	if src.IsLocal() {
-		return upload(ctx, src, dst, *progress)
+		return upload(ctx, src, dst, *progress, *resumeUpload)
 	}
 
 	// if downloading
@@ -11,12 +11,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/miniogw"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/storj"
 )
 
 var (
 	recursiveFlag *bool
+	pendingFlag   *bool
+	longFlag      *bool
 )
 
 func init() {
@@ -26,6 +29,8 @@ func init() {
 		RunE:  list,
 	}, CLICmd)
 	recursiveFlag = lsCmd.Flags().Bool("recursive", false, "if true, list recursively")
+	pendingFlag = lsCmd.Flags().Bool("pending", false, "if true, list pending objects, whose upload is in progress or was interrupted")
+	longFlag = lsCmd.Flags().Bool("long", false, "if true, list the checksums of the objects")
 }
 
 func list(cmd *cobra.Command, args []string) error {
@@ -46,6 +51,10 @@ func list(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("No bucket specified, use format sj://bucket/")
 		}
 
+		if err := checkBucket(src); err != nil {
+			return err
+		}
+
 		err = listFiles(ctx, metainfo, src, false)
 
 		return convertError(err, src)
@@ -59,19 +68,22 @@ func list(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		if len(list.Items) > 0 {
+		for _, bucket := range list.Items {
+			// the bucket keeping the multipart uploads of the gateway is hidden
+			if bucket.Name == miniogw.MultipartBucket {
+				continue
+			}
 			noBuckets = false
-			for _, bucket := range list.Items {
-				fmt.Println("BKT", formatTime(bucket.Created), bucket.Name)
-				if *recursiveFlag {
-					prefix, err := fpath.New(fmt.Sprintf("sj://%s/", bucket.Name))
-					if err != nil {
-						return err
-					}
-					err = listFiles(ctx, metainfo, prefix, true)
-					if err != nil {
-						return err
-					}
+
+			fmt.Println("BKT", formatTime(bucket.Created), bucket.Name)
+			if *recursiveFlag {
+				prefix, err := fpath.New(fmt.Sprintf("sj://%s/", bucket.Name))
+				if err != nil {
+					return err
+				}
+				err = listFiles(ctx, metainfo, prefix, true)
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -91,8 +103,13 @@ func list(cmd *cobra.Command, args []string) error {
 func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath, prependBucket bool) error {
 	startAfter := ""
 
+	listObjects := metainfo.ListObjects
+	if *pendingFlag {
+		listObjects = metainfo.ListPendingObjects
+	}
+
 	for {
-		list, err := metainfo.ListObjects(ctx, prefix.Bucket(), storj.ListOptions{
+		list, err := listObjects(ctx, prefix.Bucket(), storj.ListOptions{
 			Direction: storj.After,
 			Cursor:    startAfter,
 			Prefix:    prefix.Path(),
@@ -109,6 +126,10 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 			}
 			if object.IsPrefix {
 				fmt.Println("PRE", path)
+			} else if *pendingFlag {
+				fmt.Printf("%v %v %v\n", "PND", formatTime(object.Modified), path)
+			} else if *longFlag {
+				fmt.Printf("%v %v %12v %-34v %v\n", "OBJ", formatTime(object.Modified), object.Size, formatChecksum(object.Stream), path)
 			} else {
 				fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.Modified), object.Size, path)
 			}
@@ -127,3 +148,14 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 func formatTime(t time.Time) string {
 	return t.Local().Format("2006-01-02 15:04:05")
 }
+
+// formatChecksum formats the checksum of a stream like an S3 ETag
+func formatChecksum(stream storj.Stream) string {
+	if len(stream.Checksum) == 0 {
+		return "-"
+	}
+	if stream.ChecksumParts > 0 {
+		return fmt.Sprintf("%x-%d", stream.Checksum, stream.ChecksumParts)
+	}
+	return fmt.Sprintf("%x", stream.Checksum)
+}
@@ -41,6 +41,10 @@ func makeBucket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Nested buckets not supported, use format sj://bucket/")
 	}
 
+	if err := checkBucket(dst); err != nil {
+		return err
+	}
+
 	metainfo, _, err := cfg.Metainfo(ctx)
 	if err != nil {
 		return err
@@ -53,7 +57,7 @@ func makeBucket(cmd *cobra.Command, args []string) error {
 	if !storj.ErrBucketNotFound.Has(err) {
 		return err
 	}
-	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &storj.Bucket{PathCipher: storj.Cipher(cfg.Enc.PathType)})
+	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &storj.Bucket{PathCipher: cfg.Enc.PathCipher()})
 	if err != nil {
 		return err
 	}
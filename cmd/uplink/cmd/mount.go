@@ -59,6 +59,10 @@ func mountBucket(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("No bucket specified. Use format sj://bucket/")
 	}
 
+	if err := checkBucket(src); err != nil {
+		return err
+	}
+
 	bucket, err := metainfo.GetBucket(ctx, src.Bucket())
 	if err != nil {
 		return convertError(err, src)
@@ -208,9 +212,18 @@ func (sf *storjFS) Mkdir(name string, mode uint32, context *fuse.Context) fuse.S
 	}
 
 	upload := stream.NewUpload(sf.ctx, mutableStream, sf.streams)
-	defer utils.LogClose(upload)
 
 	_, err = upload.Write(nil)
+	err = utils.CombineErrors(err, upload.Close())
+	if err != nil {
+		// the upload cannot be continued, so its stored segments are deleted
+		if err := object.DeleteStream(sf.ctx); err != nil {
+			zap.S().Errorf("error during deleting stream: %v", err)
+		}
+		return fuse.EIO
+	}
+
+	err = object.Commit(sf.ctx)
 	if err != nil {
 		return fuse.EIO
 	}
@@ -451,11 +464,20 @@ func (f *storjFile) closeReader() {
 
 func (f *storjFile) closeWriter() {
 	if f.writer != nil {
-		utils.LogClose(f.writer)
 		f.FS.removeCreatedFile(f.name)
-		err := f.mutableObject.Commit(f.ctx)
+		err := f.writer.Close()
 		if err != nil {
-			zap.S().Errorf("error during commiting data: %v", err)
+			zap.S().Errorf("error during uploading data: %v", err)
+			// the upload cannot be continued, so its stored segments are deleted
+			err = f.mutableObject.DeleteStream(f.ctx)
+			if err != nil {
+				zap.S().Errorf("error during deleting stream: %v", err)
+			}
+		} else {
+			err = f.mutableObject.Commit(f.ctx)
+			if err != nil {
+				zap.S().Errorf("error during commiting data: %v", err)
+			}
 		}
 		f.writer = nil
 	}
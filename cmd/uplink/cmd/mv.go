@@ -0,0 +1,71 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+)
+
+func init() {
+	addCmd(&cobra.Command{
+		Use:   "mv",
+		Short: "Moves a Storj object to another location in Storj",
+		RunE:  moveObject,
+	}, CLICmd)
+}
+
+// moveObject is the function executed when mvCmd is called
+func moveObject(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified to move")
+	}
+	if len(args) == 1 {
+		return fmt.Errorf("No destination specified")
+	}
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	dst, err := fpath.New(args[1])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() || dst.IsLocal() {
+		return fmt.Errorf("Both the source and the destination must be Storj URLs")
+	}
+
+	if err := checkBucket(src, dst); err != nil {
+		return err
+	}
+
+	// if destination object name not specified, default to source object name
+	if strings.HasSuffix(dst.String(), "/") || dst.Path() == "" {
+		dst = dst.Join(src.Base())
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = metainfo.MoveObject(ctx, src.Bucket(), src.Path(), dst.Bucket(), dst.Path())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	fmt.Printf("Moved %s to %s\n", src, dst)
+
+	return nil
+}
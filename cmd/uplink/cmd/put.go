@@ -37,10 +37,14 @@ func putMain(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("No bucket specified, use format sj://bucket/")
 	}
 
+	if err := checkBucket(dst); err != nil {
+		return err
+	}
+
 	src, err := fpath.New("-")
 	if err != nil {
 		return err
 	}
 
-	return upload(ctx, src, dst, false)
+	return upload(ctx, src, dst, false, false)
 }
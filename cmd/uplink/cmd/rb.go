@@ -41,6 +41,10 @@ func deleteBucket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Nested buckets not supported, use format sj://bucket/")
 	}
 
+	if err := checkBucket(dst); err != nil {
+		return err
+	}
+
 	metainfo, _, err := cfg.Metainfo(ctx)
 	if err != nil {
 		return err
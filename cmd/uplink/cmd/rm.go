@@ -36,6 +36,10 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("No bucket specified, use format sj://bucket/")
 	}
 
+	if err := checkBucket(dst); err != nil {
+		return err
+	}
+
 	metainfo, _, err := cfg.Metainfo(ctx)
 	if err != nil {
 		return err
@@ -59,6 +59,14 @@ func (c *Config) Metainfo(ctx context.Context) (storj.Metainfo, streams.Store, e
 		return nil, nil, err
 	}
 
+	if c.Access == "" && c.Enc.NeedsPassphrase() && c.Enc.Passphrase == "" {
+		passphrase, err := readPassphrase(false)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Enc.Passphrase = string(passphrase)
+	}
+
 	return c.GetMetainfo(ctx, identity)
 }
 
@@ -73,3 +81,14 @@ func convertError(err error, path fpath.FPath) error {
 
 	return err
 }
+
+// checkBucket returns an error if a Storj path is in the bucket keeping the
+// multipart uploads of the gateway, which is changed only by the gateway
+func checkBucket(paths ...fpath.FPath) error {
+	for _, path := range paths {
+		if !path.IsLocal() && path.Bucket() == miniogw.MultipartBucket {
+			return fmt.Errorf("Bucket reserved for the gateway: %s", path.Bucket())
+		}
+	}
+	return nil
+}
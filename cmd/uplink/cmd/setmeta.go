@@ -0,0 +1,99 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+)
+
+var (
+	setmetaContentType *string
+	setmetaReplace     *bool
+)
+
+func init() {
+	setmetaCmd := addCmd(&cobra.Command{
+		Use:   "setmeta",
+		Short: "Updates the metadata of an object: setmeta sj://bucket/path [key=value ...]",
+		RunE:  setMetadata,
+	}, CLICmd)
+	setmetaContentType = setmetaCmd.Flags().String("content-type", "", "if set, replace the content type of the object")
+	setmetaReplace = setmetaCmd.Flags().Bool("replace", false, "if true, remove the existing metadata keys not specified")
+}
+
+// setMetadata is the function executed when setmetaCmd is called
+func setMetadata(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified")
+	}
+
+	dst, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if dst.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	if err := checkBucket(dst); err != nil {
+		return err
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	object, err := metainfo.ModifyObject(ctx, dst.Bucket(), dst.Path())
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	info := object.Info()
+
+	metadata := make(map[string]string)
+	if !*setmetaReplace {
+		for key, value := range info.Metadata {
+			metadata[key] = value
+		}
+	}
+
+	// an empty value removes the key
+	for _, arg := range args[1:] {
+		pair := strings.SplitN(arg, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return fmt.Errorf("Invalid metadata %q, use format key=value", arg)
+		}
+		if pair[1] == "" {
+			delete(metadata, pair[0])
+			continue
+		}
+		metadata[pair[0]] = pair[1]
+	}
+
+	contentType := info.ContentType
+	if *setmetaContentType != "" {
+		contentType = *setmetaContentType
+	}
+
+	object.SetMetadata(contentType, metadata)
+
+	err = object.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated metadata of %s\n", dst)
+
+	return nil
+}
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"storj.io/storj/internal/fpath"
 	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/miniogw"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/provider"
@@ -32,14 +34,17 @@ var (
 		Overwrite          bool                         `default:"false" help:"whether to overwrite pre-existing configuration files" setup:"true"`
 		APIKey             string                       `default:"" help:"the api key to use for the satellite" setup:"true"`
 		EncKey             string                       `default:"" help:"your root encryption key" setup:"true"`
+		EncKeyFile         string                       `default:"" help:"store the root encryption key, enc-key or a generated one if empty, encrypted with a passphrase in this file instead of in the config file" setup:"true"`
+		EncDeriveKey       bool                         `default:"false" help:"derive the root encryption key from a passphrase, with a random salt and a check value of the key stored in the config file" setup:"true"`
 		GenerateMinioCerts bool                         `default:"false" help:"generate sample TLS certs for Minio GW" setup:"true"`
 		SatelliteAddr      string                       `default:"localhost:7778" help:"the address to use for the satellite" setup:"true"`
 
-		Server miniogw.ServerConfig
-		Minio  miniogw.MinioConfig
-		Client miniogw.ClientConfig
-		RS     miniogw.RSConfig
-		Enc    miniogw.EncryptionConfig
+		Server      miniogw.ServerConfig
+		Minio       miniogw.MinioConfig
+		Client      miniogw.ClientConfig
+		RS          miniogw.RSConfig
+		Enc         miniogw.EncryptionConfig
+		Compression miniogw.CompressionConfig
 	}
 
 	cliConfDir *string
@@ -127,12 +132,87 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 		"client.overlay-addr":    setupCfg.SatelliteAddr,
 		"minio.access-key":       accessKey,
 		"minio.secret-key":       secretKey,
-		"enc.key":                setupCfg.EncKey,
+		// the passphrase is never saved in the config file
+		"enc.passphrase": "",
+	}
+
+	keyConfig, err := setupKey(setupDir)
+	if err != nil {
+		return err
+	}
+	for key, value := range keyConfig {
+		o[key] = value
 	}
 
 	return process.SaveConfig(cmd.Flags(), filepath.Join(setupDir, "config.yaml"), o)
 }
 
+// setupKey returns the config values of the root encryption key, which is
+// stored encrypted in a key file or derived from a passphrase if requested
+func setupKey(setupDir string) (map[string]interface{}, error) {
+	if setupCfg.EncKeyFile == "" && !setupCfg.EncDeriveKey {
+		return map[string]interface{}{"enc.key": setupCfg.EncKey}, nil
+	}
+	if setupCfg.EncKeyFile != "" && setupCfg.EncDeriveKey {
+		return nil, fmt.Errorf("enc-key-file and enc-derive-key cannot be used together")
+	}
+	if setupCfg.EncDeriveKey && setupCfg.EncKey != "" {
+		return nil, fmt.Errorf("enc-key cannot be imported into a key derived from a passphrase")
+	}
+
+	passphrase := []byte(setupCfg.Enc.Passphrase)
+	if len(passphrase) == 0 {
+		var err error
+		passphrase, err = readPassphrase(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the key derived from the passphrase needs only its salt, and its
+	// check value to detect a wrong passphrase
+	if setupCfg.EncDeriveKey {
+		salt := make([]byte, encryption.KeySaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		key, err := encryption.DeriveKeyFromPassphrase(passphrase, salt)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"enc.key":       "",
+			"enc.key-salt":  hex.EncodeToString(salt),
+			"enc.key-check": hex.EncodeToString(encryption.KeyCheckValue(key)),
+		}, nil
+	}
+
+	// import the given key, or generate a random one
+	key, err := miniogw.EncryptionConfig{Key: setupCfg.EncKey}.RootKey()
+	if err != nil {
+		return nil, err
+	}
+	if setupCfg.EncKey == "" {
+		if _, err := rand.Read(key[:]); err != nil {
+			return nil, err
+		}
+	}
+
+	keyFile, err := encryption.NewKeyFile(key, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	path := setupCfg.EncKeyFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(setupDir, path)
+	}
+	err = encryption.SaveKeyFile(path, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"enc.key": "", "enc.key-file": path}, nil
+}
+
 func generateAWSKey() (key string, err error) {
 	var buf [20]byte
 	_, err = rand.Read(buf[:])
@@ -0,0 +1,67 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+func init() {
+	addCmd(&cobra.Command{
+		Use:   "share",
+		Short: "Print an access to the paths under a prefix for another user, to use with --access",
+		RunE:  shareAccess,
+	}, CLICmd)
+}
+
+func shareAccess(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No prefix specified for sharing")
+	}
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return fmt.Errorf("No prefix specified, use format sj://bucket/prefix/")
+	}
+
+	if err := checkBucket(src); err != nil {
+		return err
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, src.Bucket())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	access, err := cfg.ShareAccess(storj.JoinPaths(src.Bucket(), src.Path()), bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := access.Serialize()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(serialized)
+
+	return nil
+}
@@ -0,0 +1,387 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	syncDelete      *bool
+	syncParallelism *int
+)
+
+func init() {
+	syncCmd := addCmd(&cobra.Command{
+		Use:   "sync",
+		Short: "Synchronizes a local directory with a Storj prefix or a Storj prefix with a local directory",
+		RunE:  syncMain,
+	}, CLICmd)
+	syncDelete = syncCmd.Flags().Bool("delete", false, "if true, delete files or objects in the destination that do not exist in the source")
+	syncParallelism = syncCmd.Flags().Int("parallelism", 4, "number of files or objects to transfer concurrently")
+}
+
+// mtimeKey is the metadata key holding the modification time of the local
+// file an object was uploaded from
+const mtimeKey = "mtime"
+
+// syncOptions controls how syncTrees transfers a tree
+type syncOptions struct {
+	// OnlyChanged skips entries that already exist in the destination with
+	// the same size and a modification time not older than the source, to
+	// the second.
+	OnlyChanged bool
+	// Delete removes entries from the destination that are not in the source.
+	Delete bool
+	// Parallelism is the number of concurrent transfers.
+	Parallelism int
+}
+
+// syncEntry is a single file or object found while walking a tree. The
+// modification time of an object is the one of the file it was uploaded
+// from, when it is recorded in its metadata.
+type syncEntry struct {
+	Size     int64
+	Modified time.Time
+}
+
+// changed returns whether dst needs to be overwritten with src. The
+// modification times are compared to the second, as some file systems
+// do not keep them more precisely.
+func (src syncEntry) changed(dst syncEntry) bool {
+	return src.Size != dst.Size || src.Modified.Truncate(time.Second).After(dst.Modified.Truncate(time.Second))
+}
+
+// formatMtime formats a modification time for the object metadata
+func formatMtime(mtime time.Time) string {
+	return mtime.UTC().Format(time.RFC3339Nano)
+}
+
+// objectEntry returns the sync entry of an object
+func objectEntry(object storj.Object) syncEntry {
+	entry := syncEntry{
+		Size:     object.Size,
+		Modified: object.Modified,
+	}
+	if mtime, err := time.Parse(time.RFC3339Nano, object.Metadata[mtimeKey]); err == nil {
+		entry.Modified = mtime
+	}
+	return entry
+}
+
+// syncMain is the function executed when syncCmd is called
+func syncMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("No source specified for sync")
+	}
+	if len(args) == 1 {
+		return fmt.Errorf("No destination specified")
+	}
+
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	dst, err := fpath.New(args[1])
+	if err != nil {
+		return err
+	}
+
+	if !src.IsLocal() && !dst.IsLocal() {
+		return fmt.Errorf("Sync between Storj URLs is not supported")
+	}
+
+	if err := checkBucket(src, dst); err != nil {
+		return err
+	}
+
+	return syncTrees(ctx, src, dst, syncOptions{
+		OnlyChanged: true,
+		Delete:      *syncDelete,
+		Parallelism: *syncParallelism,
+	})
+}
+
+// syncTrees transfers all files or objects under src to dst,
+// where one of them is a local directory and the other a Storj prefix.
+func syncTrees(ctx context.Context, src fpath.FPath, dst fpath.FPath, options syncOptions) error {
+	if src.IsLocal() && dst.IsLocal() {
+		return fmt.Errorf("At least one of the source or the destination must be a Storj URL")
+	}
+	if !src.IsLocal() && !dst.IsLocal() {
+		return fmt.Errorf("Recursive copy between Storj URLs is not supported")
+	}
+	if options.Parallelism <= 0 {
+		options.Parallelism = 1
+	}
+
+	if src.IsLocal() {
+		fileInfo, err := os.Stat(src.Path())
+		if err != nil {
+			return err
+		}
+		if !fileInfo.IsDir() {
+			return fmt.Errorf("source must be a directory: %s", src)
+		}
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	srcEntries, err := listTree(ctx, metainfo, src)
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	dstEntries := map[string]syncEntry{}
+	if options.OnlyChanged || options.Delete {
+		dstEntries, err = listTree(ctx, metainfo, dst)
+		if err != nil {
+			return convertError(err, dst)
+		}
+	}
+
+	var transfers []string
+	for name, srcEntry := range srcEntries {
+		dstEntry, exists := dstEntries[name]
+		if options.OnlyChanged && exists && !srcEntry.changed(dstEntry) {
+			continue
+		}
+		transfers = append(transfers, name)
+	}
+	sort.Strings(transfers)
+
+	var removals []string
+	if options.Delete {
+		for name := range dstEntries {
+			if _, exists := srcEntries[name]; !exists {
+				removals = append(removals, name)
+			}
+		}
+		sort.Strings(removals)
+	}
+
+	err = forEachParallel(ctx, options.Parallelism, transfers, func(name string) error {
+		if src.IsLocal() {
+			return uploadFile(ctx, metainfo, streams, joinTree(src, name), joinTree(dst, name), false, false)
+		}
+		return syncDownload(ctx, metainfo, streams, joinTree(src, name), joinTree(dst, name), srcEntries[name])
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachParallel(ctx, options.Parallelism, removals, func(name string) error {
+		target := joinTree(dst, name)
+		if target.IsLocal() {
+			err := os.Remove(target.Path())
+			if err != nil {
+				return err
+			}
+		} else {
+			err := metainfo.DeleteObject(ctx, target.Bucket(), target.Path())
+			if err != nil {
+				return convertError(err, target)
+			}
+		}
+		fmt.Printf("Deleted %s\n", target)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the local tree has no directories left without files, like the prefix
+	if dst.IsLocal() {
+		return removeEmptyDirs(dst.Path(), removals)
+	}
+	return nil
+}
+
+// removeEmptyDirs removes the directories of the removed files under root,
+// which are left empty, from the deepest ones up. root itself is kept.
+func removeEmptyDirs(root string, removed []string) error {
+	dirs := map[string]bool{}
+	for _, name := range removed {
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	// a directory sorts before its subdirectories, which are removed first
+	// in the reverse order
+	sort.Sort(sort.Reverse(sort.StringSlice(sorted)))
+
+	for _, dir := range sorted {
+		dir = filepath.Join(root, filepath.FromSlash(dir))
+		infos, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(infos) > 0 {
+			continue
+		}
+		err = os.Remove(dir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncDownload downloads src to dst, creating the missing parent directories,
+// and sets the modification time of dst to the one of the object.
+func syncDownload(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src fpath.FPath, dst fpath.FPath, entry syncEntry) error {
+	err := os.MkdirAll(filepath.Dir(dst.Path()), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = downloadObject(ctx, metainfo, streams, src, dst, false)
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(dst.Path(), time.Now(), entry.Modified)
+}
+
+// joinTree joins the slash separated name, relative to the root of the tree
+func joinTree(root fpath.FPath, name string) fpath.FPath {
+	if root.IsLocal() {
+		return root.Join(filepath.FromSlash(name))
+	}
+	return root.Join(name)
+}
+
+// listTree returns all files under a local directory or all objects under
+// a Storj prefix, keyed by their slash separated path relative to root.
+func listTree(ctx context.Context, metainfo storj.Metainfo, root fpath.FPath) (map[string]syncEntry, error) {
+	if root.IsLocal() {
+		return listLocalTree(root.Path())
+	}
+	return listRemoteTree(ctx, metainfo, root)
+}
+
+func listLocalTree(root string) (map[string]syncEntry, error) {
+	entries := map[string]syncEntry{}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		entries[filepath.ToSlash(name)] = syncEntry{
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+func listRemoteTree(ctx context.Context, metainfo storj.Metainfo, root fpath.FPath) (map[string]syncEntry, error) {
+	entries := map[string]syncEntry{}
+
+	options := storj.ListOptions{
+		Direction: storj.After,
+		Prefix:    root.Path(),
+		Recursive: true,
+	}
+
+	for {
+		list, err := metainfo.ListObjects(ctx, root.Bucket(), options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range list.Items {
+			if object.IsPrefix {
+				continue
+			}
+			entries[object.Path] = objectEntry(object)
+		}
+
+		if !list.More {
+			break
+		}
+
+		options = options.NextPage(list)
+		options.Recursive = true
+	}
+
+	return entries, nil
+}
+
+// forEachParallel calls fn for every name, running at most parallelism calls
+// concurrently, and returns all the errors that happened. No more calls are
+// started once ctx is canceled.
+func forEachParallel(ctx context.Context, parallelism int, names []string, fn func(name string) error) error {
+	var mu sync.Mutex
+	var group errs.Group
+
+	limiter := sync2.NewLimiter(parallelism)
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		name := name
+		started := limiter.Go(ctx, func() {
+			err := fn(name)
+			if err != nil {
+				mu.Lock()
+				group.Add(fmt.Errorf("%s: %v", name, err))
+				mu.Unlock()
+			}
+		})
+		if !started {
+			break
+		}
+	}
+	limiter.Wait()
+
+	group.Add(ctx.Err())
+
+	return group.Err()
+}
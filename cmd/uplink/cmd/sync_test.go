@@ -0,0 +1,194 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestListLocalTree(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	root := filepath.Join(ctx.Dir(), "tree")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir", "sub"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "empty"), 0755))
+
+	mtime := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	for name, data := range map[string]string{
+		"a":             "a",
+		"dir/b":         "bb",
+		"dir/sub/c.txt": "ccc",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+
+	entries, err := listLocalTree(root)
+	require.NoError(t, err)
+
+	// the directories are not listed and the names are slash separated
+	assert.Len(t, entries, 3)
+	for name, size := range map[string]int64{"a": 1, "dir/b": 2, "dir/sub/c.txt": 3} {
+		entry, ok := entries[name]
+		if assert.True(t, ok, name) {
+			assert.Equal(t, size, entry.Size, name)
+			assert.True(t, mtime.Equal(entry.Modified), name)
+		}
+	}
+
+	// a missing root is an empty tree
+	entries, err = listLocalTree(filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 0)
+}
+
+func TestRemoveEmptyDirs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	root := filepath.Join(ctx.Dir(), "tree")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir", "sub", "subsub"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "kept", "sub"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "empty"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "kept", "b"), []byte("b"), 0644))
+
+	// the files were removed from dir/sub/subsub, dir and kept/sub
+	removed := []string{"dir/sub/subsub/a", "dir/c", "kept/sub/d", "e"}
+	require.NoError(t, removeEmptyDirs(root, removed))
+
+	for _, name := range []string{"dir/sub/subsub", "dir/sub", "dir", "kept/sub"} {
+		_, err := os.Stat(filepath.Join(root, filepath.FromSlash(name)))
+		assert.True(t, os.IsNotExist(err), name)
+	}
+	// the directories with files, the ones not emptied by the removals
+	// and the root are kept
+	for _, name := range []string{"kept", "empty", "."} {
+		_, err := os.Stat(filepath.Join(root, filepath.FromSlash(name)))
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestSyncEntryChanged(t *testing.T) {
+	now := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i, tt := range []struct {
+		src, dst syncEntry
+		changed  bool
+	}{
+		{syncEntry{1, now}, syncEntry{1, now}, false},
+		{syncEntry{1, now}, syncEntry{2, now}, true},
+		{syncEntry{1, now.Add(time.Second)}, syncEntry{1, now}, true},
+		{syncEntry{1, now}, syncEntry{1, now.Add(time.Second)}, false},
+		// the times are compared to the second
+		{syncEntry{1, now.Add(500 * time.Millisecond)}, syncEntry{1, now}, false},
+		{syncEntry{1, now.Add(1500 * time.Millisecond)}, syncEntry{1, now.Add(999 * time.Millisecond)}, true},
+	} {
+		assert.Equal(t, tt.changed, tt.src.changed(tt.dst), "test case #%d", i)
+	}
+}
+
+func TestObjectEntry(t *testing.T) {
+	uploaded := time.Date(2019, 2, 3, 4, 5, 6, 0, time.UTC)
+	mtime := time.Date(2019, 1, 2, 3, 4, 5, 123456789, time.Local)
+
+	object := storj.Object{
+		Modified: uploaded,
+		Stream:   storj.Stream{Size: 10},
+	}
+
+	// the upload time is used when the object has no recorded mtime
+	entry := objectEntry(object)
+	assert.Equal(t, int64(10), entry.Size)
+	assert.True(t, uploaded.Equal(entry.Modified))
+
+	object.Metadata = map[string]string{mtimeKey: formatMtime(mtime)}
+	entry = objectEntry(object)
+	assert.True(t, mtime.Equal(entry.Modified))
+
+	object.Metadata = map[string]string{mtimeKey: "invalid"}
+	entry = objectEntry(object)
+	assert.True(t, uploaded.Equal(entry.Modified))
+}
+
+func TestForEachParallel(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	var mu sync.Mutex
+	var called []string
+	var running, maxRunning int
+
+	err := forEachParallel(ctx, 3, names, func(name string) error {
+		mu.Lock()
+		called = append(called, name)
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+
+	sort.Strings(called)
+	assert.Equal(t, names, called)
+	assert.True(t, maxRunning <= 3, "ran %d calls concurrently", maxRunning)
+
+	// all the calls are made, and their errors returned with their names
+	called = nil
+	err = forEachParallel(ctx, 2, names, func(name string) error {
+		mu.Lock()
+		called = append(called, name)
+		mu.Unlock()
+		if name == "b" || name == "e" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "b: failed")
+		assert.Contains(t, err.Error(), "e: failed")
+	}
+	assert.Len(t, called, len(names))
+
+	// no calls are started once the context is canceled
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	called = nil
+	err = forEachParallel(canceled, 2, names, func(name string) error {
+		mu.Lock()
+		called = append(called, name)
+		mu.Unlock()
+		return nil
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), context.Canceled.Error())
+	}
+	assert.Len(t, called, 0)
+}
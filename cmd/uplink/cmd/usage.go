@@ -0,0 +1,60 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+)
+
+func init() {
+	addCmd(&cobra.Command{
+		Use:   "usage [sj://BUCKET]",
+		Short: "Show the storage and bandwidth usage of all buckets or a bucket",
+		RunE:  showUsage,
+	}, CLICmd)
+}
+
+func showUsage(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	var src fpath.FPath
+	if len(args) > 0 {
+		var err error
+		src, err = fpath.New(args[0])
+		if err != nil {
+			return err
+		}
+
+		if src.IsLocal() {
+			return fmt.Errorf("No bucket specified, use format sj://bucket/")
+		}
+
+		if src.Path() != "" {
+			return fmt.Errorf("Nested buckets not supported, use format sj://bucket/")
+		}
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	usage, err := metainfo.GetUsage(ctx, src.Bucket())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	fmt.Printf("Stored bytes: %d\n", usage.StoredBytes)
+	fmt.Printf("Objects:      %d\n", usage.Objects)
+	fmt.Printf("Segments:     %d\n", usage.Segments)
+	// the bandwidth is not used by bucket
+	fmt.Printf("Egress bytes: %d\n", usage.EgressBytes)
+
+	return nil
+}
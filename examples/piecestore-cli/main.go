@@ -9,9 +9,13 @@ import (
 	"io"
 	"os"
 
+	"path/filepath"
+
 	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/process"
 )
 
@@ -60,17 +64,26 @@ var storeMain = &cobra.Command{
 			return fmt.Errorf("Path (%s) is a directory, not a file", path)
 		}
 
-		dataFileChunk, err := pstore.StoreWriter(id, outputDir)
-		if err != nil {
+		if err := pstore.CheckID(id); err != nil {
 			return err
 		}
 
-		// Close when finished
-		defer printError(dataFileChunk.Close)
+		db, err := openDB(outputDir)
+		if err != nil {
+			return err
+		}
+		defer printError(db.Close)
 
-		_, err = io.Copy(dataFileChunk, file)
+		storage := pstore.NewStorage(outputDir)
+		piece, _, err := storage.Store(context.Background(), nil, file, 0)
+		if err != nil {
+			return err
+		}
 
-		return err
+		if err := db.AddPiece(id, piece); err != nil {
+			return errs.Combine(err, storage.Delete(context.Background(), piece))
+		}
+		return nil
 	},
 }
 
@@ -93,7 +106,18 @@ var retrieveMain = &cobra.Command{
 			return fmt.Errorf("Path (%s) is a file, not a directory", path)
 		}
 
-		dataFileChunk, err := pstore.RetrieveReader(context.Background(), id, 0, -1, path)
+		db, err := openDB(path)
+		if err != nil {
+			return err
+		}
+		defer printError(db.Close)
+
+		piece, err := db.GetPiece(id)
+		if err != nil {
+			return err
+		}
+
+		dataFileChunk, err := pstore.NewStorage(path).RetrieveReader(context.Background(), piece, 0, -1)
 		if err != nil {
 			return err
 		}
@@ -115,10 +139,30 @@ var deleteMain = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 		directory := args[1]
-		return pstore.Delete(id, directory)
+
+		db, err := openDB(directory)
+		if err != nil {
+			return err
+		}
+		defer printError(db.Close)
+
+		piece, err := db.GetPiece(id)
+		if err != nil {
+			return err
+		}
+
+		if err := db.DeletePiece(id); err != nil {
+			return err
+		}
+		return pstore.NewStorage(directory).Delete(context.Background(), piece)
 	},
 }
 
+// openDB opens the database indexing the pieces stored in dir
+func openDB(dir string) (*psdb.DB, error) {
+	return psdb.Open(context.Background(), dir, filepath.Join(dir, "piecestore.db"))
+}
+
 func printError(fn func() error) {
 	err := fn()
 	if err != nil {
@@ -111,7 +111,7 @@ func main() {
 				Data: serializedAllocation,
 			}
 
-			if err := psClient.Put(context.Background(), id, dataSection, ttl, pba, nil); err != nil {
+			if err := psClient.Put(context.Background(), id, dataSection, ttl, pba, nil, 0); err != nil {
 				fmt.Printf("Failed to Store data of id: %s\n", id)
 				return err
 			}
@@ -180,7 +180,7 @@ func main() {
 				Data: serializedAllocation,
 			}
 
-			rr, err := psClient.Get(ctx, psclient.PieceID(id), pieceInfo.PieceSize, pba, nil)
+			rr, err := psClient.Get(ctx, psclient.PieceID(id), pieceInfo.PieceSize, pba, nil, nil)
 			if err != nil {
 				fmt.Printf("Failed to retrieve file of id: %s\n", id)
 				errRemove := os.Remove(outputDir)
@@ -21,8 +21,22 @@ type DB interface {
 // Error is the default migrate errs class
 var Error = errs.Class("migrate")
 
+// Migration upgrades a database created with an old schema
+type Migration struct {
+	// Schema is the old schema the database was created with
+	Schema string
+	// Migrate upgrades the database from Schema to the current schema
+	Migrate func(tx *sql.Tx) error
+}
+
 // Create with a previous schema check
 func Create(identifier string, db DB) error {
+	return CreateOrMigrate(identifier, db)
+}
+
+// CreateOrMigrate works like Create, except that a database created with
+// the old schema of one of the migrations is upgraded to the current schema
+func CreateOrMigrate(identifier string, db DB, migrations ...Migration) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return Error.Wrap(err)
@@ -59,6 +73,24 @@ func Create(identifier string, db DB) error {
 	}
 
 	if schema != previousSchema {
+		for _, migration := range migrations {
+			if migration.Schema != previousSchema {
+				continue
+			}
+
+			err := migration.Migrate(tx)
+			if err != nil {
+				return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+			}
+
+			_, err = tx.Exec(db.Rebind(`UPDATE table_schemas SET schemaText = ? WHERE id = ?;`), schema, identifier)
+			if err != nil {
+				return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+			}
+
+			return Error.Wrap(tx.Commit())
+		}
+
 		err := Error.New("schema mismatch:\nold %v\nnew %v", previousSchema, schema)
 		return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
 	}
@@ -9,8 +9,6 @@ import (
 	"math/big"
 	"sync"
 
-	"github.com/vivint/infectious"
-
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
@@ -85,7 +83,7 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 	}
 
 	// create the erasure scheme so we can get the stripe size
-	es, err := makeErasureScheme(pointer.GetRemote().GetRedundancy())
+	es, err := eestream.NewScheme(pointer.GetRemote().GetRedundancy())
 	if err != nil {
 		return nil, err
 	}
@@ -103,18 +101,6 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 	return &Stripe{Index: index, Segment: pointer, PBA: pba, Authorization: authorization}, nil
 }
 
-func makeErasureScheme(rs *pb.RedundancyScheme) (eestream.ErasureScheme, error) {
-	required := int(rs.GetMinReq())
-	total := int(rs.GetTotal())
-
-	fc, err := infectious.NewFEC(required, total)
-	if err != nil {
-		return nil, err
-	}
-	es := eestream.NewRSScheme(fc, int(rs.GetErasureShareSize()))
-	return es, nil
-}
-
 func getRandomStripe(es eestream.ErasureScheme, pointer *pb.Pointer) (index int, err error) {
 	stripeSize := es.StripeSize()
 
@@ -59,6 +59,10 @@ func (pbd *pointerDBWrapper) Delete(ctx context.Context, in *pb.DeleteRequest, o
 	return pbd.s.Delete(ctx, in)
 }
 
+func (pbd *pointerDBWrapper) Usage(ctx context.Context, in *pb.UsageRequest, opts ...grpc.CallOption) (*pb.UsageResponse, error) {
+	return pbd.s.Usage(ctx, in)
+}
+
 func (pbd *pointerDBWrapper) PayerBandwidthAllocation(ctx context.Context, in *pb.PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*pb.PayerBandwidthAllocationResponse, error) {
 	return pbd.s.PayerBandwidthAllocation(ctx, in)
 }
@@ -12,6 +12,7 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/transport"
 )
 
@@ -34,6 +35,13 @@ type Config struct {
 // Run runs the repairer with the configured values
 func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
 	identity := server.Identity()
+
+	// the nodes serving corrupted shares are reported to the overlay
+	var corruption ecclient.CorruptionObserver
+	if cache := overlay.LoadFromContext(ctx); cache != nil {
+		corruption = cache
+	}
+
 	pointers, err := pdbclient.NewClient(identity, c.SatelliteAddr, c.APIKey)
 	if err != nil {
 		return err
@@ -43,7 +51,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return err
 	}
 	transport := transport.NewClient(identity)
-	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, transport, overlay, *identity, c.APIKey)
+	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, transport, overlay, corruption, *identity, c.APIKey)
 	if err != nil {
 		return err
 	}
@@ -56,9 +64,9 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 
 // NewService instantiates a Service with access to a Cursor and Verifier
 func NewService(ctx context.Context, statDBPort string, interval time.Duration, maxRetries int, pointers pdbclient.Client, transport transport.Client, overlay overlay.Client,
-	identity provider.FullIdentity, apiKey string) (service *Service, err error) {
+	corruption ecclient.CorruptionObserver, identity provider.FullIdentity, apiKey string) (service *Service, err error) {
 	cursor := NewCursor(pointers)
-	verifier := NewVerifier(transport, overlay, identity)
+	verifier := NewVerifier(transport, overlay, identity, corruption)
 	reporter, err := NewReporter(ctx, statDBPort, maxRetries, apiKey)
 	if err != nil {
 		return nil, err
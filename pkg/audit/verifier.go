@@ -7,14 +7,16 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"sort"
 
-	"github.com/vivint/infectious"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/pkg/utils"
@@ -31,6 +33,8 @@ type share struct {
 // Verifier helps verify the correctness of a given stripe
 type Verifier struct {
 	downloader downloader
+	// corruption is notified of the nodes serving corrupted shares, when set
+	corruption ecclient.CorruptionObserver
 }
 
 type downloader interface {
@@ -51,14 +55,16 @@ func newDefaultDownloader(transport transport.Client, overlay overlay.Client, id
 	return &defaultDownloader{transport: transport, overlay: overlay, identity: id}
 }
 
-// NewVerifier creates a Verifier
-func NewVerifier(transport transport.Client, overlay overlay.Client, id provider.FullIdentity) *Verifier {
-	return &Verifier{downloader: newDefaultDownloader(transport, overlay, id)}
+// NewVerifier creates a Verifier. The corruption observer, if not nil, is
+// notified of the nodes serving corrupted shares instead of them being
+// recorded as failing the audit.
+func NewVerifier(transport transport.Client, overlay overlay.Client, id provider.FullIdentity, corruption ecclient.CorruptionObserver) *Verifier {
+	return &Verifier{downloader: newDefaultDownloader(transport, overlay, id), corruption: corruption}
 }
 
 // getShare use piece store clients to download shares from a given node
 func (d *defaultDownloader) getShare(ctx context.Context, stripeIndex, shareSize, pieceNumber int,
-	id psclient.PieceID, pieceSize int64, fromNode *pb.Node, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (s share, err error) {
+	id psclient.PieceID, pieceSize int64, hash *psclient.PieceHash, fromNode *pb.Node, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (s share, err error) {
 	defer mon.Task()(&ctx)(&err)
 	fromNode.Type.DPanicOnInvalid("audit getShare")
 	ps, err := psclient.NewPSClient(ctx, d.transport, fromNode, 0)
@@ -71,7 +77,7 @@ func (d *defaultDownloader) getShare(ctx context.Context, stripeIndex, shareSize
 		return s, err
 	}
 
-	rr, err := ps.Get(ctx, derivedPieceID, pieceSize, pba, authorization)
+	rr, err := ps.Get(ctx, derivedPieceID, pieceSize, pba, authorization, hash)
 	if err != nil {
 		return s, err
 	}
@@ -127,7 +133,13 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 		paddedSize := calcPadded(pointer.GetSegmentSize(), shareSize)
 		pieceSize := paddedSize / int64(pointer.Remote.Redundancy.GetMinReq())
 
-		s, err := d.getShare(ctx, stripeIndex, shareSize, int(pieces[i].PieceNum), pieceID, pieceSize, node, pba, authorization)
+		// the shares of the pieces stored with hashes are verified with them
+		var hash *psclient.PieceHash
+		if pointer.Remote.GetMerkleRoot() != nil && pieces[i].Hash != nil {
+			hash = &psclient.PieceHash{Root: pieces[i].Hash, ShareSize: int64(shareSize)}
+		}
+
+		s, err := d.getShare(ctx, stripeIndex, shareSize, int(pieces[i].PieceNum), pieceID, pieceSize, hash, node, pba, authorization)
 		if err != nil {
 			s = share{
 				Error:       err,
@@ -143,43 +155,39 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 	return shares, nodes, nil
 }
 
-func makeCopies(ctx context.Context, originals map[int]share) (copies []infectious.Share, err error) {
+func makeCopies(ctx context.Context, originals map[int]share) (copies map[int][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
-	copies = make([]infectious.Share, 0, len(originals))
+	copies = make(map[int][]byte, len(originals))
 	for _, original := range originals {
 		if original.Error != nil {
 			continue
 		}
-		copies = append(copies, infectious.Share{
-			Data:   append([]byte{}, original.Data...),
-			Number: original.PieceNumber})
+		copies[original.PieceNumber] = append([]byte{}, original.Data...)
 	}
 	return copies, nil
 }
 
-// auditShares takes the downloaded shares and uses infectious's Correct function to check that they
-// haven't been altered. auditShares returns a slice containing the piece numbers of altered shares.
-func auditShares(ctx context.Context, required, total int, originals map[int]share) (pieceNums []int, err error) {
+// auditShares takes the downloaded shares and decodes copies of them with the
+// erasure scheme of the segment, which corrects the altered ones, to check
+// that they haven't been altered. auditShares returns a sorted slice
+// containing the piece numbers of altered shares.
+func auditShares(ctx context.Context, es eestream.ErasureScheme, originals map[int]share) (pieceNums []int, err error) {
 	defer mon.Task()(&ctx)(&err)
-	f, err := infectious.NewFEC(required, total)
-	if err != nil {
-		return nil, err
-	}
-
 	copies, err := makeCopies(ctx, originals)
 	if err != nil {
 		return nil, err
 	}
 
-	err = f.Correct(copies)
+	_, err = es.Decode(nil, copies)
 	if err != nil {
 		return nil, err
 	}
-	for _, share := range copies {
-		if !bytes.Equal(originals[share.Number].Data, share.Data) {
-			pieceNums = append(pieceNums, share.Number)
+	for num, data := range copies {
+		if !bytes.Equal(originals[num].Data, data) {
+			pieceNums = append(pieceNums, num)
 		}
 	}
+	sort.Ints(pieceNums)
 	return pieceNums, nil
 }
 
@@ -200,28 +208,46 @@ func (verifier *Verifier) verify(ctx context.Context, stripe *Stripe) (verifiedN
 		return nil, err
 	}
 
-	var offlineNodes storj.NodeIDList
+	// the nodes whose shares do not match the hashes of their pieces fail
+	// the audit, without having to decode the stripe
+	var offlineNodes, failedNodes storj.NodeIDList
+	corrupted := make(map[int]error)
 	for pieceNum := range shares {
-		if shares[pieceNum].Error != nil {
+		switch err := shares[pieceNum].Error; {
+		case err == nil:
+		case psclient.ErrVerification.Has(err):
+			failedNodes = append(failedNodes, nodes[pieceNum].Id)
+			corrupted[pieceNum] = err
+		default:
 			offlineNodes = append(offlineNodes, nodes[pieceNum].Id)
 		}
 	}
 
-	pointer := stripe.Segment
-	required := int(pointer.Remote.Redundancy.GetMinReq())
-	total := int(pointer.Remote.Redundancy.GetTotal())
-	pieceNums, err := auditShares(ctx, required, total, shares)
+	es, err := eestream.NewScheme(stripe.Segment.GetRemote().GetRedundancy())
+	if err != nil {
+		return nil, err
+	}
+	pieceNums, err := auditShares(ctx, es, shares)
 	if err != nil {
 		return nil, err
 	}
 
-	var failedNodes storj.NodeIDList
 	for _, pieceNum := range pieceNums {
 		failedNodes = append(failedNodes, nodes[pieceNum].Id)
+		corrupted[pieceNum] = ecclient.ErrCorruptedPiece.New("%s", nodes[pieceNum].Id)
 	}
 
 	successNodes := getSuccessNodes(ctx, nodes, failedNodes, offlineNodes)
 
+	// the nodes serving corrupted shares are penalized by the observer the
+	// same way as when they are found on downloads
+	if verifier.corruption != nil {
+		for pieceNum, err := range corrupted {
+			verifier.corruption.PieceCorrupted(ctx, nodes[pieceNum], err)
+		}
+		failedNodes = nil
+	}
+
 	return &RecordAuditsInfo{
 		SuccessNodeIDs: successNodes,
 		FailNodeIDs:    failedNodes,
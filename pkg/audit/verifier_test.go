@@ -13,7 +13,10 @@ import (
 	"github.com/vivint/infectious"
 
 	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
 )
 
 type mockDownloader struct {
@@ -97,6 +100,47 @@ func TestSomeNodesPassAudit(t *testing.T) {
 	}
 }
 
+type corruptionRecorder struct {
+	nodes storj.NodeIDList
+}
+
+func (recorder *corruptionRecorder) PieceCorrupted(ctx context.Context, node *pb.Node, err error) {
+	recorder.nodes = append(recorder.nodes, node.Id)
+}
+
+func TestCorruptedSharesReported(t *testing.T) {
+	ctx := context.Background()
+	mockShares := make(map[int]share)
+
+	someData := randData(32 * 1024)
+	for i := 0; i < 30; i++ {
+		mockShares[i] = share{
+			PieceNumber: i,
+			Data:        someData,
+		}
+	}
+	for i := 0; i < 3; i++ {
+		mockShares[i] = share{
+			Error:       psclient.ErrVerification.New("hash mismatch"),
+			PieceNumber: i,
+		}
+	}
+
+	md := mockDownloader{shares: mockShares}
+	recorder := &corruptionRecorder{}
+	verifier := &Verifier{downloader: &md, corruption: recorder}
+	pointer := makePointer(30)
+	verifiedNodes, err := verifier.verify(ctx, &Stripe{Index: 6, Segment: pointer, PBA: nil, Authorization: nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the nodes are penalized by the observer rather than by the reporter
+	assert.Len(t, recorder.nodes, 3)
+	assert.Len(t, verifiedNodes.FailNodeIDs, 0)
+	assert.Len(t, verifiedNodes.SuccessNodeIDs, 27)
+}
+
 func TestFailingAudit(t *testing.T) {
 	const (
 		required = 8
@@ -140,7 +184,7 @@ func TestFailingAudit(t *testing.T) {
 			Data:        append([]byte(nil), modifiedShares[i].Data...),
 		}
 	}
-	pieceNums, err := auditShares(ctx, 8, 14, auditPkgShares)
+	pieceNums, err := auditShares(ctx, eestream.NewRSScheme(f, 2), auditPkgShares)
 	if err != nil {
 		panic(err)
 	}
@@ -182,7 +226,11 @@ func TestNotEnoughShares(t *testing.T) {
 			Data:        append([]byte(nil), shares[i].Data...),
 		}
 	}
-	_, err = auditShares(ctx, 20, 40, auditPkgShares)
+	f, err = infectious.NewFEC(20, 40)
+	if err != nil {
+		panic(err)
+	}
+	_, err = auditShares(ctx, eestream.NewRSScheme(f, 2), auditPkgShares)
 	assert.Contains(t, err.Error(), "infectious: must specify at least the number of required shares")
 }
 
@@ -4,10 +4,13 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/x509"
 
 	"github.com/gtank/cryptopasta"
 
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
 	"storj.io/storj/pkg/provider"
@@ -45,6 +48,7 @@ func NewSignedMessage(signature []byte, identity *provider.FullIdentity) (*pb.Si
 		Data:      identity.ID.Bytes(),
 		Signature: signature,
 		PublicKey: encodedKey,
+		Chain:     [][]byte{identity.Leaf.Raw, identity.CA.Raw},
 	}, nil
 }
 
@@ -77,3 +81,41 @@ func NewSignedMessageVerifier() SignedMessageVerifier {
 		return nil
 	}
 }
+
+// VerifySigner checks that the certificate chain of the signed message ties
+// its public key to the node id it carries as data, so a signer cannot claim
+// the id of another node. Only the peers checking whom they trust require it,
+// as the peers released before the chain was added do not send it.
+func VerifySigner(signedMessage *pb.SignedMessage) error {
+	if len(signedMessage.GetChain()) < 2 {
+		return Error.New("missing certificate chain for verification")
+	}
+	chain, err := identity.ParseCertChain(signedMessage.GetChain())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := peertls.VerifyPeerCertChains(nil, [][]*x509.Certificate{chain}); err != nil {
+		return Error.Wrap(err)
+	}
+
+	leafKey, ok := chain[peertls.LeafIndex].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return Error.Wrap(peertls.ErrUnsupportedKey.New("%T", chain[peertls.LeafIndex].PublicKey))
+	}
+	encodedKey, err := cryptopasta.EncodePublicKey(leafKey)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !bytes.Equal(encodedKey, signedMessage.GetPublicKey()) {
+		return Error.New("public key not certified by the certificate chain")
+	}
+
+	id, err := identity.NodeIDFromKey(chain[peertls.CAIndex].PublicKey)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !bytes.Equal(id.Bytes(), signedMessage.GetData()) {
+		return Error.New("signer is not node %x", signedMessage.GetData())
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package auth
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"testing"
 
 	"github.com/gtank/cryptopasta"
@@ -77,3 +78,45 @@ func TestSignedMessageVerifier(t *testing.T) {
 		}
 	}
 }
+
+func TestSignedMessageVerifierForged(t *testing.T) {
+	ctx := context.Background()
+	ca, err := testidentity.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+	forgerCA, err := testidentity.NewTestCA(ctx)
+	assert.NoError(t, err)
+	forger, err := forgerCA.NewIdentity()
+	assert.NoError(t, err)
+
+	// the forger signs the id of the identity with its own key
+	signature, err := GenerateSignature(identity.ID.Bytes(), forger)
+	assert.NoError(t, err)
+	signedMessage, err := NewSignedMessage(signature, forger)
+	assert.NoError(t, err)
+	signedMessage.Data = identity.ID.Bytes()
+
+	// the signature alone is valid, as the chain is not required
+	err = NewSignedMessageVerifier()(signedMessage)
+	assert.NoError(t, err)
+
+	err = VerifySigner(signedMessage)
+	assert.EqualError(t, err, fmt.Sprintf("auth error: signer is not node %x", identity.ID.Bytes()))
+
+	// along with the certificate chain of the identity
+	signedMessage.Chain = [][]byte{identity.Leaf.Raw, identity.CA.Raw}
+	err = VerifySigner(signedMessage)
+	assert.EqualError(t, err, "auth error: public key not certified by the certificate chain")
+
+	signedMessage.Chain = nil
+	err = VerifySigner(signedMessage)
+	assert.EqualError(t, err, "auth error: missing certificate chain for verification")
+
+	// the genuine message is verified
+	signature, err = GenerateSignature(identity.ID.Bytes(), identity)
+	assert.NoError(t, err)
+	signedMessage, err = NewSignedMessage(signature, identity)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifySigner(signedMessage))
+}
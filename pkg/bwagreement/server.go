@@ -16,6 +16,7 @@ import (
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
+	"storj.io/storj/pkg/storj"
 )
 
 // DB stores bandwidth agreements.
@@ -26,6 +27,8 @@ type DB interface {
 	GetAgreements(context.Context) ([]Agreement, error)
 	// GetAgreementsSince gets all bandwidth agreements since specific time.
 	GetAgreementsSince(context.Context, time.Time) ([]Agreement, error)
+	// GetUplinkAgreements gets the bandwidth agreements of an uplink for an action.
+	GetUplinkAgreements(context.Context, storj.NodeID, pb.PayerBandwidthAllocation_Action) ([]Agreement, error)
 }
 
 // Server is an implementation of the pb.BandwidthServer interface
@@ -39,6 +42,8 @@ type Server struct {
 type Agreement struct {
 	Agreement []byte
 	Signature []byte
+	UplinkID  storj.NodeID
+	Action    pb.PayerBandwidthAllocation_Action
 	CreatedAt time.Time
 }
 
@@ -61,13 +66,16 @@ func (s *Server) BandwidthAgreements(ctx context.Context, req *pb.RenterBandwidt
 		Status: pb.AgreementsSummary_FAIL,
 	}
 
-	if err = s.verifySignature(ctx, req); err != nil {
+	pbad, err := s.verifySignature(ctx, req)
+	if err != nil {
 		return reply, err
 	}
 
 	err = s.db.CreateAgreement(ctx, Agreement{
 		Signature: req.GetSignature(),
 		Agreement: req.GetData(),
+		UplinkID:  pbad.UplinkId,
+		Action:    pbad.GetAction(),
 	})
 
 	if err != nil {
@@ -81,45 +89,45 @@ func (s *Server) BandwidthAgreements(ctx context.Context, req *pb.RenterBandwidt
 	return reply, nil
 }
 
-func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllocation) error {
+func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllocation) (*pb.PayerBandwidthAllocation_Data, error) {
 	// TODO(security): detect replay attacks
 
 	//Deserealize RenterBandwidthAllocation.GetData() so we can get public key
 	rbad := &pb.RenterBandwidthAllocation_Data{}
 	if err := proto.Unmarshal(ba.GetData(), rbad); err != nil {
-		return BwAgreementError.New("Failed to unmarshal RenterBandwidthAllocation: %+v", err)
+		return nil, BwAgreementError.New("Failed to unmarshal RenterBandwidthAllocation: %+v", err)
 	}
 
 	pba := rbad.GetPayerAllocation()
 	pbad := &pb.PayerBandwidthAllocation_Data{}
 	if err := proto.Unmarshal(pba.GetData(), pbad); err != nil {
-		return BwAgreementError.New("Failed to unmarshal PayerBandwidthAllocation: %+v", err)
+		return nil, BwAgreementError.New("Failed to unmarshal PayerBandwidthAllocation: %+v", err)
 	}
 	// Extract renter's public key from PayerBandwidthAllocation_Data
 	pubkey, err := x509.ParsePKIXPublicKey(pbad.GetPubKey())
 	if err != nil {
-		return BwAgreementError.New("Failed to extract Public Key from RenterBandwidthAllocation: %+v", err)
+		return nil, BwAgreementError.New("Failed to extract Public Key from RenterBandwidthAllocation: %+v", err)
 	}
 
 	// Typecast public key
 	k, ok := pubkey.(*ecdsa.PublicKey)
 	if !ok {
-		return peertls.ErrUnsupportedKey.New("%T", pubkey)
+		return nil, peertls.ErrUnsupportedKey.New("%T", pubkey)
 	}
 
 	// verify Renter's (uplink) signature
 	if ok := cryptopasta.Verify(ba.GetData(), ba.GetSignature(), k); !ok {
-		return BwAgreementError.New("Failed to verify Renter's Signature")
+		return nil, BwAgreementError.New("Failed to verify Renter's Signature")
 	}
 
 	k, ok = s.pkey.(*ecdsa.PublicKey)
 	if !ok {
-		return peertls.ErrUnsupportedKey.New("%T", s.pkey)
+		return nil, peertls.ErrUnsupportedKey.New("%T", s.pkey)
 	}
 
 	// verify Payer's (satellite) signature
 	if ok := cryptopasta.Verify(rbad.GetPayerAllocation().GetData(), rbad.GetPayerAllocation().GetSignature(), k); !ok {
-		return BwAgreementError.New("Failed to verify Payer's Signature")
+		return nil, BwAgreementError.New("Failed to verify Payer's Signature")
 	}
-	return nil
+	return pbad, nil
 }
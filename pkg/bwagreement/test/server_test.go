@@ -62,6 +62,15 @@ func TestSameSerialNumberBandwidthAgreements(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, pb.AgreementsSummary_OK, reply.Status)
 
+		/* The agreements are stored with the uplink and action of their allocation. */
+		agreements, err := db.BandwidthAgreement().GetUplinkAgreements(ctx, teststorj.NodeIDFromString("UplinkID"), pb.PayerBandwidthAllocation_GET)
+		assert.NoError(t, err)
+		assert.Len(t, agreements, 3)
+
+		agreements, err = db.BandwidthAgreement().GetUplinkAgreements(ctx, teststorj.NodeIDFromString("UplinkID"), pb.PayerBandwidthAllocation_PUT)
+		assert.NoError(t, err)
+		assert.Len(t, agreements, 0)
+
 		/* Storage nodes can't submit a second bwagreement with the same sequence. */
 		/* Disabled until V3-1024 gets fixed
 		rbaNode1, err = GenerateRenterBandwidthAllocation(pbaFile1, teststorj.NodeIDFromString("Storage node 1"), uplinkPrivKey)
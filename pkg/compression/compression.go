@@ -0,0 +1,307 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package compression compresses the content of segments in blocks
+// compressed independently, so that ranges of the content can be read
+// without decompressing all of it.
+//
+// The compressed data is the sequence of the compressed blocks, followed by
+// an index holding the size of each compressed block as a 4-byte big-endian
+// integer, and by a footer holding the size of the uncompressed blocks, the
+// size of the uncompressed data and the number of blocks.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/readcloser"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+// Error is the default compression errs class
+var Error = errs.Class("compression error")
+
+const (
+	indexEntrySize = 4
+	footerSize     = 4 + 8 + 4
+)
+
+// CompressReader returns a Reader of the data of r compressed with algorithm
+// in blocks of blockSize bytes, followed by the index of the blocks
+func CompressReader(r io.Reader, algorithm storj.CompressionAlgorithm, blockSize int) (io.Reader, error) {
+	if blockSize <= 0 {
+		return nil, Error.New("compression block size must be larger than 0")
+	}
+	codec, err := newCodec(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &compressReader{
+		reader: r,
+		codec:  codec,
+		block:  make([]byte, blockSize),
+	}, nil
+}
+
+type compressReader struct {
+	reader io.Reader
+	codec  codec
+	block  []byte
+	out    bytes.Buffer
+	sizes  []uint32
+	total  int64
+	done   bool
+}
+
+// Read implements io.Reader
+func (r *compressReader) Read(p []byte) (n int, err error) {
+	for r.out.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	return r.out.Read(p)
+}
+
+// next compresses the next block into the output buffer, or writes the index
+// and the footer after the last block
+func (r *compressReader) next() error {
+	n, err := io.ReadFull(r.reader, r.block)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	if n > 0 {
+		before := r.out.Len()
+		if err := r.codec.compress(&r.out, r.block[:n]); err != nil {
+			return Error.Wrap(err)
+		}
+		r.sizes = append(r.sizes, uint32(r.out.Len()-before))
+		r.total += int64(n)
+	}
+	if n == len(r.block) {
+		return nil
+	}
+
+	var entry [indexEntrySize]byte
+	for _, size := range r.sizes {
+		binary.BigEndian.PutUint32(entry[:], size)
+		_, _ = r.out.Write(entry[:])
+	}
+	var footer [footerSize]byte
+	binary.BigEndian.PutUint32(footer[0:4], uint32(len(r.block)))
+	binary.BigEndian.PutUint64(footer[4:12], uint64(r.total))
+	binary.BigEndian.PutUint32(footer[12:16], uint32(len(r.sizes)))
+	_, _ = r.out.Write(footer[:])
+	r.done = true
+	return nil
+}
+
+// Decompress returns a Ranger of the decompressed data of rr, compressed
+// by CompressReader with algorithm. It reads the index of the compressed
+// blocks, so that the ranges read decompress only the blocks they overlap.
+func Decompress(ctx context.Context, rr ranger.Ranger, algorithm storj.CompressionAlgorithm) (ranger.Ranger, error) {
+	codec, err := newCodec(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if rr.Size() < footerSize {
+		return nil, Error.New("compressed data too short")
+	}
+	footer, err := readRange(ctx, rr, rr.Size()-footerSize, footerSize)
+	if err != nil {
+		return nil, err
+	}
+	blockSize := int64(binary.BigEndian.Uint32(footer[0:4]))
+	size := int64(binary.BigEndian.Uint64(footer[4:12]))
+	count := int64(binary.BigEndian.Uint32(footer[12:16]))
+	if blockSize <= 0 || size < 0 || (size+blockSize-1)/blockSize != count {
+		return nil, Error.New("invalid compression footer")
+	}
+
+	indexSize := count * indexEntrySize
+	if rr.Size()-footerSize < indexSize {
+		return nil, Error.New("compressed data too short")
+	}
+	index, err := readRange(ctx, rr, rr.Size()-footerSize-indexSize, indexSize)
+	if err != nil {
+		return nil, err
+	}
+	offsets := make([]int64, count+1)
+	for i := int64(0); i < count; i++ {
+		offsets[i+1] = offsets[i] + int64(binary.BigEndian.Uint32(index[i*indexEntrySize:]))
+	}
+	if offsets[count] != rr.Size()-footerSize-indexSize {
+		return nil, Error.New("compressed blocks do not match their index")
+	}
+
+	return &decompressedRanger{
+		rr:        rr,
+		codec:     codec,
+		blockSize: blockSize,
+		size:      size,
+		offsets:   offsets,
+	}, nil
+}
+
+func readRange(ctx context.Context, rr ranger.Ranger, offset, length int64) ([]byte, error) {
+	r, err := rr.Range(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	data := make([]byte, length)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return data, nil
+}
+
+type decompressedRanger struct {
+	rr        ranger.Ranger
+	codec     codec
+	blockSize int64
+	size      int64
+	// offsets of the compressed blocks, followed by the offset of the index
+	offsets []int64
+}
+
+// Size implements Ranger.Size
+func (rr *decompressedRanger) Size() int64 {
+	return rr.size
+}
+
+// Range implements Ranger.Range
+func (rr *decompressedRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, Error.New("negative offset")
+	}
+	if length < 0 {
+		return nil, Error.New("negative length")
+	}
+	if offset+length > rr.size {
+		return nil, Error.New("range beyond end")
+	}
+	if length == 0 {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	first := offset / rr.blockSize
+	last := (offset + length - 1) / rr.blockSize
+	r, err := rr.rr.Range(ctx, rr.offsets[first], rr.offsets[last+1]-rr.offsets[first])
+	if err != nil {
+		return nil, err
+	}
+	blocks := &blockReader{
+		ranger: rr,
+		reader: r,
+		block:  first,
+		last:   last,
+	}
+	// the range might start a few bytes in the first block
+	_, err = io.CopyN(ioutil.Discard, blocks, offset-first*rr.blockSize)
+	if err != nil {
+		_ = blocks.Close()
+		return nil, Error.Wrap(err)
+	}
+	return readcloser.LimitReadCloser(blocks, length), nil
+}
+
+// blockReader decompresses the blocks read from reader one at a time
+type blockReader struct {
+	ranger *decompressedRanger
+	reader io.ReadCloser
+	block  int64
+	last   int64
+	out    bytes.Buffer
+}
+
+// Read implements io.Reader
+func (r *blockReader) Read(p []byte) (n int, err error) {
+	for r.out.Len() == 0 {
+		if r.block > r.last {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	return r.out.Read(p)
+}
+
+// next decompresses the next block into the output buffer
+func (r *blockReader) next() error {
+	rr := r.ranger
+	expected := rr.blockSize
+	if remaining := rr.size - r.block*rr.blockSize; remaining < expected {
+		expected = remaining
+	}
+
+	compressed := io.LimitReader(r.reader, rr.offsets[r.block+1]-rr.offsets[r.block])
+	err := rr.codec.decompress(&r.out, compressed, expected)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if int64(r.out.Len()) != expected {
+		return Error.New("decompressed block %d has %d bytes, expected %d", r.block, r.out.Len(), expected)
+	}
+	r.block++
+	return nil
+}
+
+// Close implements io.Closer
+func (r *blockReader) Close() error {
+	return r.reader.Close()
+}
+
+// codec compresses and decompresses blocks with an algorithm. A block
+// decompressing to more than the expected size fails as soon as it does.
+type codec interface {
+	compress(w io.Writer, block []byte) error
+	decompress(w io.Writer, r io.Reader, expected int64) error
+}
+
+func newCodec(algorithm storj.CompressionAlgorithm) (codec, error) {
+	switch algorithm {
+	case storj.Gzip:
+		return gzipCodec{}, nil
+	default:
+		return nil, Error.New("unsupported compression algorithm %d", algorithm)
+	}
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) compress(w io.Writer, block []byte) error {
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(block); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (gzipCodec) decompress(w io.Writer, r io.Reader, expected int64) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(w, io.LimitReader(zr, expected+1))
+	if err != nil {
+		return err
+	}
+	if n > expected {
+		return Error.New("block decompresses to more than %d bytes", expected)
+	}
+	return zr.Close()
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package compression
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+func compress(t *testing.T, data []byte, blockSize int) []byte {
+	r, err := CompressReader(bytes.NewReader(data), storj.Gzip, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return compressed
+}
+
+func TestCompressRange(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("compressible content "), 1000)
+
+	for i, tt := range []struct {
+		size, blockSize int
+	}{
+		{0, 1024},
+		{1, 1024},
+		{1024, 1024},
+		{4096, 1024},
+		{len(data), 1024},
+		{len(data), 100000},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		compressed := compress(t, data[:tt.size], tt.blockSize)
+		if tt.size == len(data) {
+			assert.True(t, len(compressed) < tt.size, errTag)
+		}
+
+		rr, err := Decompress(ctx, ranger.ByteRanger(compressed), storj.Gzip)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		assert.Equal(t, int64(tt.size), rr.Size(), errTag)
+
+		for _, r := range [][2]int{
+			{0, tt.size},
+			{0, tt.size / 2},
+			{tt.size / 3, tt.size / 3},
+			{tt.size / 2, tt.size - tt.size/2},
+		} {
+			offset, length := r[0], r[1]
+			reader, err := rr.Range(ctx, int64(offset), int64(length))
+			if !assert.NoError(t, err, errTag) {
+				continue
+			}
+			read, err := ioutil.ReadAll(reader)
+			assert.NoError(t, err, errTag)
+			assert.Equal(t, data[offset:offset+length], read, errTag)
+			assert.NoError(t, reader.Close(), errTag)
+		}
+	}
+}
+
+func TestDecompressCorrupted(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("compressible content "), 1000)
+	compressed := compress(t, data, 1024)
+
+	// a truncated index is detected without reading the blocks
+	_, err := Decompress(ctx, ranger.ByteRanger(compressed[1:]), storj.Gzip)
+	assert.Error(t, err)
+
+	_, err = Decompress(ctx, ranger.ByteRanger(compressed), storj.NoCompression)
+	assert.Error(t, err)
+
+	// a corrupted block fails the read
+	compressed[20] ^= 0xff
+	rr, err := Decompress(ctx, ranger.ByteRanger(compressed), storj.Gzip)
+	if !assert.NoError(t, err) {
+		return
+	}
+	reader, err := rr.Range(ctx, 0, rr.Size())
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = ioutil.ReadAll(reader)
+	assert.Error(t, err)
+}
+
+func TestDecompressOversized(t *testing.T) {
+	var compressed bytes.Buffer
+	err := gzipCodec{}.compress(&compressed, make([]byte, 1<<20))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// a block decompressing to more than expected fails without being
+	// decompressed entirely
+	var out bytes.Buffer
+	err = gzipCodec{}.decompress(&out, &compressed, 1024)
+	assert.Error(t, err)
+	assert.Equal(t, 1025, out.Len())
+}
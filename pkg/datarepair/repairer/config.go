@@ -15,6 +15,7 @@ import (
 	"storj.io/storj/pkg/provider"
 	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/transport"
 )
 
 // Config contains configurable values for repairer
@@ -71,7 +72,16 @@ func (c Config) getSegmentRepairer(ctx context.Context, identity *provider.FullI
 		return nil, err
 	}
 
-	ec := ecclient.NewClient(identity, c.MaxBufferMem)
+	// the nodes failing to serve their pieces, too slow to serve them or
+	// serving corrupted pieces are reported to the overlay
+	var observers []transport.Observer
+	var corruption ecclient.CorruptionObserver
+	if cache := overlay.LoadFromContext(ctx); cache != nil {
+		observers = append(observers, cache)
+		corruption = cache
+	}
+
+	ec := ecclient.NewClient(identity, c.MaxBufferMem, corruption, observers...)
 
 	return segments.NewSegmentRepairer(oc, ec, pdb), nil
 }
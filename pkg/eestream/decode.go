@@ -7,7 +7,9 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"sort"
 	"sync"
+	"time"
 
 	"storj.io/storj/internal/readcloser"
 	"storj.io/storj/pkg/encryption"
@@ -38,6 +40,21 @@ type decodedReader struct {
 // set to 0, the minimum possible memory will be used.
 func DecodeReaders(ctx context.Context, rs map[int]io.ReadCloser,
 	es ErasureScheme, expectedSize int64, mbm int) io.ReadCloser {
+	return decodeReaders(ctx, rs, es, expectedSize, mbm, nil, readerOptions{})
+}
+
+// readerOptions are the DecodeOptions of a single decoded reader
+type readerOptions struct {
+	slowTimeout time.Duration
+	onSlow      func(pieces []int)
+	onCorrupted func(pieces []int)
+}
+
+// decodeReaders is like DecodeReaders, but reads also from the spare pieces
+// when needed, reporting the slow and corrupted pieces as set in opts
+func decodeReaders(ctx context.Context, rs map[int]io.ReadCloser,
+	es ErasureScheme, expectedSize int64, mbm int,
+	spares *spareRangers, opts readerOptions) io.ReadCloser {
 	if expectedSize < 0 {
 		return readcloser.FatalReadCloser(Error.New("negative expected size"))
 	}
@@ -57,6 +74,13 @@ func DecodeReaders(ctx context.Context, rs map[int]io.ReadCloser,
 		expectedStripes: expectedSize / int64(es.StripeSize()),
 	}
 	dr.ctx, dr.cancel = context.WithCancel(ctx)
+	if spares != nil {
+		spares.ctx = dr.ctx
+		dr.stripeReader.spares = spares
+		dr.stripeReader.slowTimeout = opts.slowTimeout
+		dr.stripeReader.onSlow = opts.onSlow
+	}
+	dr.stripeReader.onCorrupted = opts.onCorrupted
 	// Kick off a goroutine to watch for context cancelation.
 	go func() {
 		<-dr.ctx.Done()
@@ -83,6 +107,11 @@ func (dr *decodedReader) Read(p []byte) (n int, err error) {
 			return 0, dr.err
 		}
 		dr.currentStripe++
+		if dr.currentStripe >= dr.expectedStripes {
+			// all the erasure shares were read, so the pieces still being
+			// downloaded are not needed anymore
+			_ = dr.Close()
+		}
 	}
 
 	// copy what data we have to the output
@@ -122,6 +151,27 @@ type decodedRanger struct {
 	rrs    map[int]ranger.Ranger
 	inSize int64
 	mbm    int // max buffer memory
+	opts   DecodeOptions
+}
+
+// DecodeOptions configures the spare pieces of a Ranger returned by
+// DecodeWithOptions, and how the misbehaving pieces are reported
+type DecodeOptions struct {
+	// Spares are the rangers of the pieces read from only when one of the
+	// pieces being read from fails, or is too slow
+	Spares map[int]ranger.Ranger
+	// SlowTimeout is the time waited for the erasure shares of a stripe
+	// before reading from a spare piece. If 0, the spare pieces are read from
+	// only on failures.
+	SlowTimeout time.Duration
+	// OnSlow, if not nil, is called with the numbers of the pieces missing
+	// the erasure shares of a stripe when SlowTimeout passes
+	OnSlow func(ctx context.Context, pieces []int)
+	// OnCorrupted, if not nil, is called with the numbers of the pieces whose
+	// erasure shares were corrupted. The extra erasure shares read beyond the
+	// required ones are used to correct the corrupted ones, so they are
+	// detected only if there are enough extra erasure shares.
+	OnCorrupted func(ctx context.Context, pieces []int)
 }
 
 // Decode takes a map of Rangers and an ErasureScheme and returns a combined
@@ -131,6 +181,13 @@ type decodedRanger struct {
 // mbm is the maximum memory (in bytes) to be allocated for read buffers. If
 // set to 0, the minimum possible memory will be used.
 func Decode(rrs map[int]ranger.Ranger, es ErasureScheme, mbm int) (ranger.Ranger, error) {
+	return DecodeWithOptions(rrs, es, mbm, DecodeOptions{})
+}
+
+// DecodeWithOptions is like Decode, but the Ranger reads also from the spare
+// pieces in opts if the pieces in rrs fail or are too slow, and reports the
+// misbehaving pieces.
+func DecodeWithOptions(rrs map[int]ranger.Ranger, es ErasureScheme, mbm int, opts DecodeOptions) (ranger.Ranger, error) {
 	if err := checkMBM(mbm); err != nil {
 		return nil, err
 	}
@@ -138,7 +195,7 @@ func Decode(rrs map[int]ranger.Ranger, es ErasureScheme, mbm int) (ranger.Ranger
 		return nil, Error.New("not enough readers to reconstruct data!")
 	}
 	size := int64(-1)
-	for _, rr := range rrs {
+	for _, rr := range allRangers(rrs, opts.Spares) {
 		if size == -1 {
 			size = rr.Size()
 		} else {
@@ -161,9 +218,22 @@ func Decode(rrs map[int]ranger.Ranger, es ErasureScheme, mbm int) (ranger.Ranger
 		rrs:    rrs,
 		inSize: size,
 		mbm:    mbm,
+		opts:   opts,
 	}, nil
 }
 
+// allRangers returns the rangers of both maps in a single slice
+func allRangers(rrs, spares map[int]ranger.Ranger) []ranger.Ranger {
+	all := make([]ranger.Ranger, 0, len(rrs)+len(spares))
+	for _, rr := range rrs {
+		all = append(all, rr)
+	}
+	for _, rr := range spares {
+		all = append(all, rr)
+	}
+	return all
+}
+
 func (dr *decodedRanger) Size() int64 {
 	blocks := dr.inSize / int64(dr.es.ErasureShareSize())
 	return blocks * int64(dr.es.StripeSize())
@@ -199,8 +269,20 @@ func (dr *decodedRanger) Range(ctx context.Context, offset, length int64) (io.Re
 			readers[res.i] = res.r
 		}
 	}
+	var spares *spareRangers
+	opts := readerOptions{slowTimeout: dr.opts.SlowTimeout}
+	if len(dr.opts.Spares) > 0 {
+		spares = newSpareRangers(dr.opts.Spares, firstBlock, blockCount, dr.es.ErasureShareSize())
+		if dr.opts.OnSlow != nil {
+			opts.onSlow = func(pieces []int) { dr.opts.OnSlow(ctx, pieces) }
+		}
+	}
+	if dr.opts.OnCorrupted != nil {
+		opts.onCorrupted = func(pieces []int) { dr.opts.OnCorrupted(ctx, pieces) }
+	}
 	// decode from all those ranges
-	r := DecodeReaders(ctx, readers, dr.es, blockCount*int64(dr.es.StripeSize()), dr.mbm)
+	r := decodeReaders(ctx, readers, dr.es, blockCount*int64(dr.es.StripeSize()), dr.mbm,
+		spares, opts)
 	// offset might start a few bytes in, potentially discard the initial bytes
 	_, err := io.CopyN(ioutil.Discard, r,
 		offset-firstBlock*int64(dr.es.StripeSize()))
@@ -210,3 +292,47 @@ func (dr *decodedRanger) Range(ctx context.Context, offset, length int64) (io.Re
 	// length might not have included all of the blocks, limit what we return
 	return readcloser.LimitReadCloser(r, length), nil
 }
+
+// spareRangers opens the ranges of the spare pieces needed by a decoded
+// reader, starting from the erasure share of the stripe being read
+type spareRangers struct {
+	ctx        context.Context
+	rrs        map[int]ranger.Ranger
+	pieces     []int
+	firstBlock int64
+	blockCount int64
+	shareSize  int64
+}
+
+func newSpareRangers(rrs map[int]ranger.Ranger, firstBlock, blockCount int64, shareSize int) *spareRangers {
+	pieces := make([]int, 0, len(rrs))
+	for i := range rrs {
+		pieces = append(pieces, i)
+	}
+	sort.Ints(pieces)
+	return &spareRangers{
+		rrs:        rrs,
+		pieces:     pieces,
+		firstBlock: firstBlock,
+		blockCount: blockCount,
+		shareSize:  int64(shareSize),
+	}
+}
+
+// remaining returns the number of spare pieces not read from yet
+func (s *spareRangers) remaining() int {
+	return len(s.pieces)
+}
+
+// next returns the number of the next spare piece, and a function opening
+// its range from the num-th erasure share on
+func (s *spareRangers) next(num int64) (piece int, open func() (io.ReadCloser, error), ok bool) {
+	if len(s.pieces) == 0 || num >= s.blockCount {
+		return 0, nil, false
+	}
+	piece, s.pieces = s.pieces[0], s.pieces[1:]
+	rr := s.rrs[piece]
+	return piece, func() (io.ReadCloser, error) {
+		return rr.Range(s.ctx, (s.firstBlock+num)*s.shareSize, (s.blockCount-num)*s.shareSize)
+	}, true
+}
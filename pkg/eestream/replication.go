@@ -0,0 +1,100 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"bytes"
+
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
+)
+
+type replicationScheme struct {
+	total            int
+	erasureShareSize int
+}
+
+// NewReplicationScheme returns an ErasureScheme storing total full copies of
+// the data, cheaper than Reed-Solomon for small segments. A single piece is
+// enough to rebuild the data, or to repair the other pieces.
+func NewReplicationScheme(total, erasureShareSize int) (ErasureScheme, error) {
+	if total <= 0 {
+		return nil, Error.New("total count of replicated pieces must be positive")
+	}
+	if erasureShareSize <= 0 {
+		return nil, Error.New("erasure share size must be positive")
+	}
+	return &replicationScheme{total: total, erasureShareSize: erasureShareSize}, nil
+}
+
+func newReplicationScheme(required, total, erasureShareSize int) (ErasureScheme, error) {
+	if required != 1 {
+		return nil, Error.New("replicated pieces require 1 piece, not %d", required)
+	}
+	return NewReplicationScheme(total, erasureShareSize)
+}
+
+func (s *replicationScheme) Encode(input []byte, output func(num int, data []byte)) error {
+	for num := 0; num < s.total; num++ {
+		output(num, input)
+	}
+	return nil
+}
+
+// Decode returns the erasure share held by the majority of the pieces in, and
+// corrects the others in place
+func (s *replicationScheme) Decode(out []byte, in map[int][]byte) ([]byte, error) {
+	if len(in) == 0 {
+		return nil, infectious.NotEnoughShares.New("no erasure shares")
+	}
+	var best []byte
+	bestCount, tie := 0, false
+	for _, share := range in {
+		count := 0
+		for _, other := range in {
+			if bytes.Equal(share, other) {
+				count++
+			}
+		}
+		switch {
+		case count > bestCount:
+			best, bestCount, tie = share, count, false
+		case count == bestCount && !bytes.Equal(share, best):
+			tie = true
+		}
+	}
+	if tie {
+		return nil, infectious.TooManyErrors.New("no majority among %d erasure shares", len(in))
+	}
+	for _, share := range in {
+		copy(share, best)
+	}
+	return append(out, best...), nil
+}
+
+func (s *replicationScheme) ErasureShareSize() int {
+	return s.erasureShareSize
+}
+
+func (s *replicationScheme) StripeSize() int {
+	return s.erasureShareSize
+}
+
+func (s *replicationScheme) TotalCount() int {
+	return s.total
+}
+
+func (s *replicationScheme) RequiredCount() int {
+	return 1
+}
+
+func (s *replicationScheme) SchemeType() pb.RedundancyScheme_SchemeType {
+	return pb.RedundancyScheme_REPLICATION
+}
+
+// RepairCount returns 1, as any healthy piece is a copy of the missing ones
+func (s *replicationScheme) RepairCount(missing []int) int {
+	return 1
+}
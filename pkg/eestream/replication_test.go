@@ -0,0 +1,133 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestReplication(t *testing.T) {
+	ctx := context.Background()
+	data := randData(32 * 1024)
+	es, err := NewReplicationScheme(3, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := NewRedundancyStrategy(es, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readers, err := EncodeReader(ctx, bytes.NewReader(data), rs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pieces, err := readAll(readers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, piece := range pieces {
+		assert.Equal(t, data, piece)
+	}
+
+	// corrupt every erasure share of the first piece
+	for i := range pieces[0] {
+		pieces[0][i] ^= 0xff
+	}
+
+	rrs := make(map[int]ranger.Ranger, len(pieces))
+	for i, piece := range pieces {
+		rrs[i] = ranger.ByteRanger(piece)
+	}
+	corrupted := make(chan []int, len(pieces))
+	rr, err := DecodeWithOptions(rrs, rs, 0, DecodeOptions{
+		OnCorrupted: func(ctx context.Context, pieces []int) {
+			corrupted <- pieces
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := rr.Range(ctx, 0, rr.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data2, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, data, data2)
+	assert.NoError(t, r.Close())
+
+	select {
+	case reported := <-corrupted:
+		assert.Equal(t, []int{0}, reported)
+	case <-time.After(1 * time.Second):
+		t.Fatal("corrupted piece not reported")
+	}
+}
+
+func TestReplicationDecodeTie(t *testing.T) {
+	es, err := NewReplicationScheme(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = es.Decode(nil, map[int][]byte{
+		0: []byte("abcd"),
+		1: []byte("abce"),
+	})
+	assert.Error(t, err)
+
+	out, err := es.Decode(nil, map[int][]byte{
+		0: []byte("abcd"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("abcd"), out)
+}
+
+func TestNewScheme(t *testing.T) {
+	for i, tt := range []struct {
+		scheme    *pb.RedundancyScheme
+		algorithm storj.RedundancyAlgorithm
+		required  int
+		errString string
+	}{
+		{&pb.RedundancyScheme{Type: pb.RedundancyScheme_RS, MinReq: 2, Total: 4, ErasureShareSize: 1024},
+			storj.ReedSolomon, 2, ""},
+		{&pb.RedundancyScheme{Type: pb.RedundancyScheme_REPLICATION, MinReq: 1, Total: 4, ErasureShareSize: 1024},
+			storj.Replication, 1, ""},
+		{&pb.RedundancyScheme{Type: pb.RedundancyScheme_REPLICATION, MinReq: 2, Total: 4, ErasureShareSize: 1024},
+			0, 0, "eestream error: replicated pieces require 1 piece, not 2"},
+		{&pb.RedundancyScheme{Type: pb.RedundancyScheme_SchemeType(42), MinReq: 2, Total: 4, ErasureShareSize: 1024},
+			0, 0, "eestream error: unsupported redundancy scheme type 42"},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		es, err := NewScheme(tt.scheme)
+		if tt.errString != "" {
+			assert.EqualError(t, err, tt.errString, errTag)
+			continue
+		}
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		assert.Equal(t, tt.required, es.RequiredCount(), errTag)
+		assert.Equal(t, 4, es.TotalCount(), errTag)
+		assert.Equal(t, tt.scheme.Type, SchemeType(es), errTag)
+		assert.Equal(t, tt.algorithm, Algorithm(tt.scheme.Type), errTag)
+
+		typ, err := SchemeTypeOf(tt.algorithm)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, tt.scheme.Type, typ, errTag)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -604,6 +605,180 @@ func TestDecoderErrorWithStalledReaders(t *testing.T) {
 	}
 }
 
+func TestDecodeHedged(t *testing.T) {
+	ctx := context.Background()
+	data := randData(32 * 1024)
+	fc, err := infectious.NewFEC(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := NewRSScheme(fc, 1024)
+	rs, err := NewRedundancyStrategy(es, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readers, err := EncodeReader(ctx, bytes.NewReader(data), rs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pieces, err := readAll(readers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := int64(len(pieces[0]))
+
+	for i, tt := range []struct {
+		problematic ranger.Ranger
+		slowTimeout time.Duration
+		slow        []int
+	}{
+		{failingRanger{size: size}, 0, nil},
+		{stalledRanger{size: size}, 50 * time.Millisecond, []int{1}},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		rrs := map[int]ranger.Ranger{
+			0: ranger.ByteRanger(pieces[0]),
+			1: tt.problematic,
+		}
+		var mu sync.Mutex
+		var slow []int
+		hedging := DecodeOptions{
+			Spares: map[int]ranger.Ranger{
+				2: ranger.ByteRanger(pieces[2]),
+				3: ranger.ByteRanger(pieces[3]),
+			},
+			SlowTimeout: tt.slowTimeout,
+			OnSlow: func(ctx context.Context, pieces []int) {
+				mu.Lock()
+				defer mu.Unlock()
+				slow = append(slow, pieces...)
+			},
+		}
+
+		rr, err := DecodeWithOptions(rrs, rs, 0, hedging)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+
+		start := time.Now()
+		r, err := rr.Range(ctx, 0, rr.Size())
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		data2, err := ioutil.ReadAll(r)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, data, data2, errTag)
+		assert.NoError(t, r.Close(), errTag)
+
+		// the stalled piece is not waited for
+		assert.True(t, time.Since(start) < 1*time.Second, errTag)
+
+		mu.Lock()
+		if tt.slow == nil {
+			assert.Empty(t, slow, errTag)
+		} else {
+			assert.Subset(t, slow, tt.slow, errTag)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestDecodeCorrupted(t *testing.T) {
+	ctx := context.Background()
+	data := randData(32 * 1024)
+	fc, err := infectious.NewFEC(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := NewRSScheme(fc, 1024)
+	rs, err := NewRedundancyStrategy(es, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readers, err := EncodeReader(ctx, bytes.NewReader(data), rs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pieces, err := readAll(readers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// corrupt every erasure share of the second piece
+	for i := range pieces[1] {
+		pieces[1][i] ^= 0xff
+	}
+
+	rrs := make(map[int]ranger.Ranger, len(pieces))
+	for i, piece := range pieces {
+		rrs[i] = ranger.ByteRanger(piece)
+	}
+	corrupted := make(chan []int, len(pieces))
+	rr, err := DecodeWithOptions(rrs, rs, 0, DecodeOptions{
+		OnCorrupted: func(ctx context.Context, pieces []int) {
+			corrupted <- pieces
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := rr.Range(ctx, 0, rr.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data2, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, data, data2)
+	assert.NoError(t, r.Close())
+
+	// the corrupted piece is reported once, and not read from afterwards
+	select {
+	case reported := <-corrupted:
+		assert.Equal(t, []int{1}, reported)
+	case <-time.After(1 * time.Second):
+		t.Fatal("corrupted piece not reported")
+	}
+	assert.Empty(t, corrupted)
+}
+
+type failingRanger struct {
+	size int64
+}
+
+func (rr failingRanger) Size() int64 { return rr.size }
+
+func (rr failingRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return nil, errors.New("I am an error piece")
+}
+
+// stalledRanger returns readers blocking until closed
+type stalledRanger struct {
+	size int64
+}
+
+func (rr stalledRanger) Size() int64 { return rr.size }
+
+func (rr stalledRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return &stalledReader{closed: make(chan struct{})}, nil
+}
+
+type stalledReader struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (r *stalledReader) Read(p []byte) (n int, err error) {
+	<-r.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (r *stalledReader) Close() error {
+	r.once.Do(func() { close(r.closed) })
+	return nil
+}
+
 func BenchmarkReedSolomonErasureScheme(b *testing.B) {
 	data := randData(8 << 20)
 	output := make([]byte, 8<<20)
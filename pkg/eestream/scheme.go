@@ -0,0 +1,109 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"sync"
+
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// SchemeFactory creates the ErasureScheme of the given required and total
+// counts, and erasure share size
+type SchemeFactory func(required, total, erasureShareSize int) (ErasureScheme, error)
+
+// TypedScheme is an ErasureScheme knowing its redundancy scheme type. The
+// ErasureSchemes not implementing it are Reed-Solomon ones.
+type TypedScheme interface {
+	ErasureScheme
+	SchemeType() pb.RedundancyScheme_SchemeType
+}
+
+// LocalRepairer is implemented by the ErasureSchemes able to rebuild their
+// missing pieces from fewer pieces than their RequiredCount, once the
+// integrity of the erasure shares read is verified
+type LocalRepairer interface {
+	// RepairCount returns the number of healthy pieces to download to
+	// rebuild the missing ones
+	RepairCount(missing []int) int
+}
+
+type registeredScheme struct {
+	algorithm storj.RedundancyAlgorithm
+	factory   SchemeFactory
+}
+
+var schemes = struct {
+	sync.RWMutex
+	byType map[pb.RedundancyScheme_SchemeType]registeredScheme
+}{byType: map[pb.RedundancyScheme_SchemeType]registeredScheme{}}
+
+func init() {
+	RegisterScheme(pb.RedundancyScheme_RS, storj.ReedSolomon, newRSScheme)
+	RegisterScheme(pb.RedundancyScheme_REPLICATION, storj.Replication, newReplicationScheme)
+}
+
+// RegisterScheme makes the ErasureSchemes created by factory available to
+// NewScheme for the pointers of the given redundancy scheme type, whose
+// algorithm in the stream metadata is algorithm
+func RegisterScheme(typ pb.RedundancyScheme_SchemeType, algorithm storj.RedundancyAlgorithm, factory SchemeFactory) {
+	schemes.Lock()
+	defer schemes.Unlock()
+	schemes.byType[typ] = registeredScheme{algorithm: algorithm, factory: factory}
+}
+
+// NewScheme creates the ErasureScheme of the redundancy scheme of a pointer
+func NewScheme(scheme *pb.RedundancyScheme) (ErasureScheme, error) {
+	schemes.RLock()
+	registered, ok := schemes.byType[scheme.GetType()]
+	schemes.RUnlock()
+	if !ok {
+		return nil, Error.New("unsupported redundancy scheme type %v", scheme.GetType())
+	}
+	return registered.factory(int(scheme.GetMinReq()), int(scheme.GetTotal()), int(scheme.GetErasureShareSize()))
+}
+
+// SchemeType returns the redundancy scheme type of es
+func SchemeType(es ErasureScheme) pb.RedundancyScheme_SchemeType {
+	if rs, ok := es.(RedundancyStrategy); ok {
+		es = rs.ErasureScheme
+	}
+	if typed, ok := es.(TypedScheme); ok {
+		return typed.SchemeType()
+	}
+	return pb.RedundancyScheme_RS
+}
+
+// Algorithm returns the redundancy algorithm of the redundancy scheme type
+func Algorithm(typ pb.RedundancyScheme_SchemeType) storj.RedundancyAlgorithm {
+	schemes.RLock()
+	defer schemes.RUnlock()
+	if registered, ok := schemes.byType[typ]; ok {
+		return registered.algorithm
+	}
+	return storj.InvalidRedundancyAlgorithm
+}
+
+// SchemeTypeOf returns the redundancy scheme type of the redundancy algorithm
+func SchemeTypeOf(algorithm storj.RedundancyAlgorithm) (pb.RedundancyScheme_SchemeType, error) {
+	schemes.RLock()
+	defer schemes.RUnlock()
+	for typ, registered := range schemes.byType {
+		if registered.algorithm == algorithm {
+			return typ, nil
+		}
+	}
+	return 0, Error.New("unsupported redundancy algorithm %d", algorithm)
+}
+
+func newRSScheme(required, total, erasureShareSize int) (ErasureScheme, error) {
+	fc, err := infectious.NewFEC(required, total)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return NewRSScheme(fc, erasureShareSize), nil
+}
@@ -4,11 +4,13 @@
 package eestream
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/vivint/infectious"
 )
@@ -18,10 +20,23 @@ type StripeReader struct {
 	scheme      ErasureScheme
 	cond        *sync.Cond
 	readerCount int
+	bufSize     int
 	bufs        map[int]*PieceBuffer
 	inbufs      map[int][]byte
 	inmap       map[int][]byte
 	errmap      map[int]error
+	copies      map[int][]byte
+
+	onCorrupted func(pieces []int)
+
+	spares      *spareRangers
+	slowTimeout time.Duration
+	onSlow      func(pieces []int)
+
+	// mu guards the readers of the spare pieces, closed by Close
+	mu           sync.Mutex
+	closed       bool
+	spareReaders []io.Closer
 }
 
 // NewStripeReader creates a new StripeReader from the given readers, erasure
@@ -37,41 +52,64 @@ func NewStripeReader(rs map[int]io.ReadCloser, es ErasureScheme, mbm int) *Strip
 		inbufs:      make(map[int][]byte, readerCount),
 		inmap:       make(map[int][]byte, readerCount),
 		errmap:      make(map[int]error, readerCount),
+		copies:      make(map[int][]byte, readerCount),
 	}
 
-	bufSize := mbm / readerCount
-	bufSize -= bufSize % es.ErasureShareSize()
-	if bufSize < es.ErasureShareSize() {
-		bufSize = es.ErasureShareSize()
+	r.bufSize = mbm / readerCount
+	r.bufSize -= r.bufSize % es.ErasureShareSize()
+	if r.bufSize < es.ErasureShareSize() {
+		r.bufSize = es.ErasureShareSize()
 	}
 
 	for i := range rs {
 		r.inbufs[i] = make([]byte, es.ErasureShareSize())
-		r.bufs[i] = NewPieceBuffer(make([]byte, bufSize), es.ErasureShareSize(), r.cond)
+		r.bufs[i] = NewPieceBuffer(make([]byte, r.bufSize), es.ErasureShareSize(), r.cond)
 		// Kick off a goroutine each reader to be copied into a PieceBuffer.
-		go func(r io.Reader, buf *PieceBuffer) {
-			_, err := io.Copy(buf, r)
-			if err != nil {
-				buf.SetError(err)
-				return
-			}
-			buf.SetError(io.EOF)
-		}(rs[i], r.bufs[i])
+		go copyToBuffer(r.bufs[i], rs[i])
 	}
 
 	return r
 }
 
+// copyToBuffer copies the erasure shares read from r into buf
+func copyToBuffer(buf *PieceBuffer, r io.Reader) {
+	_, err := io.Copy(buf, r)
+	if err != nil {
+		buf.SetError(err)
+		return
+	}
+	buf.SetError(io.EOF)
+}
+
 // Close closes the StripeReader and all PieceBuffers.
 func (r *StripeReader) Close() error {
-	errs := make(chan error, len(r.bufs))
+	r.mu.Lock()
+	r.closed = true
+	spareReaders := r.spareReaders
+	r.mu.Unlock()
+
+	// the spare pieces are added to bufs only while the lock is held
+	r.cond.L.Lock()
+	bufs := make([]*PieceBuffer, 0, len(r.bufs))
 	for _, buf := range r.bufs {
+		bufs = append(bufs, buf)
+	}
+	r.cond.L.Unlock()
+
+	closers := make([]io.Closer, 0, len(bufs)+len(spareReaders))
+	for _, buf := range bufs {
+		closers = append(closers, buf)
+	}
+	closers = append(closers, spareReaders...)
+
+	errs := make(chan error, len(closers))
+	for _, closer := range closers {
 		go func(c io.Closer) {
 			errs <- c.Close()
-		}(buf)
+		}(closer)
 	}
 	var first error
-	for range r.bufs {
+	for range closers {
 		err := <-errs
 		if err != nil && first == nil {
 			first = Error.Wrap(err)
@@ -80,6 +118,91 @@ func (r *StripeReader) Close() error {
 	return first
 }
 
+// addSpare starts reading the shares from the num-th one on of a spare
+// piece. It returns false if there are no spare pieces left to read the
+// num-th share from. It must be called with r.cond.L held.
+func (r *StripeReader) addSpare(num int64) bool {
+	if r.spares == nil {
+		return false
+	}
+	i, open, ok := r.spares.next(num)
+	if !ok {
+		return false
+	}
+
+	buf := NewPieceBuffer(make([]byte, r.bufSize), r.scheme.ErasureShareSize(), r.cond)
+	// the shares before the num-th one are not read from the spare piece
+	buf.currentShare = num
+
+	r.readerCount++
+	r.inbufs[i] = make([]byte, r.scheme.ErasureShareSize())
+	r.bufs[i] = buf
+
+	// the spare piece is opened in the background, as it may take a while
+	go func() {
+		reader, err := open()
+		if err != nil {
+			buf.SetError(err)
+			return
+		}
+
+		r.mu.Lock()
+		if r.closed {
+			r.mu.Unlock()
+			_ = reader.Close()
+			buf.SetError(io.ErrClosedPipe)
+			return
+		}
+		r.spareReaders = append(r.spareReaders, reader)
+		r.mu.Unlock()
+
+		copyToBuffer(buf, reader)
+	}()
+
+	return true
+}
+
+// hasSpares checks if there are spare pieces left to read from
+func (r *StripeReader) hasSpares() bool {
+	return r.spares != nil && r.spares.remaining() > 0
+}
+
+// wait waits for new erasure shares to be written to the piece buffers, or
+// until deadline, if there are spare pieces to read from. It returns false if
+// the deadline passed. It must be called with r.cond.L held.
+func (r *StripeReader) wait(deadline time.Time) bool {
+	if r.slowTimeout <= 0 || !r.hasSpares() {
+		r.cond.Wait()
+		return true
+	}
+
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		r.cond.L.Lock()
+		defer r.cond.L.Unlock()
+		r.cond.Broadcast()
+	})
+	r.cond.Wait()
+	timer.Stop()
+
+	return time.Now().Before(deadline)
+}
+
+// hedge reports the pieces still missing the num-th erasure share as slow and
+// starts reading from a spare piece. It must be called with r.cond.L held.
+func (r *StripeReader) hedge(num int64) {
+	if r.onSlow != nil {
+		var slow []int
+		for i := range r.bufs {
+			if r.inmap[i] == nil && r.errmap[i] == nil {
+				slow = append(slow, i)
+			}
+		}
+		sort.Ints(slow)
+		go r.onSlow(slow)
+	}
+	r.addSpare(num)
+}
+
 // ReadStripe reads and decodes the num-th stripe and concatenates it to p. The
 // return value is the updated byte slice.
 func (r *StripeReader) ReadStripe(num int64, p []byte) ([]byte, error) {
@@ -90,12 +213,26 @@ func (r *StripeReader) ReadStripe(num int64, p []byte) ([]byte, error) {
 	r.cond.L.Lock()
 	defer r.cond.L.Unlock()
 
-	for r.pendingReaders() {
-		for r.readAvailableShares(num) == 0 {
-			r.cond.Wait()
+	deadline := time.Now().Add(r.slowTimeout)
+
+	// spare pieces are read from when the pieces being read from are not
+	// enough, or are too slow
+	for r.pendingReaders() || r.addSpare(num) {
+		for {
+			read, failed := r.readAvailableShares(num)
+			for ; failed > 0; failed-- {
+				r.addSpare(num)
+			}
+			if read > 0 {
+				break
+			}
+			if !r.wait(deadline) {
+				r.hedge(num)
+				deadline = time.Now().Add(r.slowTimeout)
+			}
 		}
 		if r.hasEnoughShares() {
-			out, err := r.scheme.Decode(p, r.inmap)
+			out, err := r.decode(num, p)
 			if err != nil {
 				if r.shouldWaitForMore(err) {
 					continue
@@ -109,10 +246,56 @@ func (r *StripeReader) ReadStripe(num int64, p []byte) ([]byte, error) {
 	return nil, r.combineErrs(num)
 }
 
+// decode decodes the erasure shares read for the num-th stripe. If there are
+// more erasure shares than required, the corrupted ones are corrected, and
+// their pieces are not read from anymore. It must be called with r.cond.L
+// held.
+func (r *StripeReader) decode(num int64, p []byte) ([]byte, error) {
+	if len(r.inmap) <= r.scheme.RequiredCount() {
+		// no errors can be corrected, nor detected
+		return r.scheme.Decode(p, r.inmap)
+	}
+
+	// the Reed-Solomon scheme corrects the erasure shares in place, so
+	// copies are decoded to find the corrupted ones by comparing them
+	shares := make(map[int][]byte, len(r.inmap))
+	for i, share := range r.inmap {
+		if r.copies[i] == nil {
+			r.copies[i] = make([]byte, len(share))
+		}
+		copy(r.copies[i], share)
+		shares[i] = r.copies[i]
+	}
+
+	out, err := r.scheme.Decode(p, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrupted []int
+	for i, share := range r.inmap {
+		if !bytes.Equal(share, shares[i]) {
+			corrupted = append(corrupted, i)
+			r.errmap[i] = Error.New("corrupted erasure share %d", num)
+		}
+	}
+	if len(corrupted) > 0 {
+		sort.Ints(corrupted)
+		if r.onCorrupted != nil {
+			go r.onCorrupted(corrupted)
+		}
+		// keep enough pieces to correct the following stripes
+		for range corrupted {
+			r.addSpare(num + 1)
+		}
+	}
+	return out, nil
+}
+
 // readAvailableShares reads the available num-th erasure shares from the piece
 // buffers without blocking. The return value n is the number of erasure shares
-// read.
-func (r *StripeReader) readAvailableShares(num int64) (n int) {
+// read, including the failed ones, whose number is returned as failed.
+func (r *StripeReader) readAvailableShares(num int64) (n, failed int) {
 	for i, buf := range r.bufs {
 		if r.inmap[i] != nil || r.errmap[i] != nil {
 			continue
@@ -121,13 +304,14 @@ func (r *StripeReader) readAvailableShares(num int64) (n int) {
 			err := buf.ReadShare(num, r.inbufs[i])
 			if err != nil {
 				r.errmap[i] = err
+				failed++
 			} else {
 				r.inmap[i] = r.inbufs[i]
 			}
 			n++
 		}
 	}
-	return n
+	return n, failed
 }
 
 // pendingReaders checks if there are any pending readers to get a share from.
@@ -152,7 +336,7 @@ func (r *StripeReader) shouldWaitForMore(err error) bool {
 		return false
 	}
 	// check if there are more input buffers to wait for
-	return r.pendingReaders()
+	return r.pendingReaders() || r.hasSpares()
 }
 
 // combineErrs makes a useful error message from the errors in errmap.
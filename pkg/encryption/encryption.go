@@ -42,6 +42,8 @@ func Encrypt(data []byte, cipher storj.Cipher, key *storj.Key, nonce *storj.Nonc
 		return EncryptAESGCM(data, key, ToAESGCMNonce(nonce))
 	case storj.SecretBox:
 		return EncryptSecretBox(data, key, nonce)
+	case storj.XChaCha20Poly1305:
+		return EncryptXChaCha(data, key, nonce)
 	default:
 		return nil, ErrInvalidConfig.New("encryption type %d is not supported", cipher)
 	}
@@ -61,6 +63,8 @@ func Decrypt(cipherData []byte, cipher storj.Cipher, key *storj.Key, nonce *stor
 		return DecryptAESGCM(cipherData, key, ToAESGCMNonce(nonce))
 	case storj.SecretBox:
 		return DecryptSecretBox(cipherData, key, nonce)
+	case storj.XChaCha20Poly1305:
+		return DecryptXChaCha(cipherData, key, nonce)
 	default:
 		return nil, ErrInvalidConfig.New("encryption type %d is not supported", cipher)
 	}
@@ -75,6 +79,8 @@ func NewEncrypter(cipher storj.Cipher, key *storj.Key, startingNonce *storj.Nonc
 		return NewAESGCMEncrypter(key, ToAESGCMNonce(startingNonce), encryptedBlockSize)
 	case storj.SecretBox:
 		return NewSecretboxEncrypter(key, startingNonce, encryptedBlockSize)
+	case storj.XChaCha20Poly1305:
+		return NewXChaChaEncrypter(key, startingNonce, encryptedBlockSize)
 	default:
 		return nil, ErrInvalidConfig.New("encryption type %d is not supported", cipher)
 	}
@@ -89,6 +95,8 @@ func NewDecrypter(cipher storj.Cipher, key *storj.Key, startingNonce *storj.Nonc
 		return NewAESGCMDecrypter(key, ToAESGCMNonce(startingNonce), encryptedBlockSize)
 	case storj.SecretBox:
 		return NewSecretboxDecrypter(key, startingNonce, encryptedBlockSize)
+	case storj.XChaCha20Poly1305:
+		return NewXChaChaDecrypter(key, startingNonce, encryptedBlockSize)
 	default:
 		return nil, ErrInvalidConfig.New("encryption type %d is not supported", cipher)
 	}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+
+	"golang.org/x/crypto/scrypt"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// KeySaltSize is the size of the salts of the keys derived from passphrases
+const KeySaltSize = 16
+
+// the scrypt cost parameters of the keys derived from passphrases
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// DeriveKeyFromPassphrase derives a key from passphrase and salt with
+// scrypt, which makes guessing the passphrase from the key costly
+func DeriveKeyFromPassphrase(passphrase, salt []byte) (*storj.Key, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrInvalidConfig.New("empty passphrase")
+	}
+	if len(salt) < KeySaltSize {
+		return nil, ErrInvalidConfig.New("salt of %d bytes too short", len(salt))
+	}
+	derived, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, storj.KeySize)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	key := new(storj.Key)
+	copy(key[:], derived)
+	return key, nil
+}
+
+// keyCheckMessage is the message authenticated by the check values of keys
+const keyCheckMessage = "storj root key check"
+
+// KeyCheckValue returns a value that is the same only for the same key, so
+// that a key derived from a mistyped passphrase is detected without storing
+// the key itself
+func KeyCheckValue(key *storj.Key) []byte {
+	mac := hmac.New(sha256.New, key[:])
+	_, _ = mac.Write([]byte(keyCheckMessage))
+	return mac.Sum(nil)
+}
+
+// KeyFile is the content of a file holding a key encrypted with a key
+// derived from a passphrase
+type KeyFile struct {
+	Salt         []byte                    `json:"salt"`
+	Nonce        []byte                    `json:"nonce"`
+	EncryptedKey storj.EncryptedPrivateKey `json:"encrypted_key"`
+}
+
+// NewKeyFile encrypts key with a key derived from passphrase and a random salt
+func NewKeyFile(key *storj.Key, passphrase []byte) (*KeyFile, error) {
+	salt := make([]byte, KeySaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	var nonce storj.Nonce
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	derivedKey, err := DeriveKeyFromPassphrase(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := EncryptKey(key, storj.XChaCha20Poly1305, derivedKey, &nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyFile{Salt: salt, Nonce: nonce[:], EncryptedKey: encryptedKey}, nil
+}
+
+// Unlock decrypts the key of the key file with passphrase
+func (file *KeyFile) Unlock(passphrase []byte) (*storj.Key, error) {
+	derivedKey, err := DeriveKeyFromPassphrase(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	var nonce storj.Nonce
+	copy(nonce[:], file.Nonce)
+	return DecryptKey(file.EncryptedKey, storj.XChaCha20Poly1305, derivedKey, &nonce)
+}
+
+// SaveKeyFile writes the key file to path, readable only by its owner
+func SaveKeyFile(path string, file *KeyFile) error {
+	data, err := json.Marshal(file)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(ioutil.WriteFile(path, data, 0600))
+}
+
+// LoadKeyFile reads the key file at path
+func LoadKeyFile(path string) (*KeyFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	file := new(KeyFile)
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return file, nil
+}
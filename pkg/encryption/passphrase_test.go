@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestDeriveKeyFromPassphrase(t *testing.T) {
+	salt := randData(KeySaltSize)
+
+	key, err := DeriveKeyFromPassphrase([]byte("passphrase"), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same passphrase and salt derive the same key
+	again, err := DeriveKeyFromPassphrase([]byte("passphrase"), salt)
+	assert.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	other, err := DeriveKeyFromPassphrase([]byte("passphrase"), randData(KeySaltSize))
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	_, err = DeriveKeyFromPassphrase(nil, salt)
+	assert.Error(t, err)
+	_, err = DeriveKeyFromPassphrase([]byte("passphrase"), salt[:1])
+	assert.Error(t, err)
+}
+
+func TestKeyCheckValue(t *testing.T) {
+	salt := randData(KeySaltSize)
+
+	key, err := DeriveKeyFromPassphrase([]byte("passphrase"), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := DeriveKeyFromPassphrase([]byte("passphrase"), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := DeriveKeyFromPassphrase([]byte("wrong passphrase"), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, KeyCheckValue(key), KeyCheckValue(again))
+	assert.NotEqual(t, KeyCheckValue(key), KeyCheckValue(wrong))
+	assert.NotEqual(t, key[:], KeyCheckValue(key))
+}
+
+func TestKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storj-keyfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	var key storj.Key
+	copy(key[:], randData(storj.KeySize))
+
+	file, err := NewKeyFile(&key, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "root.key")
+	if err := SaveKeyFile(path, file); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlocked, err := loaded.Unlock([]byte("passphrase"))
+	assert.NoError(t, err)
+	assert.Equal(t, &key, unlocked)
+
+	_, err = loaded.Unlock([]byte("wrong passphrase"))
+	assert.True(t, ErrDecryptFailed.Has(err))
+}
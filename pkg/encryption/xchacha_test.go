@@ -0,0 +1,38 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestXChaCha(t *testing.T) {
+	var key storj.Key
+	copy(key[:], randData(storj.KeySize))
+	var firstNonce storj.Nonce
+	copy(firstNonce[:], randData(storj.NonceSize))
+	encrypter, err := NewXChaChaEncrypter(&key, &firstNonce, 4*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := randData(encrypter.InBlockSize() * 10)
+	encrypted := TransformReader(
+		ioutil.NopCloser(bytes.NewReader(data)), encrypter, 0)
+	decrypter, err := NewXChaChaDecrypter(&key, &firstNonce, 4*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted := TransformReader(encrypted, decrypter, 0)
+	data2, err := ioutil.ReadAll(decrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, data2) {
+		t.Fatalf("encryption/decryption failed")
+	}
+}
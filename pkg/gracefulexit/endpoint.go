@@ -0,0 +1,511 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/peertls"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+type psClientFunc func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error)
+
+// Endpoint implements the satellite side of the graceful exit of the
+// storage nodes
+type Endpoint struct {
+	log             *zap.Logger
+	pointers        *pointerdb.Server
+	overlay         *overlay.Server
+	cache           *overlay.Cache
+	identity        *provider.FullIdentity
+	transport       transport.Client
+	newPSClientFunc psClientFunc
+}
+
+// NewEndpoint returns an endpoint moving the pieces of the exiting nodes
+// referenced by the pointers to the replacement nodes chosen from overlay
+func NewEndpoint(log *zap.Logger, pointers *pointerdb.Server, overlay *overlay.Server, cache *overlay.Cache, identity *provider.FullIdentity) *Endpoint {
+	return &Endpoint{
+		log:             log,
+		pointers:        pointers,
+		overlay:         overlay,
+		cache:           cache,
+		identity:        identity,
+		transport:       transport.NewClient(identity),
+		newPSClientFunc: psclient.NewPSClient,
+	}
+}
+
+// segmentPiece is a piece of the segments at paths, which share their
+// pieces with pieceID
+type segmentPiece struct {
+	paths    []string
+	pieceID  string
+	pieceNum int32
+}
+
+// Exit marks the requesting node as exiting, then streams it each of its
+// pieces to transfer with the replacement node chosen for it. Once the
+// transfers end, the node is marked as exited. An interrupted exit continues
+// from the pieces left on the next call.
+func (endpoint *Endpoint) Exit(stream pb.GracefulExit_ExitServer) (err error) {
+	ctx := stream.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	nodeID := peer.ID
+
+	// the first request announces the exit
+	if _, err := stream.Recv(); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err := endpoint.cache.UpdateExitStatus(ctx, nodeID, pb.NodeExitStatus_EXITING); err != nil {
+		return Error.Wrap(err)
+	}
+	endpoint.log.Info("node exiting", zap.String("Node ID", nodeID.String()))
+
+	pieces, err := endpoint.nodePieces(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+
+	pba, err := endpoint.payerAllocation(peer.ID, peer.Leaf.PublicKey, pb.PayerBandwidthAllocation_PUT)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	authorization, err := endpoint.signedMessage()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, piece := range pieces {
+		if err := endpoint.transfer(ctx, stream, nodeID, piece, pba, authorization); err != nil {
+			return err
+		}
+	}
+
+	if err := endpoint.cache.UpdateExitStatus(ctx, nodeID, pb.NodeExitStatus_EXITED); err != nil {
+		return Error.Wrap(err)
+	}
+	endpoint.log.Info("node exited", zap.String("Node ID", nodeID.String()))
+
+	return stream.Send(&pb.ExitResponse{Completed: true})
+}
+
+// transfer has the exiting node push the piece to a replacement node, then
+// updates the pointers of its segments with the result. The piece is
+// transferred once for all the pointers sharing it.
+func (endpoint *Endpoint) transfer(ctx context.Context, stream pb.GracefulExit_ExitServer, nodeID storj.NodeID, piece segmentPiece, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path, pointer, err := endpoint.findPointer(piece, nodeID)
+	if err != nil {
+		return err
+	}
+	if pointer == nil {
+		// deleted or moved since
+		return nil
+	}
+	remote := pointer.GetRemote()
+
+	replacement, err := endpoint.chooseReplacement(ctx, remote)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&pb.ExitResponse{
+		Transfer: &pb.TransferPiece{
+			Path:           path,
+			PieceId:        remote.PieceId,
+			PieceNum:       piece.pieceNum,
+			ShareSize:      remote.GetRedundancy().GetErasureShareSize(),
+			ExpirationDate: pointer.ExpirationDate,
+			Replacement:    replacement,
+			Pba:            pba,
+			Authorization:  authorization,
+		},
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	request, err := stream.Recv()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	result := request.GetResult()
+	if result.GetPath() != path || result.GetPieceNum() != piece.pieceNum {
+		return Error.New("unexpected transfer result for piece %d of %s", result.GetPieceNum(), result.GetPath())
+	}
+
+	success, failure := result.Success, result.Error
+	if success {
+		// the exiting node could report transfers it never made, so the
+		// piece has to be confirmed by its replacement
+		if err := endpoint.confirmTransfer(ctx, pointer, piece.pieceNum, replacement, authorization); err != nil {
+			mon.Meter("unconfirmed_transfers").Mark(1)
+			success, failure = false, err.Error()
+		}
+	}
+
+	if !success {
+		// the piece is dropped for the segment to be repaired once its
+		// pieces get too few
+		mon.Meter("failed_transfers").Mark(1)
+		endpoint.log.Warn("piece transfer failed",
+			zap.String("Node ID", nodeID.String()),
+			zap.String("Path", path),
+			zap.Int32("Piece Num", piece.pieceNum),
+			zap.String("Error", failure))
+		return endpoint.updatePointers(sharingPaths(piece, remote), piece.pieceID, func(remote *pb.RemoteSegment) bool {
+			return removePiece(remote, nodeID, piece.pieceNum)
+		})
+	}
+
+	mon.Meter("transferred_pieces").Mark(1)
+	return endpoint.updatePointers(sharingPaths(piece, remote), piece.pieceID, func(remote *pb.RemoteSegment) bool {
+		return replacePiece(remote, nodeID, piece.pieceNum, replacement.Id)
+	})
+}
+
+// confirmTransfer checks that the replacement node stores the piece with
+// pieceNum of the remote segment of pointer, reading it back to check its
+// size and, if recorded, the hashes of its erasure shares
+func (endpoint *Endpoint) confirmTransfer(ctx context.Context, pointer *pb.Pointer, pieceNum int32, replacement *pb.Node, authorization *pb.SignedMessage) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	remote := pointer.GetRemote()
+	es, err := eestream.NewScheme(remote.GetRedundancy())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	pieceSize := calcPadded(pointer.GetSegmentSize(), es.StripeSize()) / int64(es.RequiredCount())
+
+	var hash *psclient.PieceHash
+	if root := pieceHash(remote, pieceNum); root != nil {
+		hash = &psclient.PieceHash{Root: root, ShareSize: int64(es.ErasureShareSize())}
+	}
+
+	derivedID, err := psclient.PieceID(remote.GetPieceId()).Derive(replacement.Id.Bytes())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	pba, err := endpoint.payerAllocation(endpoint.identity.ID, endpoint.identity.Leaf.PublicKey, pb.PayerBandwidthAllocation_GET)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	ps, err := endpoint.newPSClientFunc(ctx, endpoint.transport, replacement, 0)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ps.Close()) }()
+
+	summary, err := ps.Meta(ctx, derivedID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if summary.GetPieceSize() != pieceSize {
+		return Error.New("replacement stores %d bytes instead of %d", summary.GetPieceSize(), pieceSize)
+	}
+
+	rr, err := ps.Get(ctx, derivedID, pieceSize, pba, authorization, hash)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	rc, err := rr.Range(ctx, 0, pieceSize)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer utils.LogClose(rc)
+
+	n, err := io.Copy(ioutil.Discard, rc)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if n != pieceSize {
+		return Error.New("replacement returned %d bytes instead of %d", n, pieceSize)
+	}
+	return nil
+}
+
+// nodePieces returns the pieces of the segments stored on the node, each
+// piece shared by several pointers once
+func (endpoint *Endpoint) nodePieces(ctx context.Context, nodeID storj.NodeID) (pieces []segmentPiece, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	type pieceKey struct {
+		pieceID  string
+		pieceNum int32
+	}
+	index := map[pieceKey]int{}
+
+	err = endpoint.pointers.Iterate(ctx, &pb.IterateRequest{Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return Error.Wrap(err)
+				}
+				remote := pointer.GetRemote()
+				for _, piece := range remote.GetRemotePieces() {
+					if piece.NodeId != nodeID {
+						continue
+					}
+					key := pieceKey{remote.PieceId, piece.PieceNum}
+					if i, ok := index[key]; ok {
+						pieces[i].paths = append(pieces[i].paths, item.Key.String())
+						continue
+					}
+					index[key] = len(pieces)
+					pieces = append(pieces, segmentPiece{
+						paths:    []string{item.Key.String()},
+						pieceID:  remote.PieceId,
+						pieceNum: piece.PieceNum,
+					})
+				}
+			}
+			return nil
+		})
+	return pieces, err
+}
+
+// findPointer returns the first of the pointers of the piece still
+// referencing it on the node with its path, or a nil pointer if there is
+// none
+func (endpoint *Endpoint) findPointer(piece segmentPiece, nodeID storj.NodeID) (path string, pointer *pb.Pointer, err error) {
+	for _, path := range piece.paths {
+		pointer, err := endpoint.getPointer(path)
+		if storage.ErrKeyNotFound.Has(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, Error.Wrap(err)
+		}
+		remote := pointer.GetRemote()
+		if remote.GetPieceId() == piece.pieceID && findPiece(remote, nodeID, piece.pieceNum) >= 0 {
+			return path, pointer, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// chooseReplacement returns a node to store a piece of the remote segment,
+// other than the ones already storing its pieces
+func (endpoint *Endpoint) chooseReplacement(ctx context.Context, remote *pb.RemoteSegment) (*pb.Node, error) {
+	var excluded storj.NodeIDList
+	for _, piece := range remote.GetRemotePieces() {
+		excluded = append(excluded, piece.NodeId)
+	}
+
+	resp, err := endpoint.overlay.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 1, ExcludedNodes: excluded},
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(resp.GetNodes()) == 0 {
+		return nil, Error.New("no replacement node available")
+	}
+	return resp.Nodes[0], nil
+}
+
+// getPointer returns the pointer at path
+func (endpoint *Endpoint) getPointer(path string) (*pb.Pointer, error) {
+	value, err := endpoint.pointers.DB.Get(storage.Key(path))
+	if err != nil {
+		return nil, err
+	}
+	pointer := &pb.Pointer{}
+	if err := proto.Unmarshal(value, pointer); err != nil {
+		return nil, err
+	}
+	return pointer, nil
+}
+
+// updatePointers applies update to the remote segments of the pointers at
+// paths that still reference the pieces with pieceID
+func (endpoint *Endpoint) updatePointers(paths []string, pieceID string, update func(remote *pb.RemoteSegment) bool) error {
+	var errlist errs.Group
+	for _, path := range paths {
+		errlist.Add(endpoint.updatePointer(path, pieceID, update))
+	}
+	return errlist.Err()
+}
+
+// updatePointer applies update to the remote segment of the pointer at path,
+// if it still references the pieces with pieceID, storing the pointer if it
+// was changed. The pointer is only replaced if it was not changed since it
+// was read, by the repairer for example, and the update is retried otherwise.
+func (endpoint *Endpoint) updatePointer(path string, pieceID string, update func(remote *pb.RemoteSegment) bool) error {
+	for {
+		value, err := endpoint.pointers.DB.Get(storage.Key(path))
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		pointer := &pb.Pointer{}
+		if err := proto.Unmarshal(value, pointer); err != nil {
+			return Error.Wrap(err)
+		}
+		// the path may have been reused since for other pieces
+		if pointer.GetRemote().GetPieceId() != pieceID || !update(pointer.Remote) {
+			return nil
+		}
+
+		newValue, err := proto.Marshal(pointer)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		err = endpoint.pointers.DB.CompareAndSwap(storage.Key(path), value, newValue)
+		if storage.ErrValueChanged.Has(err) {
+			continue
+		}
+		return Error.Wrap(err)
+	}
+}
+
+// payerAllocation returns a bandwidth allocation of the satellite for the
+// action of the uplink with key, the exiting node uploading its pieces or
+// the satellite reading them back from their replacements
+func (endpoint *Endpoint) payerAllocation(uplinkID storj.NodeID, key crypto.PublicKey, action pb.PayerBandwidthAllocation_Action) (*pb.PayerBandwidthAllocation, error) {
+	pk, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, peertls.ErrUnsupportedKey.New("%T", key)
+	}
+	pubbytes, err := x509.MarshalPKIXPublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		SatelliteId:    endpoint.identity.ID,
+		UplinkId:       uplinkID,
+		CreatedUnixSec: time.Now().Unix(),
+		Action:         action,
+		PubKey:         pubbytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	signature, err := auth.GenerateSignature(data, endpoint.identity)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PayerBandwidthAllocation{Signature: signature, Data: data}, nil
+}
+
+// signedMessage returns the authorization of the satellite, whose identity
+// the storage nodes keep its pieces under
+func (endpoint *Endpoint) signedMessage() (*pb.SignedMessage, error) {
+	signature, err := auth.GenerateSignature(endpoint.identity.ID.Bytes(), endpoint.identity)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewSignedMessage(signature, endpoint.identity)
+}
+
+// sharingPaths returns the paths of the pointers of the piece and of the
+// pointers sharing the pieces of the remote segment
+func sharingPaths(piece segmentPiece, remote *pb.RemoteSegment) []string {
+	paths := append([]string(nil), piece.paths...)
+	for _, shared := range remote.GetSharedPaths() {
+		found := false
+		for _, path := range paths {
+			if path == shared {
+				found = true
+				break
+			}
+		}
+		if !found {
+			paths = append(paths, shared)
+		}
+	}
+	return paths
+}
+
+// findPiece returns the index of the piece with pieceNum stored on the node
+// in the remote segment, or -1 if there is none
+func findPiece(remote *pb.RemoteSegment, nodeID storj.NodeID, pieceNum int32) int {
+	for i, piece := range remote.GetRemotePieces() {
+		if piece.NodeId == nodeID && piece.PieceNum == pieceNum {
+			return i
+		}
+	}
+	return -1
+}
+
+// replacePiece moves the piece with pieceNum of the remote segment from the
+// node to its replacement, returning whether it was found
+func replacePiece(remote *pb.RemoteSegment, nodeID storj.NodeID, pieceNum int32, replacementID storj.NodeID) bool {
+	i := findPiece(remote, nodeID, pieceNum)
+	if i < 0 {
+		return false
+	}
+	remote.RemotePieces[i].NodeId = replacementID
+	return true
+}
+
+// removePiece removes the piece with pieceNum stored on the node from the
+// remote segment, returning whether it was found
+func removePiece(remote *pb.RemoteSegment, nodeID storj.NodeID, pieceNum int32) bool {
+	i := findPiece(remote, nodeID, pieceNum)
+	if i < 0 {
+		return false
+	}
+	remote.RemotePieces = append(remote.RemotePieces[:i], remote.RemotePieces[i+1:]...)
+	return true
+}
+
+// pieceHash returns the root hash of the erasure shares of the piece with
+// pieceNum of the remote segment, or nil if it was not recorded
+func pieceHash(remote *pb.RemoteSegment, pieceNum int32) []byte {
+	if hashes := remote.GetPieceHashes(); int(pieceNum) < len(hashes) && hashes[pieceNum] != nil {
+		return hashes[pieceNum]
+	}
+	for _, piece := range remote.GetRemotePieces() {
+		if piece.PieceNum == pieceNum && piece.Hash != nil {
+			return piece.Hash
+		}
+	}
+	return nil
+}
+
+// calcPadded returns size padded to a multiple of blockSize
+func calcPadded(size int64, blockSize int) int64 {
+	mod := size % int64(blockSize)
+	if mod == 0 {
+		return size
+	}
+	return size + int64(blockSize) - mod
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestPointerUpdates(t *testing.T) {
+	ctx := context.Background()
+	pointers := pointerdb.NewServer(teststore.New(), nil, zap.NewNop(), pointerdb.Config{}, nil)
+	endpoint := NewEndpoint(zap.NewNop(), pointers, nil, nil, nil)
+
+	exiting := teststorj.NodeIDFromString("exiting")
+	other := teststorj.NodeIDFromString("other")
+	replacement := teststorj.NodeIDFromString("replacement")
+
+	put := func(path string, pointer *pb.Pointer) {
+		value, err := proto.Marshal(pointer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pointers.DB.Put(storage.Key(path), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	remote := func(pieceID string, pieces ...*pb.RemotePiece) *pb.Pointer {
+		return &pb.Pointer{
+			Type:   pb.Pointer_REMOTE,
+			Remote: &pb.RemoteSegment{PieceId: pieceID, RemotePieces: pieces},
+		}
+	}
+	put("l/bucket/a", remote("piece-a",
+		&pb.RemotePiece{PieceNum: 0, NodeId: other},
+		&pb.RemotePiece{PieceNum: 1, NodeId: exiting, Hash: []byte("hash")},
+	))
+	put("l/bucket/b", remote("piece-b",
+		&pb.RemotePiece{PieceNum: 0, NodeId: exiting},
+		&pb.RemotePiece{PieceNum: 1, NodeId: other},
+	))
+	put("l/bucket/c", remote("piece-c", &pb.RemotePiece{PieceNum: 0, NodeId: other}))
+	put("l/bucket/d", &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("inline")})
+
+	// a copy of a sharing its pieces
+	shared := remote("piece-a",
+		&pb.RemotePiece{PieceNum: 0, NodeId: other},
+		&pb.RemotePiece{PieceNum: 1, NodeId: exiting, Hash: []byte("hash")},
+	)
+	shared.Remote.SharedPaths = []string{"l/bucket/a"}
+	put("l/bucket/e", shared)
+
+	// the shared pieces are transferred once
+	pieces, err := endpoint.nodePieces(ctx, exiting)
+	assert.NoError(t, err)
+	assert.Equal(t, []segmentPiece{
+		{paths: []string{"l/bucket/a", "l/bucket/e"}, pieceID: "piece-a", pieceNum: 1},
+		{paths: []string{"l/bucket/b"}, pieceID: "piece-b", pieceNum: 0},
+	}, pieces)
+
+	// the piece is found in the pointers still referencing it
+	path, pointer, err := endpoint.findPointer(segmentPiece{paths: []string{"l/bucket/deleted", "l/bucket/c", "l/bucket/e"}, pieceID: "piece-a", pieceNum: 1}, exiting)
+	if assert.NoError(t, err) && assert.NotNil(t, pointer) {
+		assert.Equal(t, "l/bucket/e", path)
+	}
+	_, pointer, err = endpoint.findPointer(segmentPiece{paths: []string{"l/bucket/b"}, pieceID: "piece-b", pieceNum: 1}, exiting)
+	assert.NoError(t, err)
+	assert.Nil(t, pointer)
+
+	// the transferred piece moves to the replacement with its hash in all
+	// the pointers sharing it
+	assert.NoError(t, endpoint.updatePointers(sharingPaths(pieces[0], shared.Remote), "piece-a", func(remote *pb.RemoteSegment) bool {
+		return replacePiece(remote, exiting, 1, replacement)
+	}))
+	for _, path := range []string{"l/bucket/a", "l/bucket/e"} {
+		pointer, err := endpoint.getPointer(path)
+		if assert.NoError(t, err) {
+			pieces := pointer.Remote.RemotePieces
+			assert.Len(t, pieces, 2)
+			assert.Equal(t, replacement, pieces[1].NodeId)
+			assert.Equal(t, []byte("hash"), pieces[1].Hash)
+		}
+	}
+
+	// the piece whose transfer failed is dropped
+	assert.NoError(t, endpoint.updatePointer("l/bucket/b", "piece-b", func(remote *pb.RemoteSegment) bool {
+		return removePiece(remote, exiting, 0)
+	}))
+	pointer, err = endpoint.getPointer("l/bucket/b")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*pb.RemotePiece{{PieceNum: 1, NodeId: other}}, pointer.Remote.RemotePieces)
+	}
+
+	// the node has no pieces left
+	pieces, err = endpoint.nodePieces(ctx, exiting)
+	assert.NoError(t, err)
+	assert.Empty(t, pieces)
+
+	// the pointers deleted or reused for other pieces since are skipped
+	for _, path := range []string{"l/bucket/deleted", "l/bucket/c"} {
+		assert.NoError(t, endpoint.updatePointer(path, "piece-a", func(remote *pb.RemoteSegment) bool {
+			t.Error("updating a missing or reused pointer")
+			return false
+		}))
+	}
+
+	// a pointer changed while it is updated, by the repairer for example,
+	// is updated again from its new value
+	repaired := remote("piece-c",
+		&pb.RemotePiece{PieceNum: 0, NodeId: other},
+		&pb.RemotePiece{PieceNum: 1, NodeId: exiting},
+	)
+	updates := 0
+	assert.NoError(t, endpoint.updatePointer("l/bucket/c", "piece-c", func(remote *pb.RemoteSegment) bool {
+		updates++
+		if updates == 1 {
+			put("l/bucket/c", repaired)
+		}
+		return removePiece(remote, exiting, 1) || updates == 1
+	}))
+	assert.Equal(t, 2, updates)
+	pointer, err = endpoint.getPointer("l/bucket/c")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*pb.RemotePiece{{PieceNum: 0, NodeId: other}}, pointer.Remote.RemotePieces)
+	}
+}
+
+// replacementStore is a replacement node storing a piece
+type replacementStore struct {
+	id      psclient.PieceID
+	content []byte
+	root    []byte
+	hash    *psclient.PieceHash // hash the piece was read with
+}
+
+func (store *replacementStore) Meta(ctx context.Context, id psclient.PieceID) (*pb.PieceSummary, error) {
+	if id != store.id {
+		return nil, psclient.ClientError.New("piece not found")
+	}
+	return &pb.PieceSummary{Id: id.String(), PieceSize: int64(len(store.content))}, nil
+}
+
+func (store *replacementStore) Put(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int64) error {
+	return psclient.ClientError.New("unexpected upload")
+}
+
+func (store *replacementStore) Get(ctx context.Context, id psclient.PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, hash *psclient.PieceHash) (ranger.Ranger, error) {
+	store.hash = hash
+	if hash != nil && !bytes.Equal(hash.Root, store.root) {
+		return nil, psclient.ErrVerification.New("root hash mismatch")
+	}
+	return ranger.ByteRanger(store.content), nil
+}
+
+func (store *replacementStore) Delete(ctx context.Context, id psclient.PieceID, authorization *pb.SignedMessage) error {
+	return nil
+}
+
+func (store *replacementStore) Stats(ctx context.Context) (*pb.StatSummary, error) {
+	return &pb.StatSummary{}, nil
+}
+
+func (store *replacementStore) Close() error { return nil }
+
+func TestConfirmTransfer(t *testing.T) {
+	ctx := context.Background()
+	identity, err := testidentity.NewTestIdentity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := NewEndpoint(zap.NewNop(), nil, nil, nil, identity)
+
+	replacement := &pb.Node{Id: teststorj.NodeIDFromString("replacement")}
+	derivedID, err := psclient.PieceID("piece").Derive(replacement.Id.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &replacementStore{}
+	endpoint.newPSClientFunc = func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error) {
+		return store, nil
+	}
+
+	// 2 pieces of 2 erasure shares of 32 bytes
+	pointer := &pb.Pointer{
+		Type:        pb.Pointer_REMOTE,
+		SegmentSize: 100,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{
+				Type:             pb.RedundancyScheme_RS,
+				MinReq:           2,
+				Total:            4,
+				RepairThreshold:  2,
+				SuccessThreshold: 3,
+				ErasureShareSize: 32,
+			},
+			PieceId:      "piece",
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 1, Hash: []byte("root")}},
+		},
+	}
+
+	for _, tt := range []struct {
+		store     replacementStore
+		confirmed bool
+	}{
+		{replacementStore{id: derivedID, content: make([]byte, 64), root: []byte("root")}, true},
+		// the piece is missing or not the one sent
+		{replacementStore{id: "other", content: make([]byte, 64), root: []byte("root")}, false},
+		{replacementStore{id: derivedID, content: make([]byte, 32), root: []byte("root")}, false},
+		{replacementStore{id: derivedID, content: make([]byte, 64), root: []byte("other")}, false},
+	} {
+		*store = tt.store
+		err := endpoint.confirmTransfer(ctx, pointer, 1, replacement, nil)
+		if tt.confirmed {
+			assert.NoError(t, err)
+			if assert.NotNil(t, store.hash) {
+				assert.Equal(t, []byte("root"), store.hash.Root)
+				assert.EqualValues(t, 32, store.hash.ShareSize)
+			}
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
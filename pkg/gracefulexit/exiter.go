@@ -0,0 +1,145 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gracefulexit
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+)
+
+// Pieces gives access to the pieces stored by the storage node
+type Pieces interface {
+	// OpenPiece opens the content of the piece with id stored for the
+	// satellite of namespace
+	OpenPiece(ctx context.Context, namespace []byte, id string) (storage.ReadSeekCloser, error)
+}
+
+// Exiter implements the storage node side of the graceful exit, pushing its
+// pieces to the replacement nodes chosen by the satellite
+type Exiter struct {
+	log       *zap.Logger
+	identity  *provider.FullIdentity
+	transport transport.Client
+	pieces    Pieces
+}
+
+// NewExiter returns an exiter transferring the pieces of the storage node
+// with identity
+func NewExiter(log *zap.Logger, identity *provider.FullIdentity, pieces Pieces) *Exiter {
+	return &Exiter{
+		log:       log,
+		identity:  identity,
+		transport: transport.NewClient(identity),
+		pieces:    pieces,
+	}
+}
+
+// Exit exits from the satellite at address, transferring each piece it asks
+// for until the exit completes. The pieces are kept, as the segments of other
+// satellites may still reference them.
+//	returns the number of transferred pieces and of the failed transfers
+func (exiter *Exiter) Exit(ctx context.Context, address string) (transferred, failed int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := exiter.transport.DialAddress(ctx, address)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	stream, err := pb.NewGracefulExitClient(conn).Exit(ctx)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+	if err := stream.Send(&pb.ExitRequest{}); err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return transferred, failed, Error.New("exit ended before completing")
+		}
+		if err != nil {
+			return transferred, failed, Error.Wrap(err)
+		}
+		if resp.Completed {
+			return transferred, failed, Error.Wrap(stream.CloseSend())
+		}
+
+		piece := resp.GetTransfer()
+		if piece == nil {
+			return transferred, failed, Error.New("unexpected response without a transfer")
+		}
+
+		result := &pb.TransferResult{Path: piece.Path, PieceNum: piece.PieceNum, Success: true}
+		if err := exiter.transfer(ctx, piece); err != nil {
+			exiter.log.Warn("piece transfer failed",
+				zap.String("Path", piece.Path),
+				zap.Int32("Piece Num", piece.PieceNum),
+				zap.Error(err))
+			result.Success = false
+			result.Error = err.Error()
+			failed++
+		} else {
+			transferred++
+		}
+
+		if err := stream.Send(&pb.ExitRequest{Result: result}); err != nil {
+			return transferred, failed, Error.Wrap(err)
+		}
+	}
+}
+
+// transfer pushes the content of the piece to its replacement node
+func (exiter *Exiter) transfer(ctx context.Context, piece *pb.TransferPiece) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieceID := psclient.PieceID(piece.PieceId)
+	derivedID, err := pieceID.Derive(exiter.identity.ID.Bytes())
+	if err != nil {
+		return err
+	}
+	replacement := piece.GetReplacement()
+	if replacement == nil {
+		return Error.New("missing replacement node")
+	}
+	replacementID, err := pieceID.Derive(replacement.Id.Bytes())
+	if err != nil {
+		return err
+	}
+
+	data, err := exiter.pieces.OpenPiece(ctx, piece.GetAuthorization().GetData(), derivedID.String())
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, data.Close()) }()
+
+	expiration := time.Time{}
+	if piece.ExpirationDate != nil {
+		expiration, err = ptypes.Timestamp(piece.ExpirationDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := psclient.NewPSClient(ctx, exiter.transport, replacement, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, client.Close()) }()
+
+	return client.Put(ctx, replacementID, data, expiration, piece.Pba, piece.Authorization, int64(piece.ShareSize))
+}
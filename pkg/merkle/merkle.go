@@ -0,0 +1,180 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package merkle implements the hash trees over the erasure shares of the
+// pieces, and the proofs of the integrity of ranges of erasure shares.
+//
+// The trees are built like in RFC 6962: a leaf hashes its data with a 0x00
+// prefix, an interior node hashes its children with a 0x01 prefix, and the
+// left subtree of n leaves holds the largest power of two smaller than n.
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the errs class of merkle tree errors
+var Error = errs.Class("merkle error")
+
+// HashSize is the size of the hashes of the trees
+const HashSize = sha256.Size
+
+const (
+	leafPrefix     = 0x00
+	interiorPrefix = 0x01
+)
+
+// LeafHash returns the hash of a leaf holding data
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte{leafPrefix})
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+func interiorHash(left, right []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte{interiorPrefix})
+	_, _ = h.Write(left)
+	_, _ = h.Write(right)
+	return h.Sum(nil)
+}
+
+// split returns the number of leaves in the left subtree of n leaves
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// Root returns the root hash of the tree over the leaf hashes
+func Root(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		empty := sha256.Sum256(nil)
+		return empty[:]
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return interiorHash(Root(leaves[:k]), Root(leaves[k:]))
+}
+
+// Prove returns the proof of the leaf hashes from start to end, excluded.
+// The proof holds the roots of the subtrees without any of those leaves.
+func Prove(leaves [][]byte, start, end int) ([][]byte, error) {
+	if start < 0 || end > len(leaves) || start >= end {
+		return nil, Error.New("invalid range [%d, %d) of %d leaves", start, end, len(leaves))
+	}
+	var proof [][]byte
+	var prove func(lo, hi int)
+	prove = func(lo, hi int) {
+		switch {
+		case hi <= start || lo >= end:
+			proof = append(proof, Root(leaves[lo:hi]))
+		case start <= lo && hi <= end:
+			// computed from the leaves of the range
+		default:
+			k := split(hi - lo)
+			prove(lo, lo+k)
+			prove(lo+k, hi)
+		}
+	}
+	prove(0, len(leaves))
+	return proof, nil
+}
+
+// Verify checks that the leaf hashes of a range starting from start are the
+// ones of the tree of count leaves with the given root, as proven by proof.
+func Verify(root []byte, count, start int, leaves, proof [][]byte) error {
+	end := start + len(leaves)
+	if start < 0 || end > count || start >= end {
+		return Error.New("invalid range [%d, %d) of %d leaves", start, end, count)
+	}
+	var verify func(lo, hi int) ([]byte, error)
+	verify = func(lo, hi int) ([]byte, error) {
+		switch {
+		case hi <= start || lo >= end:
+			if len(proof) == 0 {
+				return nil, Error.New("proof too short")
+			}
+			hash := proof[0]
+			proof = proof[1:]
+			return hash, nil
+		case start <= lo && hi <= end:
+			return Root(leaves[lo-start : hi-start]), nil
+		default:
+			k := split(hi - lo)
+			left, err := verify(lo, lo+k)
+			if err != nil {
+				return nil, err
+			}
+			right, err := verify(lo+k, hi)
+			if err != nil {
+				return nil, err
+			}
+			return interiorHash(left, right), nil
+		}
+	}
+	computed, err := verify(0, count)
+	if err != nil {
+		return err
+	}
+	if len(proof) > 0 {
+		return Error.New("proof too long")
+	}
+	if !bytes.Equal(computed, root) {
+		return Error.New("root hash mismatch")
+	}
+	return nil
+}
+
+// ShareHasher is an io.Writer computing the leaf hashes of the erasure
+// shares written to it
+type ShareHasher struct {
+	shareSize int
+	buf       []byte
+	leaves    [][]byte
+}
+
+// NewShareHasher returns a ShareHasher for erasure shares of shareSize bytes
+func NewShareHasher(shareSize int) *ShareHasher {
+	return &ShareHasher{shareSize: shareSize}
+}
+
+// Write implements io.Writer
+func (h *ShareHasher) Write(p []byte) (n int, err error) {
+	n = len(p)
+	for len(p) > 0 {
+		free := h.shareSize - len(h.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		h.buf = append(h.buf, p[:free]...)
+		p = p[free:]
+		if len(h.buf) == h.shareSize {
+			h.leaves = append(h.leaves, LeafHash(h.buf))
+			h.buf = h.buf[:0]
+		}
+	}
+	return n, nil
+}
+
+// Leaves returns the leaf hashes of the erasure shares written so far,
+// including the last partial one
+func (h *ShareHasher) Leaves() [][]byte {
+	if len(h.buf) > 0 {
+		return append(h.leaves[:len(h.leaves):len(h.leaves)], LeafHash(h.buf))
+	}
+	return h.leaves
+}
+
+// Root returns the root hash of the erasure shares written so far
+func (h *ShareHasher) Root() []byte {
+	return Root(h.Leaves())
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = LeafHash([]byte{byte(i)})
+	}
+	return leaves
+}
+
+func TestProveVerify(t *testing.T) {
+	for count := 1; count <= 9; count++ {
+		leaves := testLeaves(count)
+		root := Root(leaves)
+		for start := 0; start < count; start++ {
+			for end := start + 1; end <= count; end++ {
+				errTag := fmt.Sprintf("Test case [%d, %d) of %d", start, end, count)
+
+				proof, err := Prove(leaves, start, end)
+				if !assert.NoError(t, err, errTag) {
+					continue
+				}
+				assert.NoError(t, Verify(root, count, start, leaves[start:end], proof), errTag)
+
+				// a single altered leaf fails the verification
+				altered := append([][]byte{}, leaves[start:end]...)
+				altered[len(altered)-1] = LeafHash([]byte("altered"))
+				assert.Error(t, Verify(root, count, start, altered, proof), errTag)
+
+				// so does a proof of another range
+				if start > 0 {
+					assert.Error(t, Verify(root, count, start-1, leaves[start:end], proof), errTag)
+				}
+			}
+		}
+	}
+}
+
+func TestProveInvalidRange(t *testing.T) {
+	leaves := testLeaves(4)
+	for i, tt := range []struct {
+		start, end int
+	}{
+		{-1, 2}, {0, 5}, {2, 2}, {3, 1},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+		_, err := Prove(leaves, tt.start, tt.end)
+		assert.Error(t, err, errTag)
+	}
+}
+
+func TestShareHasher(t *testing.T) {
+	data := bytes.Repeat([]byte("storj"), 100)
+	shareSize := 64
+
+	var leaves [][]byte
+	for i := 0; i < len(data); i += shareSize {
+		end := i + shareSize
+		if end > len(data) {
+			end = len(data)
+		}
+		leaves = append(leaves, LeafHash(data[i:end]))
+	}
+
+	h := NewShareHasher(shareSize)
+	// write in chunks not aligned to the erasure shares
+	for i := 0; i < len(data); i += 100 {
+		end := i + 100
+		if end > len(data) {
+			end = len(data)
+		}
+		_, err := h.Write(data[i:end])
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, leaves, h.Leaves())
+	assert.Equal(t, Root(leaves), h.Root())
+}
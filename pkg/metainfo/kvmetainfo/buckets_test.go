@@ -345,7 +345,7 @@ func newDB(planet *testplanet.Planet) (*DB, error) {
 		return nil, err
 	}
 
-	ec := ecclient.NewClient(planet.Uplinks[0].Identity, 0)
+	ec := ecclient.NewClient(planet.Uplinks[0].Identity, 0, nil)
 	fc, err := infectious.NewFEC(2, 4)
 	if err != nil {
 		return nil, err
@@ -360,15 +360,16 @@ func newDB(planet *testplanet.Planet) (*DB, error) {
 
 	key := new(storj.Key)
 	copy(key[:], TestEncKey)
+	rootKey := streams.RootKey(key)
 
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), rootKey, int(1*memory.KB), storj.AESGCM, 1, 0, storj.CompressionScheme{})
 	if err != nil {
 		return nil, err
 	}
 
 	buckets := buckets.NewStore(streams)
 
-	return New(buckets, streams, segments, pdb, key), nil
+	return New(buckets, streams, segments, pdb, rootKey), nil
 }
 
 func forAllCiphers(test func(cipher storj.Cipher)) {
@@ -376,6 +377,7 @@ func forAllCiphers(test func(cipher storj.Cipher)) {
 		storj.Unencrypted,
 		storj.AESGCM,
 		storj.SecretBox,
+		storj.XChaCha20Poly1305,
 	} {
 		test(cipher)
 	}
@@ -0,0 +1,299 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+// ConcatObjects writes the object at dstPath from the segments of the objects
+// at srcPaths, in their order, without transferring their data. The segments
+// are moved, so the source objects are deleted. An existing object at dstPath
+// is replaced only once the new one is committed.
+func (db *DB) ConcatObjects(ctx context.Context, srcBucket string, srcPaths []storj.Path, dstBucket string, dstPath storj.Path, info *storj.CreateObject) (object storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(srcPaths) == 0 {
+		return storj.Object{}, errClass.New("no objects to concatenate")
+	}
+
+	dstBucketInfo, err := db.GetBucket(ctx, dstBucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	if dstPath == "" {
+		return storj.Object{}, storj.ErrNoPath.New("")
+	}
+
+	dstFullpath := dstBucket + "/" + dstPath
+
+	dstEncryptedPath, err := db.key.EncryptPath(dstFullpath, dstBucketInfo.PathCipher)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	dstKey, err := db.key.DeriveContentKey(dstFullpath)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	// the segments of the new object, read from the source objects
+	var (
+		srcSegmentPaths  []storj.Path
+		pointers         []*pb.Pointer
+		segmentMetas     []*pb.SegmentMeta
+		segmentSizes     []int64
+		segmentChecksums [][]byte
+		partChecksums    [][]byte
+		streamMeta       pb.StreamMeta
+		streamInfo       pb.StreamInfo
+	)
+	knownSegmentChecksums, knownPartChecksums := true, true
+
+	for i, srcPath := range srcPaths {
+		if srcBucket == dstBucket && srcPath == dstPath {
+			return storj.Object{}, errClass.New("source and destination are the same object")
+		}
+
+		src, _, err := db.getInfo(ctx, committedPrefix, srcBucket, srcPath)
+		if err != nil {
+			return storj.Object{}, err
+		}
+
+		if i == 0 {
+			streamMeta.EncryptionType = src.streamMeta.EncryptionType
+			streamMeta.EncryptionBlockSize = src.streamMeta.EncryptionBlockSize
+			streamInfo.SegmentsSize = src.streamInfo.SegmentsSize
+			streamInfo.CompressionType = src.streamInfo.CompressionType
+		} else if src.streamMeta.EncryptionType != streamMeta.EncryptionType ||
+			src.streamMeta.EncryptionBlockSize != streamMeta.EncryptionBlockSize ||
+			src.streamInfo.CompressionType != streamInfo.CompressionType {
+			return storj.Object{}, errClass.New("objects with different encryption or compression cannot be concatenated")
+		}
+
+		srcKey, err := db.key.DeriveContentKey(src.fullpath)
+		if err != nil {
+			return storj.Object{}, err
+		}
+
+		cipher := storj.Cipher(src.streamMeta.EncryptionType)
+		lastIndex := src.streamInfo.NumberOfSegments - 1
+
+		for index := int64(0); index <= lastIndex; index++ {
+			srcSegmentPath := getSegmentPath(src.encryptedPath, index)
+			if index == lastIndex {
+				srcSegmentPath = committedPrefix + src.encryptedPath
+			}
+
+			pointer, _, _, err := db.pointers.Get(ctx, srcSegmentPath)
+			if err != nil {
+				return storj.Object{}, err
+			}
+
+			segmentMeta := &pb.SegmentMeta{}
+			size := src.streamInfo.SegmentsSize
+			if index < int64(len(src.streamInfo.SegmentSizes)) {
+				size = src.streamInfo.SegmentSizes[index]
+			}
+			if index == lastIndex {
+				if src.streamMeta.LastSegmentMeta != nil {
+					segmentMeta = src.streamMeta.LastSegmentMeta
+				}
+				size = src.streamInfo.LastSegmentSize
+			} else {
+				err = proto.Unmarshal(pointer.Metadata, segmentMeta)
+				if err != nil {
+					return storj.Object{}, err
+				}
+			}
+
+			if cipher != storj.Unencrypted {
+				err = reencryptKey(segmentMeta, cipher, srcKey, dstKey)
+				if err != nil {
+					return storj.Object{}, err
+				}
+			}
+
+			// the nonce is derived from the index in the source object
+			nonce, err := streams.ContentNonce(segmentMeta, index)
+			if err != nil {
+				return storj.Object{}, err
+			}
+			segmentMeta.ContentNonce = nonce[:]
+			segmentMeta.ContentSize = size
+
+			srcSegmentPaths = append(srcSegmentPaths, srcSegmentPath)
+			pointers = append(pointers, pointer)
+			segmentMetas = append(segmentMetas, segmentMeta)
+			segmentSizes = append(segmentSizes, size)
+		}
+
+		if int64(len(src.streamInfo.SegmentChecksums)) != src.streamInfo.NumberOfSegments {
+			knownSegmentChecksums = false
+		}
+		segmentChecksums = append(segmentChecksums, src.streamInfo.SegmentChecksums...)
+
+		// the checksum of a part is the checksum of its content
+		if len(src.streamInfo.Checksum) == 0 || src.streamInfo.ChecksumParts > 0 {
+			knownPartChecksums = false
+		}
+		partChecksums = append(partChecksums, src.streamInfo.Checksum)
+	}
+
+	lastIndex := int64(len(pointers) - 1)
+
+	metadata, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: info.ContentType,
+		UserDefined: info.Metadata,
+	})
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	streamInfo.NumberOfSegments = lastIndex + 1
+	streamInfo.LastSegmentSize = segmentSizes[lastIndex]
+	streamInfo.Metadata = metadata
+	for _, size := range segmentSizes[:lastIndex] {
+		if size != streamInfo.SegmentsSize {
+			streamInfo.SegmentSizes = segmentSizes[:lastIndex]
+			break
+		}
+	}
+	if knownSegmentChecksums {
+		streamInfo.SegmentChecksums = segmentChecksums
+	}
+	if knownPartChecksums {
+		streamInfo.Checksum = streams.PartsChecksum(partChecksums)
+		streamInfo.ChecksumParts = int64(len(partChecksums))
+	}
+
+	streamInfoData, err := proto.Marshal(&streamInfo)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	streamMeta.LastSegmentMeta = segmentMetas[lastIndex]
+	streamMetaData, err := proto.Marshal(&streamMeta)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	// the stream info is encrypted with the content key of the last segment
+	// and a random nonce, as the key was used for the content of its object
+	streamMetaData, err = streams.ReplaceStreamInfo(ctx, segments.Meta{Data: streamMetaData}, streamInfoData, dstFullpath, db.key)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	exp, err := ptypes.TimestampProto(info.Expires)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	dstSegmentPaths := make([]storj.Path, len(pointers))
+	pieceIDs := make(map[string]bool)
+	for index, pointer := range pointers {
+		dstSegmentPaths[index] = getSegmentPath(dstEncryptedPath, int64(index))
+		pointer.Metadata, err = proto.Marshal(segmentMetas[index])
+		if err != nil {
+			return storj.Object{}, err
+		}
+		pointer.ExpirationDate = exp
+		if pointer.GetType() == pb.Pointer_REMOTE {
+			pieceIDs[pointer.GetRemote().GetPieceId()] = true
+		}
+	}
+	dstSegmentPaths[lastIndex] = committedPrefix + dstEncryptedPath
+	pointers[lastIndex].Metadata = streamMetaData
+
+	// the pointers of the replaced object, to delete its pieces after the commit
+	var replacedPaths []storj.Path
+	var replaced []*pb.Pointer
+	old, _, err := db.getInfo(ctx, committedPrefix, dstBucket, dstPath)
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return storj.Object{}, err
+	}
+	if err == nil {
+		oldLastIndex := old.streamInfo.NumberOfSegments - 1
+		for index := int64(0); index <= oldLastIndex; index++ {
+			path := getSegmentPath(dstEncryptedPath, index)
+			if index == oldLastIndex {
+				path = committedPrefix + dstEncryptedPath
+			}
+
+			pointer, _, _, err := db.pointers.Get(ctx, path)
+			if err != nil {
+				return storj.Object{}, err
+			}
+
+			replacedPaths = append(replacedPaths, path)
+			replaced = append(replaced, pointer)
+		}
+	}
+
+	for index, pointer := range pointers {
+		err = segments.MoveSharedPieces(ctx, db.pointers, pointer, dstSegmentPaths[index])
+		if err != nil {
+			return storj.Object{}, err
+		}
+	}
+
+	// the last segment is written last, so the object shows up only when complete
+	for index, pointer := range pointers {
+		err = db.pointers.Put(ctx, dstSegmentPaths[index], pointer)
+		if err != nil {
+			return storj.Object{}, err
+		}
+	}
+
+	for index, pointer := range replaced {
+		path := replacedPaths[index]
+
+		// pieces already referenced by the new object were moved
+		// there by an interrupted concatenation
+		if !pieceIDs[pointer.GetRemote().GetPieceId()] {
+			err = db.segments.DeletePieces(ctx, path, pointer)
+			if err != nil {
+				return storj.Object{}, err
+			}
+		}
+
+		// the paths of the segments beyond the new ones are not overwritten
+		if int64(index) >= lastIndex && index < len(replaced)-1 {
+			err = db.pointers.Delete(ctx, path)
+			if err != nil {
+				return storj.Object{}, err
+			}
+		}
+	}
+
+	// the last segment of each source object is deleted first,
+	// so the object disappears at once
+	for index := lastIndex; index >= 0; index-- {
+		pointer := pointers[index]
+		if pointer.GetType() == pb.Pointer_REMOTE {
+			_, err = segments.UnsharePieces(ctx, db.pointers, srcSegmentPaths[index], pointer)
+			if err != nil {
+				return storj.Object{}, err
+			}
+		}
+
+		err = db.pointers.Delete(ctx, srcSegmentPaths[index])
+		if err != nil {
+			return storj.Object{}, err
+		}
+	}
+
+	_, object, err = db.getInfo(ctx, committedPrefix, dstBucket, dstPath)
+	return object, err
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storj"
+)
+
+// CopyObject copies an object to another path without transferring its data.
+// The copy references the same remote pieces as the source, so the pieces
+// are kept on the storage nodes until both of the objects are deleted.
+func (db *DB) CopyObject(ctx context.Context, srcBucket string, srcPath storj.Path, dstBucket string, dstPath storj.Path) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.copyObject(ctx, srcBucket, srcPath, dstBucket, dstPath, false)
+}
+
+// MoveObject moves an object to another path without transferring its data
+func (db *DB) MoveObject(ctx context.Context, srcBucket string, srcPath storj.Path, dstBucket string, dstPath storj.Path) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.copyObject(ctx, srcBucket, srcPath, dstBucket, dstPath, true)
+}
+
+// copyObject writes pointers for dst referencing the segments of src,
+// re-encrypting the content keys with the key derived from the new path.
+// When move is set the pointers of src are deleted afterwards.
+func (db *DB) copyObject(ctx context.Context, srcBucket string, srcPath storj.Path, dstBucket string, dstPath storj.Path, move bool) (info storj.Object, err error) {
+	src, _, err := db.getInfo(ctx, committedPrefix, srcBucket, srcPath)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	dstBucketInfo, err := db.GetBucket(ctx, dstBucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	if dstPath == "" {
+		return storj.Object{}, storj.ErrNoPath.New("")
+	}
+
+	if srcBucket == dstBucket && srcPath == dstPath {
+		return storj.Object{}, errClass.New("source and destination are the same object")
+	}
+
+	dstFullpath := dstBucket + "/" + dstPath
+
+	dstEncryptedPath, err := db.key.EncryptPath(dstFullpath, dstBucketInfo.PathCipher)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	srcKey, err := db.key.DeriveContentKey(src.fullpath)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	dstKey, err := db.key.DeriveContentKey(dstFullpath)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	// replace the existing object, as an upload would do
+	err = db.DeleteObject(ctx, dstBucket, dstPath)
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return storj.Object{}, err
+	}
+
+	cipher := storj.Cipher(src.streamMeta.EncryptionType)
+	lastIndex := src.streamInfo.NumberOfSegments - 1
+
+	// the pointers of src, to unshare their pieces when moved
+	moved := make([]*pb.Pointer, lastIndex+1)
+
+	// the last segment is written last, so the object shows up only when complete
+	for index := int64(0); index <= lastIndex; index++ {
+		srcSegmentPath := getSegmentPath(src.encryptedPath, index)
+		dstSegmentPath := getSegmentPath(dstEncryptedPath, index)
+		if index == lastIndex {
+			srcSegmentPath = committedPrefix + src.encryptedPath
+			dstSegmentPath = committedPrefix + dstEncryptedPath
+		}
+
+		pointer, _, _, err := db.pointers.Get(ctx, srcSegmentPath)
+		if err != nil {
+			return storj.Object{}, err
+		}
+
+		if pointer.GetType() == pb.Pointer_REMOTE {
+			if move {
+				moved[index] = pointer
+				err = segments.MoveSharedPieces(ctx, db.pointers, pointer, dstSegmentPath)
+			} else {
+				var shared []string
+				shared, err = segments.SharePieces(ctx, db.pointers, srcSegmentPath, pointer, dstSegmentPath)
+				pointer.Remote.SharedPaths = shared
+			}
+			if err != nil {
+				return storj.Object{}, err
+			}
+		}
+
+		if index == lastIndex {
+			pointer.Metadata, err = reencryptStreamMeta(pointer.Metadata, cipher, srcKey, dstKey)
+		} else {
+			pointer.Metadata, err = reencryptSegmentMeta(pointer.Metadata, cipher, srcKey, dstKey)
+		}
+		if err != nil {
+			return storj.Object{}, err
+		}
+
+		err = db.pointers.Put(ctx, dstSegmentPath, pointer)
+		if err != nil {
+			return storj.Object{}, err
+		}
+	}
+
+	if move {
+		// the last segment is deleted first, so the object disappears at once
+		for index := lastIndex; index >= 0; index-- {
+			srcSegmentPath := getSegmentPath(src.encryptedPath, index)
+			if index == lastIndex {
+				srcSegmentPath = committedPrefix + src.encryptedPath
+			}
+
+			if moved[index] != nil {
+				_, err = segments.UnsharePieces(ctx, db.pointers, srcSegmentPath, moved[index])
+				if err != nil {
+					return storj.Object{}, err
+				}
+			}
+
+			err = db.pointers.Delete(ctx, srcSegmentPath)
+			if err != nil {
+				return storj.Object{}, err
+			}
+		}
+	}
+
+	_, info, err = db.getInfo(ctx, committedPrefix, dstBucket, dstPath)
+	return info, err
+}
+
+// reencryptSegmentMeta re-encrypts the content key in a marshaled pb.SegmentMeta
+func reencryptSegmentMeta(data []byte, cipher storj.Cipher, srcKey, dstKey *storj.Key) ([]byte, error) {
+	if cipher == storj.Unencrypted {
+		return data, nil
+	}
+
+	segmentMeta := pb.SegmentMeta{}
+	err := proto.Unmarshal(data, &segmentMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reencryptKey(&segmentMeta, cipher, srcKey, dstKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&segmentMeta)
+}
+
+// reencryptStreamMeta re-encrypts the content key of the last segment in a
+// marshaled pb.StreamMeta. The stream info is encrypted with the content key
+// itself, so it stays unchanged.
+func reencryptStreamMeta(data []byte, cipher storj.Cipher, srcKey, dstKey *storj.Key) ([]byte, error) {
+	streamMeta := pb.StreamMeta{}
+	err := proto.Unmarshal(data, &streamMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if cipher == storj.Unencrypted || streamMeta.LastSegmentMeta == nil {
+		return data, nil
+	}
+
+	err = reencryptKey(streamMeta.LastSegmentMeta, cipher, srcKey, dstKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&streamMeta)
+}
+
+// reencryptKey decrypts the content key in segmentMeta with srcKey and
+// encrypts it again with dstKey and a new random nonce
+func reencryptKey(segmentMeta *pb.SegmentMeta, cipher storj.Cipher, srcKey, dstKey *storj.Key) error {
+	var keyNonce storj.Nonce
+	copy(keyNonce[:], segmentMeta.KeyNonce)
+
+	contentKey, err := encryption.DecryptKey(segmentMeta.EncryptedKey, cipher, srcKey, &keyNonce)
+	if err != nil {
+		return err
+	}
+
+	_, err = rand.Read(keyNonce[:])
+	if err != nil {
+		return err
+	}
+
+	segmentMeta.EncryptedKey, err = encryption.EncryptKey(contentKey, cipher, dstKey, &keyNonce)
+	if err != nil {
+		return err
+	}
+	segmentMeta.KeyNonce = keyNonce[:]
+
+	return nil
+}
@@ -4,6 +4,8 @@
 package kvmetainfo
 
 import (
+	"context"
+
 	"github.com/zeebo/errs"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
@@ -31,17 +33,17 @@ type DB struct {
 	segments segments.Store
 	pointers pdbclient.Client
 
-	rootKey *storj.Key
+	key *streams.PrefixKey
 }
 
 // New creates a new metainfo database
-func New(buckets buckets.Store, streams streams.Store, segments segments.Store, pointers pdbclient.Client, rootKey *storj.Key) *DB {
+func New(buckets buckets.Store, streams streams.Store, segments segments.Store, pointers pdbclient.Client, key *streams.PrefixKey) *DB {
 	return &DB{
 		buckets:  buckets,
 		streams:  streams,
 		segments: segments,
 		pointers: pointers,
-		rootKey:  rootKey,
+		key:      key,
 	}
 }
 
@@ -53,3 +55,27 @@ func (db *DB) Limits() (storj.MetainfoLimits, error) {
 		MaximumInlineSegmentSize: int64(memory.MB),
 	}, nil
 }
+
+// GetUsage returns the usage of all buckets, or of a single bucket if bucket is not empty
+func (db *DB) GetUsage(ctx context.Context, bucket string) (usage storj.Usage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if bucket != "" {
+		_, err = db.GetBucket(ctx, bucket)
+		if err != nil {
+			return storj.Usage{}, err
+		}
+	}
+
+	resp, err := db.pointers.Usage(ctx, bucket)
+	if err != nil {
+		return storj.Usage{}, err
+	}
+
+	return storj.Usage{
+		StoredBytes: resp.GetStoredBytes(),
+		Objects:     resp.GetObjects(),
+		Segments:    resp.GetSegments(),
+		EgressBytes: resp.GetEgressBytes(),
+	}, nil
+}
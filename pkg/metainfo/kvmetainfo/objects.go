@@ -5,7 +5,6 @@ package kvmetainfo
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -14,7 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/memory"
-	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storage/objects"
@@ -27,6 +26,8 @@ import (
 const (
 	// commitedPrefix is prefix where completed object info is stored
 	committedPrefix = "l/"
+	// pendingPrefix is prefix where the info of objects being uploaded is stored
+	pendingPrefix = "p/"
 )
 
 var defaultRS = storj.RedundancyScheme{
@@ -61,16 +62,18 @@ func (db *DB) GetObjectStream(ctx context.Context, bucket string, path storj.Pat
 		return nil, err
 	}
 
-	streamKey, err := encryption.DeriveContentKey(meta.fullpath, db.rootKey)
+	streamKey, err := db.key.DeriveContentKey(meta.fullpath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &readonlyStream{
-		db:            db,
-		info:          info,
-		encryptedPath: meta.encryptedPath,
-		streamKey:     streamKey,
+		db:               db,
+		info:             info,
+		encryptedPath:    meta.encryptedPath,
+		streamKey:        streamKey,
+		segmentSizes:     meta.streamInfo.SegmentSizes,
+		segmentChecksums: meta.streamInfo.SegmentChecksums,
 	}, nil
 }
 
@@ -123,7 +126,17 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 // ModifyObject modifies a committed object
 func (db *DB) ModifyObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil, errors.New("not implemented")
+
+	_, info, err := db.getInfo(ctx, committedPrefix, bucket, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mutableObject{
+		db:        db,
+		info:      info,
+		committed: true,
+	}, nil
 }
 
 // DeleteObject deletes an object from database
@@ -141,13 +154,54 @@ func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path)
 // ModifyPendingObject creates an interface for updating a partially uploaded object
 func (db *DB) ModifyPendingObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil, errors.New("not implemented")
+
+	// the pending marker keeps the schemes of the upload
+	_, info, err := db.getInfo(ctx, pendingPrefix, bucket, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mutableObject{
+		db:   db,
+		info: info,
+	}, nil
 }
 
 // ListPendingObjects lists pending objects in bucket based on the ListOptions
 func (db *DB) ListPendingObjects(ctx context.Context, bucket string, options storj.ListOptions) (list storj.ObjectList, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return storj.ObjectList{}, errors.New("not implemented")
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	startAfter, endBefore, err := listBounds(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := db.streams.ListPending(ctx, storj.JoinPaths(bucket, options.Prefix), startAfter, endBefore, bucketInfo.PathCipher, options.Recursive, options.Limit, meta.All)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		object, err := objectFromStreamMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta)
+		if err != nil {
+			return storj.ObjectList{}, err
+		}
+		list.Items = append(list.Items, object)
+	}
+
+	return list, nil
 }
 
 // ListObjects lists objects in bucket based on the ListOptions
@@ -164,7 +218,33 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		return storj.ObjectList{}, err
 	}
 
-	var startAfter, endBefore string
+	startAfter, endBefore, err := listBounds(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
+	}
+
+	return list, nil
+}
+
+// listBounds converts the cursor and the direction of options
+// to the startAfter and endBefore keys of a listing
+func listBounds(options storj.ListOptions) (startAfter, endBefore string, err error) {
 	switch options.Direction {
 	case storj.Before:
 		// before lists backwards from cursor, without cursor
@@ -179,7 +259,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		// after lists forwards from cursor, without cursor
 		startAfter = options.Cursor
 	default:
-		return storj.ObjectList{}, errClass.New("invalid direction %d", options.Direction)
+		return "", "", errClass.New("invalid direction %d", options.Direction)
 	}
 
 	// TODO: remove this hack-fix of specifying the last key
@@ -187,23 +267,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		endBefore = "\x7f\x7f\x7f\x7f\x7f\x7f\x7f"
 	}
 
-	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
-	if err != nil {
-		return storj.ObjectList{}, err
-	}
-
-	list = storj.ObjectList{
-		Bucket: bucket,
-		Prefix: options.Prefix,
-		More:   more,
-		Items:  make([]storj.Object, 0, len(items)),
-	}
-
-	for _, item := range items {
-		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
-	}
-
-	return list, nil
+	return startAfter, endBefore, nil
 }
 
 type object struct {
@@ -228,7 +292,7 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 
 	fullpath := bucket + "/" + path
 
-	encryptedPath, err := streams.EncryptAfterBucket(fullpath, bucketInfo.PathCipher, db.rootKey)
+	encryptedPath, err := db.key.EncryptPath(fullpath, bucketInfo.PathCipher)
 	if err != nil {
 		return object{}, storj.Object{}, err
 	}
@@ -241,21 +305,6 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
-	var redundancyScheme *pb.RedundancyScheme
-	if pointer.GetType() == pb.Pointer_REMOTE {
-		redundancyScheme = pointer.GetRemote().GetRedundancy()
-	} else {
-		// TODO: handle better
-		redundancyScheme = &pb.RedundancyScheme{
-			Type:             pb.RedundancyScheme_RS,
-			MinReq:           -1,
-			Total:            -1,
-			RepairThreshold:  -1,
-			SuccessThreshold: -1,
-			ErasureShareSize: -1,
-		}
-	}
-
 	lastSegmentMeta := segments.Meta{
 		Modified:   convertTime(pointer.GetCreationDate()),
 		Expiration: convertTime(pointer.GetExpirationDate()),
@@ -263,7 +312,7 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		Data:       pointer.GetMetadata(),
 	}
 
-	streamInfoData, err := streams.DecryptStreamInfo(ctx, lastSegmentMeta, fullpath, db.rootKey)
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, lastSegmentMeta, fullpath, db.key)
 	if err != nil {
 		return object{}, storj.Object{}, err
 	}
@@ -280,6 +329,23 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
+	var redundancyScheme *pb.RedundancyScheme
+	if pointer.GetType() == pb.Pointer_REMOTE {
+		redundancyScheme = pointer.GetRemote().GetRedundancy()
+	} else if streamInfo.Redundancy != nil {
+		redundancyScheme = streamInfo.Redundancy
+	} else {
+		// TODO: handle better
+		redundancyScheme = &pb.RedundancyScheme{
+			Type:             pb.RedundancyScheme_RS,
+			MinReq:           -1,
+			Total:            -1,
+			RepairThreshold:  -1,
+			SuccessThreshold: -1,
+			ErasureShareSize: -1,
+		}
+	}
+
 	info, err = objectStreamFromMeta(bucketInfo, path, lastSegmentMeta, streamInfo, streamMeta, redundancyScheme)
 	if err != nil {
 		return object{}, storj.Object{}, err
@@ -309,15 +375,35 @@ func objectFromMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta ob
 		Expires:     meta.Expiration,
 
 		Stream: storj.Stream{
-			Size:     meta.Size,
-			Checksum: []byte(meta.Checksum),
+			Size:          meta.Size,
+			Checksum:      []byte(meta.Checksum),
+			ChecksumParts: meta.ChecksumParts,
+			SegmentCount:  meta.SegmentCount,
 		},
 	}
 }
 
+func objectFromStreamMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, m streams.Meta) (storj.Object, error) {
+	serMetaInfo := pb.SerializableMeta{}
+	err := proto.Unmarshal(m.Data, &serMetaInfo)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	return objectFromMeta(bucket, path, isPrefix, objects.Meta{
+		SerializableMeta: serMetaInfo,
+		Modified:         m.Modified,
+		Expiration:       m.Expiration,
+		Size:             m.Size,
+		SegmentCount:     m.SegmentCount,
+		Checksum:         string(m.Checksum),
+		ChecksumParts:    m.ChecksumParts,
+	}), nil
+}
+
 func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segments.Meta, stream pb.StreamInfo, streamMeta pb.StreamMeta, redundancyScheme *pb.RedundancyScheme) (storj.Object, error) {
 	var nonce storj.Nonce
-	copy(nonce[:], streamMeta.LastSegmentMeta.KeyNonce)
+	copy(nonce[:], streamMeta.GetLastSegmentMeta().GetKeyNonce())
 
 	serMetaInfo := pb.SerializableMeta{}
 	err := proto.Unmarshal(stream.Metadata, &serMetaInfo)
@@ -325,6 +411,11 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		return storj.Object{}, err
 	}
 
+	fixedSegmentSize := stream.SegmentsSize
+	if len(stream.SegmentSizes) > 0 {
+		fixedSegmentSize = -1
+	}
+
 	return storj.Object{
 		Version:  0, // TODO:
 		Bucket:   bucket,
@@ -339,14 +430,15 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		Expires:     lastSegment.Expiration, // TODO: use correct field
 
 		Stream: storj.Stream{
-			Size: stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
-			// Checksum: []byte(object.Checksum),
+			Size:          streams.StreamSize(&stream),
+			Checksum:      stream.Checksum,
+			ChecksumParts: stream.ChecksumParts,
 
 			SegmentCount:     stream.NumberOfSegments,
-			FixedSegmentSize: stream.SegmentsSize,
+			FixedSegmentSize: fixedSegmentSize,
 
 			RedundancyScheme: storj.RedundancyScheme{
-				Algorithm:      storj.ReedSolomon,
+				Algorithm:      eestream.Algorithm(redundancyScheme.GetType()),
 				ShareSize:      redundancyScheme.GetErasureShareSize(),
 				RequiredShares: int16(redundancyScheme.GetMinReq()),
 				RepairShares:   int16(redundancyScheme.GetRepairThreshold()),
@@ -360,7 +452,7 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 			LastSegment: storj.LastSegment{
 				Size:              stream.LastSegmentSize,
 				EncryptedKeyNonce: nonce,
-				EncryptedKey:      streamMeta.LastSegmentMeta.EncryptedKey,
+				EncryptedKey:      streamMeta.GetLastSegmentMeta().GetEncryptedKey(),
 			},
 		},
 	}, nil
@@ -378,30 +470,226 @@ func convertTime(ts *timestamp.Timestamp) time.Time {
 	return t
 }
 
+// convertRedundancyScheme converts the redundancy scheme to its form in the pointers
+func convertRedundancyScheme(rs storj.RedundancyScheme) (*pb.RedundancyScheme, error) {
+	schemeType, err := eestream.SchemeTypeOf(rs.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RedundancyScheme{
+		Type:             schemeType,
+		MinReq:           int32(rs.RequiredShares),
+		Total:            int32(rs.TotalShares),
+		RepairThreshold:  int32(rs.RepairShares),
+		SuccessThreshold: int32(rs.OptimalShares),
+		ErasureShareSize: rs.ShareSize,
+	}, nil
+}
+
 type mutableObject struct {
 	db   *DB
 	info storj.Object
+	// committed is set for objects returned by ModifyObject,
+	// where Commit only updates the metadata
+	committed bool
 }
 
 func (object *mutableObject) Info() storj.Object { return object.info }
 
-func (object *mutableObject) CreateStream(ctx context.Context) (storj.MutableStream, error) {
+// SetMetadata sets the metadata used by the stream created next or,
+// for a committed object, the metadata stored by Commit.
+func (object *mutableObject) SetMetadata(contentType string, metadata map[string]string) {
+	object.info.ContentType = contentType
+	object.info.Metadata = metadata
+}
+
+// CreateStream creates a new stream for the object and marks the object
+// as pending until it is committed.
+func (object *mutableObject) CreateStream(ctx context.Context) (_ storj.MutableStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if object.committed {
+		return nil, errClass.New("object %q is already committed", object.info.Path)
+	}
+
+	err = object.putPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &mutableStream{
 		db:   object.db,
 		info: object.info,
 	}, nil
 }
 
-func (object *mutableObject) ContinueStream(ctx context.Context) (storj.MutableStream, error) {
-	return nil, errors.New("not implemented")
+// ContinueStream returns the stream of an interrupted upload, with the
+// segments that were already stored by it. The returned stream info has
+// the number and the total size of those segments.
+func (object *mutableObject) ContinueStream(ctx context.Context) (_ storj.MutableStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, _, err = object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
+	if err == nil {
+		return nil, errClass.New("object %q is already committed", object.info.Path)
+	}
+	if !storj.ErrObjectNotFound.Has(err) {
+		return nil, err
+	}
+
+	err = object.putPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fullpath := object.info.Bucket.Name + "/" + object.info.Path
+
+	segmentCount, size, err := object.db.streams.Committed(ctx, fullpath, object.info.Bucket.PathCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	info := object.info
+	info.SegmentCount = segmentCount
+	info.Size = size
+	// the stored segments may differ in size
+	info.FixedSegmentSize = -1
+
+	return &mutableStream{
+		db:   object.db,
+		info: info,
+	}, nil
 }
 
-func (object *mutableObject) DeleteStream(ctx context.Context) error {
-	return errors.New("not implemented")
+// DeleteStream deletes the segments stored by the pending upload of the
+// object and its mark as pending. A committed object is left untouched,
+// as the stored segments are its own then.
+func (object *mutableObject) DeleteStream(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fullpath := object.info.Bucket.Name + "/" + object.info.Path
+
+	_, _, err = object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
+	switch {
+	case err == nil:
+		err = object.db.streams.DeletePending(ctx, fullpath, object.info.Bucket.PathCipher)
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		return err
+	case !storj.ErrObjectNotFound.Has(err):
+		return err
+	}
+
+	encryptedPath, err := object.db.key.EncryptPath(fullpath, object.info.Bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	for index := int64(0); ; index++ {
+		err = object.db.segments.Delete(ctx, getSegmentPath(encryptedPath, index))
+		if storage.ErrKeyNotFound.Has(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the object stays pending until all its segments are deleted
+	err = object.db.streams.DeletePending(ctx, fullpath, object.info.Bucket.PathCipher)
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil
+	}
+	return err
 }
 
-func (object *mutableObject) Commit(ctx context.Context) error {
+// Commit reads the info of the uploaded object and removes
+// the mark of the object as pending. For committed objects
+// it stores their new metadata instead.
+func (object *mutableObject) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if object.committed {
+		return object.commitMetadata(ctx)
+	}
+
 	_, info, err := object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
 	object.info = info
+	if err != nil {
+		return err
+	}
+
+	fullpath := object.info.Bucket.Name + "/" + object.info.Path
+
+	err = object.db.streams.DeletePending(ctx, fullpath, object.info.Bucket.PathCipher)
+	if storage.ErrKeyNotFound.Has(err) {
+		return nil
+	}
+	return err
+}
+
+// putPending marks the object as pending until it is committed or its stream deleted
+func (object *mutableObject) putPending(ctx context.Context) error {
+	metadata, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: object.info.ContentType,
+		UserDefined: object.info.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	redundancy, err := convertRedundancyScheme(object.info.RedundancyScheme)
+	if err != nil {
+		return err
+	}
+
+	fullpath := object.info.Bucket.Name + "/" + object.info.Path
+
+	_, err = object.db.streams.PutPending(ctx, fullpath, object.info.Bucket.PathCipher, metadata, object.info.Expires, redundancy, object.info.EncryptionScheme)
+	return err
+}
+
+// commitMetadata replaces the stream info in the last segment of a committed
+// object with one holding the new metadata, leaving its data untouched
+func (object *mutableObject) commitMetadata(ctx context.Context) error {
+	obj, _, err := object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
+	if err != nil {
+		return err
+	}
+
+	pointer, _, _, err := object.db.pointers.Get(ctx, committedPrefix+obj.encryptedPath)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := proto.Marshal(&pb.SerializableMeta{
+		ContentType: object.info.ContentType,
+		UserDefined: object.info.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	streamInfo := obj.streamInfo
+	streamInfo.Metadata = metadata
+
+	streamInfoData, err := proto.Marshal(&streamInfo)
+	if err != nil {
+		return err
+	}
+
+	pointer.Metadata, err = streams.ReplaceStreamInfo(ctx, obj.lastSegmentMeta, streamInfoData, obj.fullpath, object.db.key)
+	if err != nil {
+		return err
+	}
+
+	err = object.db.pointers.Put(ctx, committedPrefix+obj.encryptedPath, pointer)
+	if err != nil {
+		return err
+	}
+
+	_, object.info, err = object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
 	return err
 }
@@ -5,6 +5,7 @@ package kvmetainfo
 
 import (
 	"context"
+	"crypto/md5"
 	"crypto/rand"
 	"fmt"
 	"io"
@@ -14,8 +15,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/storage/buckets"
+	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/stream"
+	"storj.io/storj/storage"
 )
 
 const TestFile = "test-file"
@@ -151,6 +156,86 @@ func TestGetObjectStream(t *testing.T) {
 	})
 }
 
+func TestSegmentsAt(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "empty-file", nil)
+		upload(ctx, t, db, bucket, "small-file", []byte("test"))
+
+		for i, tt := range []struct {
+			path     storj.Path
+			offset   int64
+			segments int
+			err      bool
+		}{
+			{path: "empty-file", offset: 0, segments: 1},
+			{path: "empty-file", offset: 1, segments: 0},
+			{path: "small-file", offset: 0, segments: 1},
+			{path: "small-file", offset: 3, segments: 1},
+			{path: "small-file", offset: 4, segments: 0},
+			{path: "small-file", offset: -1, err: true},
+		} {
+			errTag := fmt.Sprintf("%d. %+v", i, tt)
+
+			readOnly, err := db.GetObjectStream(ctx, bucket.Name, tt.path)
+			if !assert.NoError(t, err, errTag) {
+				return
+			}
+
+			segments, more, err := readOnly.SegmentsAt(ctx, tt.offset, 0)
+			if tt.err {
+				assert.Error(t, err, errTag)
+				continue
+			}
+			if assert.NoError(t, err, errTag) {
+				assert.False(t, more, errTag)
+				assert.Equal(t, tt.segments, len(segments), errTag)
+			}
+		}
+	})
+}
+
+func TestSegmentIndex(t *testing.T) {
+	for i, tt := range []struct {
+		segmentCount     int64
+		fixedSegmentSize int64
+		offset           int64
+		index            int64
+	}{
+		{segmentCount: 1, fixedSegmentSize: 10, offset: 5, index: 0},
+		{segmentCount: 1, fixedSegmentSize: -1, offset: 5, index: 0},
+		{segmentCount: 3, fixedSegmentSize: 10, offset: 0, index: 0},
+		{segmentCount: 3, fixedSegmentSize: 10, offset: 9, index: 0},
+		{segmentCount: 3, fixedSegmentSize: 10, offset: 10, index: 1},
+		{segmentCount: 3, fixedSegmentSize: 10, offset: 25, index: 2},
+		{segmentCount: 3, fixedSegmentSize: 10, offset: 35, index: 2},
+	} {
+		errTag := fmt.Sprintf("%d. %+v", i, tt)
+
+		stream := readonlyStream{info: storj.Object{Stream: storj.Stream{
+			SegmentCount:     tt.segmentCount,
+			FixedSegmentSize: tt.fixedSegmentSize,
+		}}}
+
+		index, err := stream.segmentIndex(tt.offset)
+		if assert.NoError(t, err, errTag) {
+			assert.Equal(t, tt.index, index, errTag)
+		}
+	}
+
+	stream := readonlyStream{info: storj.Object{Stream: storj.Stream{
+		SegmentCount:     3,
+		FixedSegmentSize: -1,
+	}}}
+
+	_, err := stream.segmentIndex(5)
+	assert.Error(t, err)
+}
+
 func upload(ctx context.Context, t *testing.T, db *DB, bucket storj.Bucket, path storj.Path, data []byte) {
 	obj, err := db.CreateObject(ctx, bucket.Name, path, nil)
 	if !assert.NoError(t, err) {
@@ -190,6 +275,9 @@ func assertStream(ctx context.Context, t *testing.T, db *DB, bucket storj.Bucket
 	assert.Equal(t, TestBucket, readOnly.Info().Bucket.Name)
 	assert.Equal(t, storj.AESGCM, readOnly.Info().Bucket.PathCipher)
 
+	checksum := md5.Sum(content)
+	assert.Equal(t, checksum[:], readOnly.Info().Checksum)
+
 	segments, more, err := readOnly.Segments(ctx, 0, 0)
 	if !assert.NoError(t, err) {
 		return
@@ -202,6 +290,7 @@ func assertStream(ctx context.Context, t *testing.T, db *DB, bucket storj.Bucket
 
 	assert.EqualValues(t, 0, segments[0].Index)
 	assert.EqualValues(t, len(content), segments[0].Size)
+	assert.Equal(t, checksum[:], segments[0].Checksum)
 	if segments[0].Size > int64(4*memory.KB) {
 		assertRemoteSegment(t, segments[0])
 	} else {
@@ -278,6 +367,476 @@ func TestDeleteObject(t *testing.T) {
 	})
 }
 
+func TestCopyObject(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		data := make([]byte, 32*memory.KB)
+		_, err := rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "small-file", []byte("test"))
+		upload(ctx, t, db, bucket, "large-file", data)
+
+		_, err = db.CopyObject(ctx, bucket.Name, "non-existing-file", bucket.Name, "copy")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		_, err = db.CopyObject(ctx, bucket.Name, "small-file", "non-existing-bucket", "copy")
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		_, err = db.CopyObject(ctx, bucket.Name, "small-file", bucket.Name, "")
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.CopyObject(ctx, bucket.Name, "small-file", bucket.Name, "small-file")
+		assert.Error(t, err)
+
+		info, err := db.CopyObject(ctx, bucket.Name, "small-file", bucket.Name, "small-copy")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "small-copy", info.Path)
+			assert.EqualValues(t, 4, info.Size)
+		}
+
+		info, err = db.CopyObject(ctx, bucket.Name, "large-file", bucket.Name, "large-copy")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "large-copy", info.Path)
+			assert.EqualValues(t, 32*memory.KB, info.Size)
+		}
+
+		// the source and the copy reference each other
+		sharedPaths := func(path storj.Path) []string {
+			obj, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, path)
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			pointer, _, _, err := db.pointers.Get(ctx, committedPrefix+obj.encryptedPath)
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			return pointer.GetRemote().GetSharedPaths()
+		}
+		src, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, "large-file")
+		assert.NoError(t, err)
+		dst, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, "large-copy")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{committedPrefix + dst.encryptedPath}, sharedPaths("large-file"))
+		assert.Equal(t, []string{committedPrefix + src.encryptedPath}, sharedPaths("large-copy"))
+
+		// the pieces are shared, so deleting the source must keep the copy
+		// readable, the copy being left as the last one referencing them
+		err = db.DeleteObject(ctx, bucket.Name, "large-file")
+		assert.NoError(t, err)
+		assert.Empty(t, sharedPaths("large-copy"))
+
+		assertStream(ctx, t, db, bucket, "small-file", 4, []byte("test"))
+		assertStream(ctx, t, db, bucket, "small-copy", 4, []byte("test"))
+		assertStream(ctx, t, db, bucket, "large-copy", int64(32*memory.KB), data)
+	})
+}
+
+func TestMoveObject(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		data := make([]byte, 32*memory.KB)
+		_, err := rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "large-file", data)
+		upload(ctx, t, db, bucket, "existing-file", []byte("test"))
+
+		_, err = db.MoveObject(ctx, bucket.Name, "non-existing-file", bucket.Name, "moved")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		// the destination is replaced when it already exists
+		info, err := db.MoveObject(ctx, bucket.Name, "large-file", bucket.Name, "existing-file")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "existing-file", info.Path)
+			assert.EqualValues(t, 32*memory.KB, info.Size)
+		}
+
+		_, err = db.GetObject(ctx, bucket.Name, "large-file")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		assertStream(ctx, t, db, bucket, "existing-file", int64(32*memory.KB), data)
+
+		// the copies of a moved object reference it at its new path
+		_, err = db.CopyObject(ctx, bucket.Name, "existing-file", bucket.Name, "copy")
+		assert.NoError(t, err)
+		_, err = db.MoveObject(ctx, bucket.Name, "existing-file", bucket.Name, "moved")
+		assert.NoError(t, err)
+
+		copyObj, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, "copy")
+		assert.NoError(t, err)
+		movedObj, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, "moved")
+		assert.NoError(t, err)
+		pointer, _, _, err := db.pointers.Get(ctx, committedPrefix+copyObj.encryptedPath)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{committedPrefix + movedObj.encryptedPath}, pointer.GetRemote().GetSharedPaths())
+		}
+		pointer, _, _, err = db.pointers.Get(ctx, committedPrefix+movedObj.encryptedPath)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{committedPrefix + copyObj.encryptedPath}, pointer.GetRemote().GetSharedPaths())
+		}
+	})
+}
+
+func TestConcatObjects(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		// small segments, so the objects have several of them
+		small, err := streams.NewStreamStore(db.segments, int64(8*memory.KB), db.key, int(1*memory.KB), storj.AESGCM, 1, 0, storj.CompressionScheme{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		db = New(buckets.NewStore(small), small, db.segments, db.pointers, db.key)
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var content []byte
+		var paths []storj.Path
+		for i, size := range []memory.Size{20 * memory.KB, 3 * memory.KB, 17 * memory.KB} {
+			data := make([]byte, size)
+			_, err := rand.Read(data)
+			if !assert.NoError(t, err) {
+				return
+			}
+			path := fmt.Sprintf("part%d", i)
+			upload(ctx, t, db, bucket, path, data)
+			content = append(content, data...)
+			paths = append(paths, path)
+		}
+
+		// the existing object has more segments than the new one
+		existing := make([]byte, 60*memory.KB)
+		_, err = rand.Read(existing)
+		if !assert.NoError(t, err) {
+			return
+		}
+		upload(ctx, t, db, bucket, TestFile, existing)
+
+		old, _, err := db.getInfo(ctx, committedPrefix, bucket.Name, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 8, old.streamInfo.NumberOfSegments)
+
+		_, err = db.ConcatObjects(ctx, bucket.Name, []storj.Path{"part0", TestFile}, bucket.Name, TestFile, &storj.CreateObject{})
+		assert.Error(t, err)
+
+		_, err = db.ConcatObjects(ctx, bucket.Name, []storj.Path{"part0", "non-existing-file"}, bucket.Name, TestFile, &storj.CreateObject{})
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		info, err := db.ConcatObjects(ctx, bucket.Name, paths, bucket.Name, TestFile, &storj.CreateObject{
+			ContentType: "text/plain",
+			Metadata:    map[string]string{"key": "value"},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, TestFile, info.Path)
+		assert.Equal(t, "text/plain", info.ContentType)
+		assert.Equal(t, map[string]string{"key": "value"}, info.Metadata)
+		assert.EqualValues(t, len(content), info.Size)
+		assert.EqualValues(t, 7, info.SegmentCount)
+
+		for _, path := range paths {
+			_, err = db.GetObject(ctx, bucket.Name, path)
+			assert.True(t, storj.ErrObjectNotFound.Has(err))
+		}
+
+		// the segment of the replaced object beyond the new ones is deleted
+		_, _, _, err = db.pointers.Get(ctx, getSegmentPath(old.encryptedPath, 6))
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+		readOnly, err := db.GetObjectStream(ctx, bucket.Name, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		segments, more, err := readOnly.Segments(ctx, 0, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.False(t, more)
+		var sizes []int64
+		for _, segment := range segments {
+			sizes = append(sizes, segment.Size)
+		}
+		assert.Equal(t, []int64{8192, 8192, 4096, 3072, 8192, 8192, 1024}, sizes)
+
+		download := stream.NewDownload(ctx, readOnly, db.streams)
+		defer func() {
+			err = download.Close()
+			assert.NoError(t, err)
+		}()
+
+		data := make([]byte, len(content))
+		_, err = io.ReadFull(download, data)
+		if assert.NoError(t, err) {
+			assert.Equal(t, content, data)
+		}
+
+		// ranges starting in each of the moved segments
+		for _, offset := range []int64{100, 9000, 17000, 21000, 25000, 33000, 40000} {
+			_, err = download.Seek(offset, io.SeekStart)
+			if !assert.NoError(t, err) {
+				return
+			}
+			data := make([]byte, int64(len(content))-offset)
+			_, err = io.ReadFull(download, data)
+			if assert.NoError(t, err) {
+				assert.Equal(t, content[offset:], data)
+			}
+		}
+	})
+}
+
+func TestModifyObject(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, TestFile, []byte("test"))
+
+		_, err = db.ModifyObject(ctx, bucket.Name, "")
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.ModifyObject(ctx, bucket.Name, "non-existing-file")
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		object, err := db.ModifyObject(ctx, bucket.Name, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = object.CreateStream(ctx)
+		assert.Error(t, err)
+
+		metadata := map[string]string{"key": "value"}
+		object.SetMetadata("text/plain", metadata)
+
+		err = object.Commit(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "text/plain", object.Info().ContentType)
+			assert.Equal(t, metadata, object.Info().Metadata)
+		}
+
+		info, err := db.GetObject(ctx, bucket.Name, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "text/plain", info.ContentType)
+			assert.Equal(t, metadata, info.Metadata)
+			assert.EqualValues(t, 4, info.Size)
+		}
+
+		// the metadata can be replaced more than once
+		object, err = db.ModifyObject(ctx, bucket.Name, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		object.SetMetadata("", nil)
+
+		err = object.Commit(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "", object.Info().ContentType)
+			assert.Empty(t, object.Info().Metadata)
+		}
+
+		assertStream(ctx, t, db, bucket, TestFile, 4, []byte("test"))
+	})
+}
+
+func TestContinueStream(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "committed-file", nil)
+
+		obj, err := db.CreateObject(ctx, bucket.Name, "committed-file", nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = obj.ContinueStream(ctx)
+		assert.Error(t, err)
+
+		// the committed object is not deleted with the pending stream
+		err = obj.DeleteStream(ctx)
+		assert.NoError(t, err)
+
+		_, err = db.GetObject(ctx, bucket.Name, "committed-file")
+		assert.NoError(t, err)
+
+		obj, err = db.CreateObject(ctx, bucket.Name, TestFile, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		str, err := obj.ContinueStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 0, str.Info().SegmentCount)
+		assert.EqualValues(t, 0, str.Info().Size)
+
+		// store the first segment as an interrupted upload would do
+		streamKey, err := db.key.DeriveContentKey(bucket.Name + "/" + TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var contentKey storj.Key
+		segment := storj.Segment{Index: 0, Inline: []byte("test")}
+		segment.EncryptedKey, err = encryption.EncryptKey(&contentKey, obj.Info().EncryptionScheme.Cipher, streamKey, &segment.EncryptedKeyNonce)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		err = str.UpdateSegments(ctx, segment)
+		assert.Error(t, err)
+
+		err = str.AddSegments(ctx, segment)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		err = str.AddSegments(ctx, segment)
+		assert.Error(t, err)
+
+		err = str.UpdateSegments(ctx, segment)
+		assert.NoError(t, err)
+
+		str, err = obj.ContinueStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 1, str.Info().SegmentCount)
+		assert.EqualValues(t, 4, str.Info().Size)
+
+		err = obj.DeleteStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		str, err = obj.ContinueStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 0, str.Info().SegmentCount)
+	})
+}
+
+func TestPendingObjects(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = db.ListPendingObjects(ctx, "", storj.ListOptions{})
+		assert.True(t, storj.ErrNoBucket.Has(err))
+
+		_, err = db.ModifyPendingObject(ctx, bucket.Name, "")
+		assert.True(t, storj.ErrNoPath.Has(err))
+
+		_, err = db.ModifyPendingObject(ctx, bucket.Name, TestFile)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		rs := storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+			ShareSize:      2 * memory.KB.Int32(),
+		}
+		es := storj.EncryptionScheme{
+			Cipher:    storj.SecretBox,
+			BlockSize: 2 * memory.KB.Int32(),
+		}
+
+		obj, err := db.CreateObject(ctx, bucket.Name, TestFile, &storj.CreateObject{
+			ContentType:      "text/plain",
+			Metadata:         map[string]string{"key": "value"},
+			RedundancyScheme: rs,
+			EncryptionScheme: es,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = obj.CreateStream(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// the object is pending, but not listed as an object
+		list, err := db.ListPendingObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Items)) {
+			assert.Equal(t, TestFile, list.Items[0].Path)
+			assert.Equal(t, "text/plain", list.Items[0].ContentType)
+			assert.Equal(t, map[string]string{"key": "value"}, list.Items[0].Metadata)
+		}
+
+		list, err = db.ListObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, len(list.Items))
+		}
+
+		pending, err := db.ModifyPendingObject(ctx, bucket.Name, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, TestFile, pending.Info().Path)
+			assert.Equal(t, "text/plain", pending.Info().ContentType)
+			assert.Equal(t, map[string]string{"key": "value"}, pending.Info().Metadata)
+			assert.Equal(t, rs, pending.Info().RedundancyScheme)
+			assert.Equal(t, es, pending.Info().EncryptionScheme)
+		}
+
+		// aborting the upload removes the pending object
+		err = pending.DeleteStream(ctx)
+		assert.NoError(t, err)
+
+		list, err = db.ListPendingObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, len(list.Items))
+		}
+
+		// committing the upload removes the pending object
+		upload(ctx, t, db, bucket, TestFile, []byte("test"))
+
+		list, err = db.ListPendingObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, len(list.Items))
+		}
+	})
+}
+
 func TestListObjectsEmpty(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
@@ -8,10 +8,13 @@ import (
 	"errors"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 
 	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var _ storj.ReadOnlyStream = (*readonlyStream)(nil)
@@ -19,22 +22,64 @@ var _ storj.ReadOnlyStream = (*readonlyStream)(nil)
 type readonlyStream struct {
 	db *DB
 
-	info          storj.Object
-	encryptedPath storj.Path
-	streamKey     *storj.Key // lazySegmentReader derivedKey
+	info             storj.Object
+	encryptedPath    storj.Path
+	streamKey        *storj.Key // lazySegmentReader derivedKey
+	segmentSizes     []int64    // nil when all but the last have the fixed size
+	segmentChecksums [][]byte   // nil when unknown
 }
 
 func (stream *readonlyStream) Info() storj.Object { return stream.info }
 
+// SegmentsAt returns the segment that contains byteOffset and the segments
+// following it. All segments but the last one have the fixed segment size
+// unless their sizes are listed, and the last segment holds everything
+// after them.
 func (stream *readonlyStream) SegmentsAt(ctx context.Context, byteOffset int64, limit int64) (infos []storj.Segment, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if byteOffset < 0 {
+		return nil, false, errors.New("invalid argument")
+	}
+	if byteOffset > 0 && byteOffset >= stream.info.Size {
+		return nil, false, nil
+	}
+
+	index, err := stream.segmentIndex(byteOffset)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return stream.Segments(ctx, index, limit)
+}
+
+// segmentIndex returns the index of the segment containing byteOffset
+func (stream *readonlyStream) segmentIndex(byteOffset int64) (int64, error) {
+	lastIndex := stream.info.SegmentCount - 1
+	if lastIndex <= 0 {
+		return 0, nil
+	}
+
+	if len(stream.segmentSizes) > 0 {
+		index := int64(0)
+		for ; index < lastIndex && index < int64(len(stream.segmentSizes)); index++ {
+			if byteOffset < stream.segmentSizes[index] {
+				break
+			}
+			byteOffset -= stream.segmentSizes[index]
+		}
+		return index, nil
+	}
+
 	if stream.info.FixedSegmentSize <= 0 {
-		return nil, false, errors.New("not implemented")
+		return 0, errClass.New("unknown segment size of a stream with %d segments", stream.info.SegmentCount)
 	}
 
 	index := byteOffset / stream.info.FixedSegmentSize
-	return stream.Segments(ctx, index, limit)
+	if index > lastIndex {
+		index = lastIndex
+	}
+	return index, nil
 }
 
 func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment storj.Segment, err error) {
@@ -43,8 +88,12 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 	segment = storj.Segment{
 		Index: index,
 	}
+	if index < int64(len(stream.segmentChecksums)) {
+		segment.Checksum = stream.segmentChecksums[index]
+	}
 
 	var segmentPath storj.Path
+	var segmentMeta *pb.SegmentMeta
 	isLastSegment := segment.Index+1 == stream.info.SegmentCount
 	if !isLastSegment {
 		segmentPath = getSegmentPath(stream.encryptedPath, index)
@@ -53,13 +102,16 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 			return segment, err
 		}
 
-		segmentMeta := pb.SegmentMeta{}
-		err = proto.Unmarshal(meta.Data, &segmentMeta)
+		segmentMeta = &pb.SegmentMeta{}
+		err = proto.Unmarshal(meta.Data, segmentMeta)
 		if err != nil {
 			return segment, err
 		}
 
-		segment.Size = stream.info.FixedSegmentSize
+		segment.Size = segmentMeta.ContentSize
+		if segment.Size == 0 {
+			segment.Size = stream.info.FixedSegmentSize
+		}
 		copy(segment.EncryptedKeyNonce[:], segmentMeta.KeyNonce)
 		segment.EncryptedKey = segmentMeta.EncryptedKey
 	} else {
@@ -74,13 +126,21 @@ func (stream *readonlyStream) segment(ctx context.Context, index int64) (segment
 		return segment, err
 	}
 
-	nonce := new(storj.Nonce)
-	_, err = encryption.Increment(nonce, index+1)
+	pointer, _, _, err := stream.db.pointers.Get(ctx, segmentPath)
 	if err != nil {
 		return segment, err
 	}
 
-	pointer, _, _, err := stream.db.pointers.Get(ctx, segmentPath)
+	if isLastSegment {
+		streamMeta := pb.StreamMeta{}
+		err = proto.Unmarshal(pointer.Metadata, &streamMeta)
+		if err != nil {
+			return segment, err
+		}
+		segmentMeta = streamMeta.LastSegmentMeta
+	}
+
+	nonce, err := streams.ContentNonce(segmentMeta, index)
 	if err != nil {
 		return segment, err
 	}
@@ -134,10 +194,146 @@ type mutableStream struct {
 
 func (stream *mutableStream) Info() storj.Object { return stream.info }
 
-func (stream *mutableStream) AddSegments(ctx context.Context, segments ...storj.Segment) error {
-	return errors.New("not implemented")
+// AddSegments stores the pointers of new segments of the stream. The last
+// segment is stored by the stream store when the stream is committed, so
+// only the segments before it can be added.
+func (stream *mutableStream) AddSegments(ctx context.Context, segments ...storj.Segment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return stream.putSegments(ctx, false, segments)
+}
+
+// UpdateSegments replaces the pointers of existing segments of the stream.
+func (stream *mutableStream) UpdateSegments(ctx context.Context, segments ...storj.Segment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return stream.putSegments(ctx, true, segments)
+}
+
+func (stream *mutableStream) putSegments(ctx context.Context, update bool, segments []storj.Segment) (err error) {
+	fullpath := stream.info.Bucket.Name + "/" + stream.info.Path
+
+	encryptedPath, err := stream.db.key.EncryptPath(fullpath, stream.info.Bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	streamKey, err := stream.db.key.DeriveContentKey(fullpath)
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		if segment.Index < 0 {
+			return errClass.New("invalid segment index %d", segment.Index)
+		}
+
+		segmentPath := getSegmentPath(encryptedPath, segment.Index)
+
+		_, err = stream.db.segments.Meta(ctx, segmentPath)
+		switch {
+		case err == nil && !update:
+			return errClass.New("segment %d already exists", segment.Index)
+		case storage.ErrKeyNotFound.Has(err) && update:
+			return errClass.New("segment %d does not exist", segment.Index)
+		case err != nil && !storage.ErrKeyNotFound.Has(err):
+			return err
+		}
+
+		pointer, err := stream.segmentPointer(streamKey, segment)
+		if err != nil {
+			return err
+		}
+
+		err = stream.db.pointers.Put(ctx, segmentPath, pointer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (stream *mutableStream) UpdateSegments(ctx context.Context, segments ...storj.Segment) error {
-	return errors.New("not implemented")
+// segmentPointer creates the pointer describing segment, the same way
+// as the segment store does when uploading the segment's data.
+func (stream *mutableStream) segmentPointer(streamKey *storj.Key, segment storj.Segment) (*pb.Pointer, error) {
+	scheme := stream.info.EncryptionScheme
+
+	expiration, err := ptypes.TimestampProto(stream.info.Expires)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := pb.SegmentMeta{ContentSize: segment.Size}
+	if len(segment.Pieces) == 0 {
+		meta.ContentSize = int64(len(segment.Inline))
+	}
+	if scheme.Cipher != storj.Unencrypted {
+		meta.EncryptedKey = segment.EncryptedKey
+		meta.KeyNonce = segment.EncryptedKeyNonce[:]
+	}
+
+	metadata, err := proto.Marshal(&meta)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := encryption.DecryptKey(segment.EncryptedKey, scheme.Cipher, streamKey, &segment.EncryptedKeyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := new(storj.Nonce)
+	_, err = encryption.Increment(nonce, segment.Index+1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segment.Pieces) == 0 {
+		inline, err := encryption.Encrypt(segment.Inline, scheme.Cipher, contentKey, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Pointer{
+			Type:           pb.Pointer_INLINE,
+			InlineSegment:  inline,
+			SegmentSize:    int64(len(inline)),
+			ExpirationDate: expiration,
+			Metadata:       metadata,
+		}, nil
+	}
+
+	encrypter, err := encryption.NewEncrypter(scheme.Cipher, contentKey, nonce, int(scheme.BlockSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// the data is padded to a multiple of the input block size,
+	// with at least 4 bytes of padding holding the padding length
+	inBlockSize := int64(encrypter.InBlockSize())
+	blocks := (segment.Size + 4 + inBlockSize - 1) / inBlockSize
+
+	pieces := make([]*pb.RemotePiece, 0, len(segment.Pieces))
+	for _, piece := range segment.Pieces {
+		pieces = append(pieces, &pb.RemotePiece{
+			PieceNum: int32(piece.Number),
+			NodeId:   piece.Location,
+		})
+	}
+
+	redundancy, err := convertRedundancyScheme(stream.info.RedundancyScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			Redundancy:   redundancy,
+			PieceId:      string(segment.PieceID),
+			RemotePieces: pieces,
+		},
+		SegmentSize:    blocks * int64(encrypter.OutBlockSize()),
+		ExpirationDate: expiration,
+		Metadata:       metadata,
+	}, nil
 }
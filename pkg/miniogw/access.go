@@ -0,0 +1,70 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"encoding/json"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+// Access is the access to the paths under a prefix, shared with another
+// user who can only list and decrypt the paths under it
+type Access struct {
+	OverlayAddr     string       `json:"overlay_addr"`
+	PointerDBAddr   string       `json:"pointerdb_addr"`
+	APIKey          string       `json:"api_key"`
+	Prefix          storj.Path   `json:"prefix"`
+	EncryptedPrefix storj.Path   `json:"encrypted_prefix"`
+	PathCipher      storj.Cipher `json:"path_cipher"`
+	Key             []byte       `json:"key"`
+}
+
+// ParseAccess parses the access serialized with Serialize
+func ParseAccess(serialized string) (*Access, error) {
+	data := base58.Decode(serialized)
+	if len(data) == 0 {
+		return nil, Error.New("invalid access")
+	}
+
+	access := new(Access)
+	if err := json.Unmarshal(data, access); err != nil {
+		return nil, Error.New("invalid access: %v", err)
+	}
+	if len(access.Key) != storj.KeySize {
+		return nil, Error.New("invalid access: key of %d bytes", len(access.Key))
+	}
+	if access.Prefix == "" {
+		return nil, Error.New("invalid access: empty prefix")
+	}
+	return access, nil
+}
+
+// Serialize returns the access as a string to hand to another user
+func (a *Access) Serialize() (string, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return base58.Encode(data), nil
+}
+
+// Bucket returns the bucket of the prefix
+func (a *Access) Bucket() string {
+	return storj.SplitPath(a.Prefix)[0]
+}
+
+// PrefixKey returns the key of the paths under the prefix
+func (a *Access) PrefixKey() *streams.PrefixKey {
+	key := new(storj.Key)
+	copy(key[:], a.Key)
+	return &streams.PrefixKey{
+		Prefix:          a.Prefix,
+		EncryptedPrefix: a.EncryptedPrefix,
+		Key:             key,
+	}
+}
@@ -5,19 +5,22 @@ package miniogw
 
 import (
 	"context"
+	"crypto/hmac"
+	"encoding/hex"
 	"errors"
 	"os"
 
 	"github.com/minio/cli"
 	minio "github.com/minio/minio/cmd"
-	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/metainfo/kvmetainfo"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/buckets"
@@ -30,21 +33,115 @@ import (
 // RSConfig is a configuration struct that keeps details about default
 // redundancy strategy information
 type RSConfig struct {
-	MaxBufferMem     int `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"0x400000"`
-	ErasureShareSize int `help:"the size of each new erasure sure in bytes" default:"1024"`
-	MinThreshold     int `help:"the minimum pieces required to recover a segment. k." default:"29"`
-	RepairThreshold  int `help:"the minimum safe pieces before a repair is triggered. m." default:"35"`
-	SuccessThreshold int `help:"the desired total pieces for a segment. o." default:"80"`
-	MaxThreshold     int `help:"the largest amount of pieces to encode to. n." default:"95"`
+	MaxBufferMem     int    `help:"maximum buffer memory (in bytes) to be allocated for read buffers, shared by the segments downloaded concurrently and by the segments read ahead by concurrent uploads" default:"0x400000"`
+	ErasureShareSize int    `help:"the size of each new erasure sure in bytes" default:"1024"`
+	MinThreshold     int    `help:"the minimum pieces required to recover a segment. k." default:"29"`
+	RepairThreshold  int    `help:"the minimum safe pieces before a repair is triggered. m." default:"35"`
+	SuccessThreshold int    `help:"the desired total pieces for a segment. o." default:"80"`
+	MaxThreshold     int    `help:"the largest amount of pieces to encode to. n." default:"95"`
+	Scheme           string `help:"the erasure scheme of new segments: RS, or REPLICATION storing full copies of small segments, which requires a min threshold of 1" default:"RS"`
+}
+
+// schemeType returns the redundancy scheme type of the configured scheme
+func (c RSConfig) schemeType() (pb.RedundancyScheme_SchemeType, error) {
+	typ, ok := pb.RedundancyScheme_SchemeType_value[c.Scheme]
+	if !ok {
+		return 0, Error.New("unknown erasure scheme %q", c.Scheme)
+	}
+	return pb.RedundancyScheme_SchemeType(typ), nil
 }
 
 // EncryptionConfig is a configuration struct that keeps details about
 // encrypting segments
 type EncryptionConfig struct {
-	Key       string `help:"root key for encrypting the data"`
-	BlockSize int    `help:"size (in bytes) of encrypted blocks" default:"1024"`
-	DataType  int    `help:"Type of encryption to use for content and metadata (1=AES-GCM, 2=SecretBox)" default:"1"`
-	PathType  int    `help:"Type of encryption to use for paths (0=Unencrypted, 1=AES-GCM, 2=SecretBox)" default:"1"`
+	Key        string `help:"root key for encrypting the data"`
+	BlockSize  int    `help:"size (in bytes) of encrypted blocks" default:"1024"`
+	DataType   int    `help:"Type of encryption to use for content and metadata (1=AES-GCM, 2=SecretBox, 3=XChaCha20-Poly1305)" default:"1"`
+	PathType   int    `help:"Type of encryption to use for paths (0=Unencrypted, 1=AES-GCM, 2=SecretBox, 3=XChaCha20-Poly1305)" default:"1"`
+	KeyFile    string `help:"path to the file holding the root key encrypted with the passphrase, used instead of key"`
+	KeySalt    string `help:"hex-encoded salt of the root key derived from the passphrase, used instead of key"`
+	KeyCheck   string `help:"hex-encoded check value of the root key derived from the passphrase, rejecting a wrong passphrase"`
+	Passphrase string `help:"passphrase unlocking the key file or deriving the root key, better set with the STORJ_ENC_PASSPHRASE environment variable than in the config file"`
+	Cipher     string `help:"cipher to use for content, metadata and paths, overriding data-type and path-type (aesgcm, secretbox or xchacha20poly1305, which is faster than aesgcm without AES hardware support)" default:""`
+}
+
+// NeedsPassphrase returns whether the root key is unlocked or derived with
+// the passphrase
+func (c EncryptionConfig) NeedsPassphrase() bool {
+	return c.KeyFile != "" || c.KeySalt != ""
+}
+
+// RootKey returns the root key for encrypting the data, unlocked from the
+// key file or derived from the passphrase when configured so
+func (c EncryptionConfig) RootKey() (*storj.Key, error) {
+	if c.NeedsPassphrase() && c.Passphrase == "" {
+		return nil, Error.New("the passphrase of the root key is not set")
+	}
+
+	switch {
+	case c.KeyFile != "":
+		file, err := encryption.LoadKeyFile(c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return file.Unlock([]byte(c.Passphrase))
+	case c.KeySalt != "":
+		salt, err := hex.DecodeString(c.KeySalt)
+		if err != nil {
+			return nil, Error.New("invalid key salt: %v", err)
+		}
+		key, err := encryption.DeriveKeyFromPassphrase([]byte(c.Passphrase), salt)
+		if err != nil {
+			return nil, err
+		}
+		// a wrong passphrase derives another key, with which new data
+		// would be encrypted unnoticed. Configs set up before the check
+		// value was stored have none.
+		if c.KeyCheck != "" {
+			check, err := hex.DecodeString(c.KeyCheck)
+			if err != nil {
+				return nil, Error.New("invalid key check: %v", err)
+			}
+			if !hmac.Equal(check, encryption.KeyCheckValue(key)) {
+				return nil, Error.New("wrong passphrase of the root key")
+			}
+		}
+		return key, nil
+	default:
+		key := new(storj.Key)
+		copy(key[:], c.Key)
+		return key, nil
+	}
+}
+
+// ciphers are the ciphers of the cipher option by name
+var ciphers = map[string]storj.Cipher{
+	"aesgcm":            storj.AESGCM,
+	"secretbox":         storj.SecretBox,
+	"xchacha20poly1305": storj.XChaCha20Poly1305,
+}
+
+// DataCipher returns the cipher to use for content and metadata
+func (c EncryptionConfig) DataCipher() storj.Cipher {
+	if cipher, ok := ciphers[c.Cipher]; ok {
+		return cipher
+	}
+	return storj.Cipher(c.DataType)
+}
+
+// PathCipher returns the cipher to use for paths
+func (c EncryptionConfig) PathCipher() storj.Cipher {
+	if cipher, ok := ciphers[c.Cipher]; ok {
+		return cipher
+	}
+	return storj.Cipher(c.PathType)
+}
+
+// CompressionConfig is a configuration struct that keeps details about
+// compressing segments before encrypting them
+type CompressionConfig struct {
+	Type      int `help:"Type of compression to use for content before its encryption (0=None, 1=Gzip)" default:"0"`
+	BlockSize int `help:"size (in bytes) of the blocks of content compressed independently, read whole by range reads" default:"65536"`
 }
 
 // MinioConfig is a configuration struct that keeps details about starting
@@ -65,6 +162,8 @@ type ClientConfig struct {
 	APIKey        string `help:"API Key (TODO: this needs to change to macaroons somehow)"`
 	MaxInlineSize int    `help:"max inline segment size in bytes" default:"4096"`
 	SegmentSize   int64  `help:"the size of a segment in bytes" default:"64000000"`
+
+	SegmentConcurrency int `help:"the number of segments of an object uploaded or downloaded concurrently. Each segment uploaded concurrently is buffered in memory." default:"1"`
 }
 
 // ServerConfig determines how minio listens for requests
@@ -75,12 +174,15 @@ type ServerConfig struct {
 // Config is a general miniogw configuration struct. This should be everything
 // one needs to start a minio gateway.
 type Config struct {
-	Identity identity.Config
-	Server   ServerConfig
-	Minio    MinioConfig
-	Client   ClientConfig
-	RS       RSConfig
-	Enc      EncryptionConfig
+	Identity    identity.Config
+	Server      ServerConfig
+	Minio       MinioConfig
+	Client      ClientConfig
+	RS          RSConfig
+	Enc         EncryptionConfig
+	Compression CompressionConfig
+
+	Access string `help:"access shared with uplink share, used instead of the satellite addresses, API key and root key to list and decrypt only the paths under its prefix" default:""`
 }
 
 // Run starts a Minio Gateway given proper config
@@ -135,6 +237,11 @@ func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *provi
 func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity) (db storj.Metainfo, ss streams.Store, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	c, access, err := c.withAccess()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if c.Client.OverlayAddr == "" || c.Client.PointerDBAddr == "" {
 		var errlist errs.Group
 		if c.Client.OverlayAddr == "" {
@@ -156,12 +263,31 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 		return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
 	}
 
-	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem)
-	fc, err := infectious.NewFEC(c.RS.MinThreshold, c.RS.MaxThreshold)
+	if _, ok := ciphers[c.Enc.Cipher]; c.Enc.Cipher != "" && !ok {
+		return nil, nil, Error.New("unknown cipher %q", c.Enc.Cipher)
+	}
+
+	if c.Client.SegmentConcurrency <= 0 {
+		return nil, nil, Error.New("segment concurrency must be larger than 0")
+	}
+
+	// the segments downloaded concurrently share the buffer memory, and
+	// the nodes serving corrupted pieces are reported to the overlay
+	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem/c.Client.SegmentConcurrency, oc)
+	schemeType, err := c.RS.schemeType()
+	if err != nil {
+		return nil, nil, err
+	}
+	es, err := eestream.NewScheme(&pb.RedundancyScheme{
+		Type:             schemeType,
+		MinReq:           int32(c.RS.MinThreshold),
+		Total:            int32(c.RS.MaxThreshold),
+		ErasureShareSize: int32(c.RS.ErasureShareSize),
+	})
 	if err != nil {
 		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
 	}
-	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, c.RS.ErasureShareSize), c.RS.RepairThreshold, c.RS.SuccessThreshold)
+	rs, err := eestream.NewRedundancyStrategy(es, c.RS.RepairThreshold, c.RS.SuccessThreshold)
 	if err != nil {
 		return nil, nil, Error.New("failed to create redundancy strategy: %v", err)
 	}
@@ -173,23 +299,98 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 		return nil, nil, err
 	}
 
-	key := new(storj.Key)
-	copy(key[:], c.Enc.Key)
+	key, err := c.prefixKey(access)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize, key, c.Enc.BlockSize, storj.Cipher(c.Enc.DataType))
+	compression := storj.CompressionScheme{
+		Algorithm: storj.CompressionAlgorithm(c.Compression.Type),
+		BlockSize: int32(c.Compression.BlockSize),
+	}
+	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize, key, c.Enc.BlockSize, c.Enc.DataCipher(), c.Client.SegmentConcurrency, c.RS.MaxBufferMem, compression)
 	if err != nil {
 		return nil, nil, Error.New("failed to create stream store: %v", err)
 	}
 
-	buckets := buckets.NewStore(streams)
+	bucketStore := buckets.NewStore(streams)
+	if access != nil {
+		bucketStore = buckets.NewSharedStore(streams, access.Bucket(), access.PathCipher)
+	}
+
+	return kvmetainfo.New(bucketStore, streams, segments, pdb, key), streams, nil
+}
+
+// withAccess returns the config with the satellite addresses and API key of
+// the imported access, and the access, which is nil without one
+func (c Config) withAccess() (Config, *Access, error) {
+	if c.Access == "" {
+		return c, nil, nil
+	}
 
-	return kvmetainfo.New(buckets, streams, segments, pdb, key), streams, nil
+	access, err := ParseAccess(c.Access)
+	if err != nil {
+		return c, nil, err
+	}
+	c.Client.OverlayAddr = access.OverlayAddr
+	c.Client.PointerDBAddr = access.PointerDBAddr
+	c.Client.APIKey = access.APIKey
+	return c, access, nil
+}
+
+// prefixKey returns the key of the paths accessible with the config, which
+// is the root key without an imported access
+func (c Config) prefixKey(access *Access) (*streams.PrefixKey, error) {
+	if access != nil {
+		return access.PrefixKey(), nil
+	}
+
+	key, err := c.Enc.RootKey()
+	if err != nil {
+		return nil, err
+	}
+	return streams.RootKey(key), nil
+}
+
+// ShareAccess returns the access to the paths under prefix, which starts
+// with the bucket whose paths are encrypted with pathCipher
+func (c Config) ShareAccess(prefix storj.Path, pathCipher storj.Cipher) (*Access, error) {
+	c, access, err := c.withAccess()
+	if err != nil {
+		return nil, err
+	}
+	if access != nil {
+		pathCipher = access.PathCipher
+	}
+
+	key, err := c.prefixKey(access)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := key.Share(prefix, pathCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Access{
+		OverlayAddr:     c.Client.OverlayAddr,
+		PointerDBAddr:   c.Client.PointerDBAddr,
+		APIKey:          c.Client.APIKey,
+		Prefix:          shared.Prefix,
+		EncryptedPrefix: shared.EncryptedPrefix,
+		PathCipher:      pathCipher,
+		Key:             shared.Key[:],
+	}, nil
 }
 
 // GetRedundancyScheme returns the configured redundancy scheme for new uploads
 func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
+	algorithm := storj.InvalidRedundancyAlgorithm
+	if schemeType, err := c.RS.schemeType(); err == nil {
+		algorithm = eestream.Algorithm(schemeType)
+	}
 	return storj.RedundancyScheme{
-		Algorithm:      storj.ReedSolomon,
+		Algorithm:      algorithm,
 		RequiredShares: int16(c.RS.MinThreshold),
 		RepairShares:   int16(c.RS.RepairThreshold),
 		OptimalShares:  int16(c.RS.SuccessThreshold),
@@ -200,7 +401,7 @@ func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 // GetEncryptionScheme returns the configured encryption scheme for new uploads
 func (c Config) GetEncryptionScheme() storj.EncryptionScheme {
 	return storj.EncryptionScheme{
-		Cipher:    storj.Cipher(c.Enc.DataType),
+		Cipher:    c.Enc.DataCipher(),
 		BlockSize: int32(c.Enc.BlockSize),
 	}
 }
@@ -214,5 +415,5 @@ func (c Config) NewGateway(ctx context.Context, identity *provider.FullIdentity)
 		return nil, err
 	}
 
-	return NewStorjGateway(metainfo, streams, storj.Cipher(c.Enc.PathType), c.GetEncryptionScheme(), c.GetRedundancyScheme()), nil
+	return NewStorjGateway(metainfo, streams, c.Enc.PathCipher(), c.GetEncryptionScheme(), c.GetRedundancyScheme()), nil
 }
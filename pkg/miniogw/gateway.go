@@ -7,12 +7,14 @@ import (
 	"context"
 	"encoding/hex"
 	"io"
+	"strconv"
 	"strings"
 
 	minio "github.com/minio/minio/cmd"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/storage/streams"
@@ -36,7 +38,6 @@ func NewStorjGateway(metainfo storj.Metainfo, streams streams.Store, pathCipher
 		pathCipher: pathCipher,
 		encryption: encryption,
 		redundancy: redundancy,
-		multipart:  NewMultipartUploads(),
 	}
 }
 
@@ -47,7 +48,6 @@ type Gateway struct {
 	pathCipher storj.Cipher
 	encryption storj.EncryptionScheme
 	redundancy storj.RedundancyScheme
-	multipart  *MultipartUploads
 }
 
 // Name implements cmd.Gateway
@@ -153,7 +153,7 @@ func (layer *gatewayLayer) GetObjectInfo(ctx context.Context, bucket, object str
 		Bucket:      bucket,
 		ModTime:     obj.Modified,
 		Size:        obj.Size,
-		ETag:        hex.EncodeToString(obj.Checksum),
+		ETag:        objectETag(obj.Stream),
 		ContentType: obj.ContentType,
 		UserDefined: obj.Metadata,
 	}, err
@@ -171,6 +171,10 @@ func (layer *gatewayLayer) ListBuckets(ctx context.Context) (bucketItems []minio
 		}
 
 		for _, item := range list.Items {
+			// the bucket keeping the multipart uploads is not an S3 bucket
+			if item.Name == MultipartBucket {
+				continue
+			}
 			bucketItems = append(bucketItems, minio.BucketInfo{Name: item.Name, Created: item.Created})
 		}
 
@@ -224,7 +228,7 @@ func (layer *gatewayLayer) ListObjects(ctx context.Context, bucket, prefix, mark
 				Name:        path,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        objectETag(item.Stream),
 				ContentType: item.ContentType,
 				UserDefined: item.Metadata,
 			})
@@ -293,7 +297,7 @@ func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucket, prefix, co
 				Name:        path,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        objectETag(item.Stream),
 				ContentType: item.ContentType,
 				UserDefined: item.Metadata,
 			})
@@ -341,24 +345,41 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucket st
 func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo minio.ObjectInfo) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	readOnlyStream, err := layer.gateway.metainfo.GetObjectStream(ctx, srcBucket, srcObject)
+	_, err = layer.gateway.metainfo.GetObject(ctx, srcBucket, srcObject)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, srcBucket, srcObject)
 	}
 
-	download := stream.NewDownload(ctx, readOnlyStream, layer.gateway.streams)
-	defer utils.LogClose(download)
+	// copying an object onto itself only replaces its metadata
+	if srcBucket != destBucket || srcObject != destObject {
+		_, err = layer.gateway.metainfo.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject)
+		if err != nil {
+			return minio.ObjectInfo{}, convertError(err, destBucket, destObject)
+		}
+	}
 
-	info := readOnlyStream.Info()
-	createInfo := storj.CreateObject{
-		ContentType:      info.ContentType,
-		Expires:          info.Expires,
-		Metadata:         info.Metadata,
-		RedundancyScheme: info.RedundancyScheme,
-		EncryptionScheme: info.EncryptionScheme,
+	// srcInfo holds the metadata of the source or the replacing one,
+	// depending on the metadata directive of the request
+	metadata := make(map[string]string, len(srcInfo.UserDefined))
+	for key, value := range srcInfo.UserDefined {
+		metadata[key] = value
 	}
 
-	return layer.putObject(ctx, destBucket, destObject, download, &createInfo)
+	contentType, ok := metadata["content-type"]
+	if ok {
+		delete(metadata, "content-type")
+	} else {
+		contentType = srcInfo.ContentType
+	}
+
+	mutableObject, err := layer.gateway.metainfo.ModifyObject(ctx, destBucket, destObject)
+	if err != nil {
+		return minio.ObjectInfo{}, convertError(err, destBucket, destObject)
+	}
+
+	mutableObject.SetMetadata(contentType, metadata)
+
+	return commitObject(ctx, destBucket, destObject, mutableObject)
 }
 
 func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string, reader io.Reader, createInfo *storj.CreateObject) (objInfo minio.ObjectInfo, err error) {
@@ -374,6 +395,11 @@ func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string,
 		return minio.ObjectInfo{}, err
 	}
 
+	return commitObject(ctx, bucket, object, mutableObject)
+}
+
+// commitObject commits the uploaded mutableObject and returns its info
+func commitObject(ctx context.Context, bucket, object string, mutableObject storj.MutableObject) (objInfo minio.ObjectInfo, err error) {
 	err = mutableObject.Commit(ctx)
 	if err != nil {
 		return minio.ObjectInfo{}, err
@@ -386,12 +412,22 @@ func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string,
 		Bucket:      bucket,
 		ModTime:     info.Modified,
 		Size:        info.Size,
-		ETag:        hex.EncodeToString(info.Checksum),
+		ETag:        objectETag(info.Stream),
 		ContentType: info.ContentType,
 		UserDefined: info.Metadata,
 	}, nil
 }
 
+// objectETag returns the ETag of an object stream. Like with S3 multipart
+// uploads, the ETag of a stream concatenated from parts is the checksum of
+// the part checksums followed by the number of parts.
+func objectETag(stream storj.Stream) string {
+	if len(stream.Checksum) == 0 || stream.ChecksumParts == 0 {
+		return hex.EncodeToString(stream.Checksum)
+	}
+	return hex.EncodeToString(stream.Checksum) + "-" + strconv.FormatInt(stream.ChecksumParts, 10)
+}
+
 func upload(ctx context.Context, streams streams.Store, mutableObject storj.MutableObject, reader io.Reader) error {
 	mutableStream, err := mutableObject.CreateStream(ctx)
 	if err != nil {
@@ -401,8 +437,12 @@ func upload(ctx context.Context, streams streams.Store, mutableObject storj.Muta
 	upload := stream.NewUpload(ctx, mutableStream, streams)
 
 	_, err = io.Copy(upload, reader)
-
-	return utils.CombineErrors(err, upload.Close())
+	err = utils.CombineErrors(err, upload.Close())
+	if err != nil {
+		// the upload cannot be continued, so its stored segments are deleted
+		return utils.CombineErrors(err, mutableObject.DeleteStream(context.Background()))
+	}
+	return nil
 }
 
 func (layer *gatewayLayer) PutObject(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (objInfo minio.ObjectInfo, err error) {
@@ -426,8 +466,49 @@ func (layer *gatewayLayer) Shutdown(ctx context.Context) (err error) {
 	return nil
 }
 
-func (layer *gatewayLayer) StorageInfo(context.Context) minio.StorageInfo {
-	return minio.StorageInfo{}
+// StorageInfo reports the size of the data stored in all buckets as the used
+// space. The number of objects and the bandwidth used for downloads, which
+// minio.StorageInfo has no place for, are reported as metrics, and by the
+// uplink usage command.
+func (layer *gatewayLayer) StorageInfo(ctx context.Context) (info minio.StorageInfo) {
+	usage, err := layer.usage(ctx)
+	if err != nil {
+		zap.L().Error("failed to get usage", zap.Error(err))
+		return minio.StorageInfo{}
+	}
+
+	mon.IntVal("stored_bytes").Observe(usage.StoredBytes)
+	mon.IntVal("objects").Observe(usage.Objects)
+	mon.IntVal("segments").Observe(usage.Segments)
+	mon.IntVal("egress_bytes").Observe(usage.EgressBytes)
+
+	// minio shows Total - Free as the used space, and there is no free space limit
+	info.Total = uint64(usage.StoredBytes)
+	info.Backend.Type = minio.Unknown
+	return info
+}
+
+// usage returns the usage of all buckets, except the one keeping the
+// multipart uploads, which is not listed either
+func (layer *gatewayLayer) usage(ctx context.Context) (storj.Usage, error) {
+	usage, err := layer.gateway.metainfo.GetUsage(ctx, "")
+	if err != nil {
+		return storj.Usage{}, err
+	}
+
+	hidden, err := layer.gateway.metainfo.GetUsage(ctx, MultipartBucket)
+	if storj.ErrBucketNotFound.Has(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return storj.Usage{}, err
+	}
+
+	// the bandwidth is not used by bucket, so it is all reported
+	usage.StoredBytes -= hidden.StoredBytes
+	usage.Objects -= hidden.Objects
+	usage.Segments -= hidden.Segments
+	return usage, nil
 }
 
 func convertError(err error, bucket, object string) error {
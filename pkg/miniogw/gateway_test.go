@@ -6,6 +6,7 @@ package miniogw
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/hex"
 	"flag"
 	"fmt"
@@ -150,6 +151,58 @@ func TestListBuckets(t *testing.T) {
 	})
 }
 
+func TestStorageInfo(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Check that no usage is reported if nothing is stored
+		info := layer.StorageInfo(ctx)
+		assert.EqualValues(t, 0, info.Total)
+
+		// Check the error when getting the usage of a non-existing bucket
+		_, err := metainfo.GetUsage(ctx, TestBucket)
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		// Create the bucket and the objects using the Metainfo API
+		_, err = metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		_, err = createFile(ctx, metainfo, streams, TestBucket, "file1", nil, []byte("test"))
+		assert.NoError(t, err)
+		_, err = createFile(ctx, metainfo, streams, TestBucket, "file2", nil, []byte("more test data"))
+		assert.NoError(t, err)
+
+		// Check the usage of the bucket using the Metainfo API
+		usage, err := metainfo.GetUsage(ctx, TestBucket)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 2, usage.Objects)
+			assert.EqualValues(t, 2, usage.Segments)
+			assert.True(t, usage.StoredBytes >= int64(len("test")+len("more test data")))
+		}
+
+		// Check that the usage is reported using the Minio API
+		info = layer.StorageInfo(ctx)
+		assert.EqualValues(t, usage.StoredBytes, info.Total)
+		assert.EqualValues(t, 0, info.Free)
+
+		// Check that the parts of multipart uploads are not reported
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, "file3", map[string]string{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		data, err := hash.NewReader(bytes.NewReader([]byte("part data")), int64(len("part data")), "", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = layer.PutObjectPart(ctx, TestBucket, "file3", uploadID, 1, data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		info = layer.StorageInfo(ctx)
+		assert.EqualValues(t, usage.StoredBytes, info.Total)
+	})
+}
+
 func TestPutObject(t *testing.T) {
 	data, err := hash.NewReader(bytes.NewReader([]byte("test")),
 		int64(len("test")),
@@ -198,7 +251,7 @@ func TestPutObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, time.Since(info.ModTime) < 1*time.Second)
 			assert.Equal(t, data.Size(), info.Size)
-			// assert.Equal(t, data.SHA256HexString(), info.ETag) TODO: when we start calculating checksums
+			assert.Equal(t, data.MD5HexString(), info.ETag)
 			assert.Equal(t, serMetaInfo.ContentType, info.ContentType)
 			assert.Equal(t, serMetaInfo.UserDefined, info.UserDefined)
 		}
@@ -218,6 +271,15 @@ func TestPutObject(t *testing.T) {
 	})
 }
 
+func TestObjectETag(t *testing.T) {
+	checksum := []byte{0x09, 0x8f, 0x6b, 0xcd}
+
+	assert.Equal(t, "", objectETag(storj.Stream{SegmentCount: 1}))
+	assert.Equal(t, "098f6bcd", objectETag(storj.Stream{Checksum: checksum, SegmentCount: 1}))
+	assert.Equal(t, "098f6bcd", objectETag(storj.Stream{Checksum: checksum, SegmentCount: 3}))
+	assert.Equal(t, "098f6bcd-2", objectETag(storj.Stream{Checksum: checksum, ChecksumParts: 2, SegmentCount: 3}))
+}
+
 func TestGetObjectInfo(t *testing.T) {
 	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
 		// Check the error when getting an object from a bucket with empty name
@@ -393,6 +455,30 @@ func TestCopyObject(t *testing.T) {
 			assert.Equal(t, info.ContentType, obj.ContentType)
 			assert.Equal(t, info.UserDefined, obj.Metadata)
 		}
+
+		// Replace the metadata by copying the object onto itself using the Minio API
+		srcInfo.UserDefined = map[string]string{"content-type": "media/foo", "key3": "value3"}
+		info, err = layer.CopyObject(ctx, DestBucket, DestFile, DestBucket, DestFile, srcInfo)
+		if assert.NoError(t, err) {
+			assert.Equal(t, obj.Size, info.Size)
+			assert.Equal(t, "media/foo", info.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, info.UserDefined)
+		}
+
+		// Check that the metadata is replaced using the Metainfo API
+		obj, err = metainfo.GetObject(ctx, DestBucket, DestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, info.Size, obj.Size)
+			assert.Equal(t, "media/foo", obj.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, obj.Metadata)
+		}
+
+		// Check that the source object keeps its metadata using the Metainfo API
+		obj, err = metainfo.GetObject(ctx, TestBucket, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, createInfo.ContentType, obj.ContentType)
+			assert.Equal(t, createInfo.Metadata, obj.Metadata)
+		}
 	})
 }
 
@@ -633,6 +719,284 @@ func testListObjects(t *testing.T, listObjects func(context.Context, minio.Objec
 	})
 }
 
+func TestListMultipartUploads(t *testing.T) {
+	data, err := hash.NewReader(bytes.NewReader([]byte("test")),
+		int64(len("test")),
+		"098f6bcd4621d373cade4e832627b4f6",
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Check the error when listing uploads in a non-existing bucket
+		_, err := layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		assert.Equal(t, minio.BucketNotFound{Bucket: TestBucket}, err)
+
+		// Create the bucket using the Metainfo API
+		_, err = metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		// Start a multipart upload and wait for its first part to be stored
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, map[string]string{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 1, data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Check that the upload is listed, but not as an object
+		list, err := layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Uploads)) {
+			assert.Equal(t, TestFile, list.Uploads[0].Object)
+			assert.Equal(t, uploadID, list.Uploads[0].UploadID)
+		}
+
+		objects, err := layer.ListObjects(ctx, TestBucket, "", "", "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, objects.Objects)
+		}
+
+		// Check that the uploads after the key and upload ID markers are listed
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", TestFile, "", "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Uploads)
+		}
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", TestFile, uploadID, "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Uploads)
+		}
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", TestFile, "0", "", 0)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Uploads)) {
+			assert.Equal(t, TestFile, list.Uploads[0].Object)
+			assert.Equal(t, uploadID, list.Uploads[0].UploadID)
+		}
+
+		// Check the pagination and the common prefixes
+		otherID, err := layer.NewMultipartUpload(ctx, TestBucket, "dir/"+TestFile, map[string]string{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 1)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Uploads)) {
+			assert.Equal(t, "dir/"+TestFile, list.Uploads[0].Object)
+			assert.True(t, list.IsTruncated)
+			assert.Equal(t, "dir/"+TestFile, list.NextKeyMarker)
+			assert.Equal(t, otherID, list.NextUploadIDMarker)
+		}
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", list.NextKeyMarker, list.NextUploadIDMarker, "", 1)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Uploads)) {
+			assert.Equal(t, TestFile, list.Uploads[0].Object)
+			assert.False(t, list.IsTruncated)
+		}
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "/", 0)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(list.Uploads)) {
+			assert.Equal(t, TestFile, list.Uploads[0].Object)
+			assert.Equal(t, []string{"dir/"}, list.CommonPrefixes)
+		}
+
+		err = layer.AbortMultipartUpload(ctx, TestBucket, "dir/"+TestFile, otherID)
+		assert.NoError(t, err)
+
+		// Check that the aborted upload is not listed anymore
+		err = layer.AbortMultipartUpload(ctx, TestBucket, TestFile, uploadID)
+		assert.NoError(t, err)
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Uploads)
+		}
+
+		pending, err := metainfo.ListPendingObjects(ctx, TestBucket, storj.ListOptions{Direction: storj.After})
+		if assert.NoError(t, err) {
+			assert.Empty(t, pending.Items)
+		}
+	})
+}
+
+func TestCopyObjectPart(t *testing.T) {
+	data, err := hash.NewReader(bytes.NewReader([]byte("test")),
+		int64(len("test")),
+		"098f6bcd4621d373cade4e832627b4f6",
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Create the bucket and the source object using the Metainfo API
+		_, err := metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		_, err = createFile(ctx, metainfo, streams, TestBucket, TestFile, nil, []byte("abcdef"))
+		assert.NoError(t, err)
+
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, DestFile, map[string]string{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Check the error when copying from a non-existing object
+		_, err = layer.CopyObjectPart(ctx, TestBucket, "non-existing-file", TestBucket, DestFile, uploadID, 1, 0, -1, minio.ObjectInfo{})
+		assert.Equal(t, minio.ObjectNotFound{Bucket: TestBucket, Object: "non-existing-file"}, err)
+
+		// Check the error when copying a range outside of the object
+		_, err = layer.CopyObjectPart(ctx, TestBucket, TestFile, TestBucket, DestFile, uploadID, 1, 4, 3, minio.ObjectInfo{})
+		assert.Equal(t, minio.InvalidRange{OffsetBegin: 4, OffsetEnd: 7, ResourceSize: 6}, err)
+
+		// Check the error when copying to a non-existing upload
+		_, err = layer.CopyObjectPart(ctx, TestBucket, TestFile, TestBucket, DestFile, "non-existing-upload", 1, 0, -1, minio.ObjectInfo{})
+		assert.Error(t, err)
+
+		// Copy a range of the object, then the whole object, then upload a part
+		part, err := layer.CopyObjectPart(ctx, TestBucket, TestFile, TestBucket, DestFile, uploadID, 1, 1, 3, minio.ObjectInfo{})
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 3, part.Size)
+			assert.Equal(t, "d4b7c284882ca9e208bb65e8abd5f4c8", part.ETag) // MD5 of "bcd"
+		}
+
+		part, err = layer.CopyObjectPart(ctx, TestBucket, TestFile, TestBucket, DestFile, uploadID, 2, 0, -1, minio.ObjectInfo{})
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 6, part.Size)
+		}
+
+		_, err = layer.PutObjectPart(ctx, TestBucket, DestFile, uploadID, 3, data)
+		assert.NoError(t, err)
+
+		parts, err := layer.ListObjectParts(ctx, TestBucket, DestFile, uploadID, 0, 10)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, len(parts.Parts))
+		}
+
+		info, err := layer.CompleteMultipartUpload(ctx, TestBucket, DestFile, uploadID, completeParts(parts.Parts))
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 13, info.Size)
+		}
+
+		var buf bytes.Buffer
+		err = layer.GetObject(ctx, TestBucket, DestFile, 0, -1, &buf, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "bcdabcdeftest", buf.String())
+		}
+	})
+}
+
+func TestMultipartUploadRestart(t *testing.T) {
+	newData := func(data string) *hash.Reader {
+		reader, err := hash.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reader
+	}
+
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Create the bucket using the Metainfo API
+		_, err := metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		metadata := map[string]string{"content-type": "text/plain", "key": "value"}
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, metadata)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Upload the parts out of order, one of them twice
+		_, err = layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 2, newData("second"))
+		assert.NoError(t, err)
+		_, err = layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 1, newData("frist"))
+		assert.NoError(t, err)
+		_, err = layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, 1, newData("first"))
+		assert.NoError(t, err)
+
+		// Check the error when using the upload ID with another object
+		_, err = layer.PutObjectPart(ctx, TestBucket, DestFile, uploadID, 3, newData("third"))
+		assert.Equal(t, minio.InvalidUploadID{UploadID: uploadID}, err)
+
+		// Continue the upload with a new gateway, as after a restart
+		gateway := *layer.(*gatewayLayer).gateway
+		layer = &gatewayLayer{gateway: &gateway}
+
+		parts, err := layer.ListObjectParts(ctx, TestBucket, TestFile, uploadID, 0, 10)
+		if !assert.NoError(t, err) || !assert.Equal(t, 2, len(parts.Parts)) {
+			return
+		}
+		assert.Equal(t, 1, parts.Parts[0].PartNumber)
+		assert.EqualValues(t, len("first"), parts.Parts[0].Size)
+		assert.Equal(t, 2, parts.Parts[1].PartNumber)
+		assert.Equal(t, map[string]string{"key": "value"}, parts.UserDefined)
+
+		// Check the listing truncated to a page of parts, or to none
+		page, err := layer.ListObjectParts(ctx, TestBucket, TestFile, uploadID, 0, 1)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(page.Parts)) {
+			assert.Equal(t, 1, page.Parts[0].PartNumber)
+			assert.Equal(t, 1, page.NextPartNumberMarker)
+			assert.True(t, page.IsTruncated)
+		}
+		page, err = layer.ListObjectParts(ctx, TestBucket, TestFile, uploadID, 0, 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, page.Parts)
+			assert.Equal(t, 0, page.NextPartNumberMarker)
+			assert.True(t, page.IsTruncated)
+		}
+
+		// Check the error when completing with a wrong ETag
+		_, err = layer.CompleteMultipartUpload(ctx, TestBucket, TestFile, uploadID, []minio.CompletePart{{PartNumber: 1, ETag: "wrong"}})
+		assert.Equal(t, minio.InvalidPart{}, err)
+
+		info, err := layer.CompleteMultipartUpload(ctx, TestBucket, TestFile, uploadID, completeParts(parts.Parts))
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, len("firstsecond"), info.Size)
+			first, second := md5.Sum([]byte("first")), md5.Sum([]byte("second"))
+			etag := md5.Sum(append(first[:], second[:]...))
+			assert.Equal(t, hex.EncodeToString(etag[:])+"-2", info.ETag)
+			assert.Equal(t, "text/plain", info.ContentType)
+			assert.Equal(t, map[string]string{"key": "value"}, info.UserDefined)
+		}
+
+		var buf bytes.Buffer
+		err = layer.GetObject(ctx, TestBucket, TestFile, 0, -1, &buf, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "firstsecond", buf.String())
+		}
+
+		// Check that the completed upload and its parts are deleted
+		_, err = layer.ListObjectParts(ctx, TestBucket, TestFile, uploadID, 0, 10)
+		assert.Equal(t, minio.InvalidUploadID{UploadID: uploadID}, err)
+
+		list, err := metainfo.ListObjects(ctx, MultipartBucket, storj.ListOptions{Direction: storj.After, Recursive: true})
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Items)
+		}
+		pending, err := metainfo.ListPendingObjects(ctx, MultipartBucket, storj.ListOptions{Direction: storj.After, Recursive: true})
+		if assert.NoError(t, err) {
+			assert.Empty(t, pending.Items)
+		}
+
+		// Check that the bucket of the uploads is not listed
+		buckets, err := layer.ListBuckets(ctx)
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(buckets)) {
+			assert.Equal(t, TestBucket, buckets[0].Name)
+		}
+	})
+}
+
+func completeParts(parts []minio.PartInfo) []minio.CompletePart {
+	complete := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		complete = append(complete, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	return complete
+}
+
 func runTest(t *testing.T, test func(context.Context, minio.ObjectLayer, storj.Metainfo, streams.Store)) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -671,7 +1035,7 @@ func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, stre
 		return nil, nil, nil, err
 	}
 
-	ec := ecclient.NewClient(planet.Uplinks[0].Identity, 0)
+	ec := ecclient.NewClient(planet.Uplinks[0].Identity, 0, nil)
 	fc, err := infectious.NewFEC(2, 4)
 	if err != nil {
 		return nil, nil, nil, err
@@ -686,15 +1050,16 @@ func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, stre
 
 	key := new(storj.Key)
 	copy(key[:], TestEncKey)
+	rootKey := streams.RootKey(key)
 
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), rootKey, int(1*memory.KB), storj.AESGCM, 1, 0, storj.CompressionScheme{})
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	buckets := buckets.NewStore(streams)
 
-	metainfo := kvmetainfo.New(buckets, streams, segments, pdb, key)
+	metainfo := kvmetainfo.New(buckets, streams, segments, pdb, rootKey)
 
 	gateway := NewStorjGateway(
 		metainfo,
@@ -5,17 +5,38 @@ package miniogw
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"sort"
 	"strconv"
-	"sync"
-	"sync/atomic"
-	"time"
+	"strings"
 
 	minio "github.com/minio/minio/cmd"
 	"github.com/minio/minio/pkg/hash"
 
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
+	"storj.io/storj/pkg/utils"
+)
+
+// Multipart uploads are kept in the MultipartBucket, so they survive a
+// restart of the gateway and their parts can be uploaded in any order:
+//
+//	<bucket>/<upload ID>          the upload, a pending object with the
+//	                              metadata of the object and its key
+//	<bucket>/<upload ID>/<part>   the uploaded parts, whose ETag is
+//	                              the checksum of their content
+//
+// The parts are concatenated into the object when the upload is completed.
+//
+// The MultipartBucket is not an S3 bucket, nor listed by the uplink.
+const (
+	MultipartBucket = ".multipart"
+
+	uploadObjectKey = "object"
 )
 
 func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, object string, metadata map[string]string) (uploadID string, err error) {
@@ -27,110 +48,213 @@ func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, objec
 		return "", convertError(err, bucket, "")
 	}
 
-	uploads := layer.gateway.multipart
-
-	upload, err := uploads.Create(bucket, object, metadata)
+	err = layer.ensureMultipartBucket(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	go func() {
-		contentType := metadata["content-type"]
-		delete(metadata, "content-type")
+	var id [16]byte
+	_, err = rand.Read(id[:])
+	if err != nil {
+		return "", err
+	}
+	uploadID = hex.EncodeToString(id[:])
 
-		createInfo := storj.CreateObject{
-			ContentType:      contentType,
-			Metadata:         metadata,
-			RedundancyScheme: layer.gateway.redundancy,
-			EncryptionScheme: layer.gateway.encryption,
+	uploadMetadata := map[string]string{uploadObjectKey: object}
+	for key, value := range metadata {
+		if key != "content-type" {
+			uploadMetadata[key] = value
 		}
-		objInfo, err := layer.putObject(ctx, bucket, object, upload.Stream, &createInfo)
+	}
 
-		uploads.RemoveByID(upload.ID)
+	mutableObject, err := layer.gateway.metainfo.CreateObject(ctx, MultipartBucket, uploadPath(bucket, uploadID), &storj.CreateObject{
+		ContentType:      metadata["content-type"],
+		Metadata:         uploadMetadata,
+		RedundancyScheme: layer.gateway.redundancy,
+		EncryptionScheme: layer.gateway.encryption,
+	})
+	if err != nil {
+		return "", err
+	}
 
-		if err != nil {
-			upload.fail(err)
-		} else {
-			upload.complete(objInfo)
-		}
-	}()
+	// the upload stays pending until it is completed or aborted
+	_, err = mutableObject.CreateStream(ctx)
+	if err != nil {
+		return "", err
+	}
 
-	return upload.ID, nil
+	return uploadID, nil
 }
 
 func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	_, err = layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
+
+	return layer.putPart(ctx, bucket, uploadID, partID, data)
+}
+
+func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	upload, err := uploads.Get(bucket, object, uploadID)
+	_, err = layer.getUpload(ctx, destBucket, destObject, uploadID)
 	if err != nil {
 		return minio.PartInfo{}, err
 	}
 
-	part, err := upload.Stream.AddPart(partID, data)
+	readOnlyStream, err := layer.gateway.metainfo.GetObjectStream(ctx, srcBucket, srcObject)
+	if err != nil {
+		return minio.PartInfo{}, convertError(err, srcBucket, srcObject)
+	}
+
+	size := readOnlyStream.Info().Size
+	if startOffset < 0 || length < -1 || startOffset+length > size {
+		return minio.PartInfo{}, minio.InvalidRange{
+			OffsetBegin:  startOffset,
+			OffsetEnd:    startOffset + length,
+			ResourceSize: size,
+		}
+	}
+
+	if length == -1 {
+		length = size - startOffset
+	}
+
+	download := stream.NewDownload(ctx, readOnlyStream, layer.gateway.streams)
+	defer utils.LogClose(download)
+
+	_, err = download.Seek(startOffset, io.SeekStart)
 	if err != nil {
 		return minio.PartInfo{}, err
 	}
 
-	err = <-part.Done
+	data, err := hash.NewReader(io.LimitReader(download, length), length, "", "")
 	if err != nil {
 		return minio.PartInfo{}, err
 	}
 
-	partInfo := minio.PartInfo{
-		PartNumber:   part.Number,
-		LastModified: time.Now(),
-		ETag:         data.SHA256HexString(),
-		Size:         atomic.LoadInt64(&part.Size),
+	return layer.putPart(ctx, destBucket, uploadID, partID, data)
+}
+
+// putPart stores data as a part of the upload, replacing a previous
+// upload of the same part
+func (layer *gatewayLayer) putPart(ctx context.Context, bucket, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
+	path := partPath(bucket, uploadID, partID)
+
+	mutableObject, err := layer.gateway.metainfo.CreateObject(ctx, MultipartBucket, path, &storj.CreateObject{
+		RedundancyScheme: layer.gateway.redundancy,
+		EncryptionScheme: layer.gateway.encryption,
+	})
+	if err != nil {
+		return minio.PartInfo{}, err
 	}
 
-	upload.addCompletedPart(partInfo)
+	err = upload(ctx, layer.gateway.streams, mutableObject, data)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
 
-	return partInfo, nil
+	err = mutableObject.Commit(ctx)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
+
+	return partInfo(partID, mutableObject.Info()), nil
 }
 
 func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
-
-	upload, err := uploads.Remove(bucket, object, uploadID)
+	_, err = layer.getUpload(ctx, bucket, object, uploadID)
 	if err != nil {
 		return err
 	}
 
-	errAbort := Error.New("abort")
-	upload.Stream.Abort(errAbort)
-	r := <-upload.Done
-	if r.Error != errAbort {
-		return r.Error
-	}
-	return nil
+	return layer.deleteUpload(ctx, bucket, uploadID)
 }
 
 func (layer *gatewayLayer) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
-	upload, err := uploads.Remove(bucket, object, uploadID)
+	upload, err := layer.getUpload(ctx, bucket, object, uploadID)
 	if err != nil {
 		return minio.ObjectInfo{}, err
 	}
 
-	// notify stream that there aren't more parts coming
-	upload.Stream.Close()
-	// wait for completion
-	result := <-upload.Done
-	// return the final info
-	return result.Info, result.Error
+	parts, err := layer.listParts(ctx, bucket, uploadID)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	stored := make(map[int]minio.PartInfo, len(parts))
+	for _, part := range parts {
+		stored[part.PartNumber] = part
+	}
+
+	for i, uploaded := range uploadedParts {
+		// the parts are moved into the object, so each can be used once
+		if i > 0 && uploaded.PartNumber <= uploadedParts[i-1].PartNumber {
+			return minio.ObjectInfo{}, minio.InvalidPart{}
+		}
+		part, ok := stored[uploaded.PartNumber]
+		if !ok || part.ETag != strings.Trim(uploaded.ETag, "\"") {
+			return minio.ObjectInfo{}, minio.InvalidPart{}
+		}
+	}
+
+	createInfo := storj.CreateObject{
+		ContentType:      upload.ContentType,
+		Metadata:         upload.UserDefined,
+		RedundancyScheme: layer.gateway.redundancy,
+		EncryptionScheme: layer.gateway.encryption,
+	}
+
+	objInfo, err = layer.concatParts(ctx, bucket, object, uploadID, uploadedParts, &createInfo)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	return objInfo, layer.deleteUpload(ctx, bucket, uploadID)
+}
+
+// concatParts writes the object from the segments of the parts in their
+// order, moving them without transferring their data
+func (layer *gatewayLayer) concatParts(ctx context.Context, bucket, object, uploadID string, parts []minio.CompletePart, createInfo *storj.CreateObject) (objInfo minio.ObjectInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	paths := make([]storj.Path, len(parts))
+	for i, part := range parts {
+		paths[i] = partPath(bucket, uploadID, part.PartNumber)
+	}
+
+	info, err := layer.gateway.metainfo.ConcatObjects(ctx, MultipartBucket, paths, bucket, object, createInfo)
+	if err != nil {
+		return minio.ObjectInfo{}, convertError(err, bucket, object)
+	}
+
+	return minio.ObjectInfo{
+		Name:        object,
+		Bucket:      bucket,
+		ModTime:     info.Modified,
+		Size:        info.Size,
+		ETag:        objectETag(info.Stream),
+		ContentType: info.ContentType,
+		UserDefined: info.Metadata,
+	}, nil
 }
 
 func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int) (result minio.ListPartsInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
-	upload, err := uploads.Get(bucket, object, uploadID)
+	upload, err := layer.getUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return minio.ListPartsInfo{}, err
+	}
+
+	parts, err := layer.listParts(ctx, bucket, uploadID)
 	if err != nil {
 		return minio.ListPartsInfo{}, err
 	}
@@ -142,316 +266,251 @@ func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object,
 	list.UploadID = uploadID
 	list.PartNumberMarker = partNumberMarker
 	list.MaxParts = maxParts
-	list.UserDefined = upload.Metadata
-	list.Parts = upload.getCompletedParts()
-
-	sort.Slice(list.Parts, func(i, k int) bool {
-		return list.Parts[i].PartNumber < list.Parts[k].PartNumber
-	})
+	list.UserDefined = upload.UserDefined
 
-	var first int
-	for i, p := range list.Parts {
-		first = i
-		if partNumberMarker <= p.PartNumber {
-			break
+	for _, part := range parts {
+		if part.PartNumber > partNumberMarker {
+			list.Parts = append(list.Parts, part)
 		}
 	}
 
-	list.Parts = list.Parts[first:]
-	if len(list.Parts) > maxParts {
-		list.NextPartNumberMarker = list.Parts[maxParts].PartNumber
+	if maxParts <= 0 {
+		// no part is listed, the listing resuming at the same marker
+		list.IsTruncated = len(list.Parts) > 0
+		list.NextPartNumberMarker = partNumberMarker
+		list.Parts = nil
+	} else if len(list.Parts) > maxParts {
 		list.Parts = list.Parts[:maxParts]
+		list.NextPartNumberMarker = list.Parts[maxParts-1].PartNumber
 		list.IsTruncated = true
 	}
 
 	return list, nil
 }
 
-// TODO: implement
-// func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
-// func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
-
-// MultipartUploads manages pending multipart uploads
-type MultipartUploads struct {
-	mu      sync.RWMutex
-	lastID  int
-	pending map[string]*MultipartUpload
-}
+func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-// NewMultipartUploads creates new MultipartUploads
-func NewMultipartUploads() *MultipartUploads {
-	return &MultipartUploads{
-		pending: map[string]*MultipartUpload{},
+	if delimiter != "" && delimiter != "/" {
+		return minio.ListMultipartsInfo{}, minio.UnsupportedDelimiter{Delimiter: delimiter}
 	}
-}
-
-// Create creates a new upload
-func (uploads *MultipartUploads) Create(bucket, object string, metadata map[string]string) (*MultipartUpload, error) {
-	uploads.mu.Lock()
-	defer uploads.mu.Unlock()
 
-	for id, upload := range uploads.pending {
-		if upload.Bucket == bucket && upload.Object == object {
-			upload.Stream.Abort(Error.New("aborted by another upload to the same location"))
-			delete(uploads.pending, id)
-		}
+	// Check that the bucket exists
+	_, err = layer.gateway.metainfo.GetBucket(ctx, bucket)
+	if err != nil {
+		return minio.ListMultipartsInfo{}, convertError(err, bucket, "")
 	}
 
-	uploads.lastID++
-	uploadID := "Upload" + strconv.Itoa(uploads.lastID)
-
-	upload := NewMultipartUpload(uploadID, bucket, object, metadata)
-	uploads.pending[uploadID] = upload
-
-	return upload, nil
-}
-
-// Get finds a pending upload
-func (uploads *MultipartUploads) Get(bucket, object, uploadID string) (*MultipartUpload, error) {
-	uploads.mu.Lock()
-	defer uploads.mu.Unlock()
-
-	upload, ok := uploads.pending[uploadID]
-	if !ok {
-		return nil, Error.New("pending upload %q missing", uploadID)
-	}
-	if upload.Bucket != bucket || upload.Object != object {
-		return nil, Error.New("pending upload %q bucket/object name mismatch", uploadID)
+	uploads, err := layer.listUploads(ctx, bucket)
+	if err != nil {
+		return minio.ListMultipartsInfo{}, err
 	}
 
-	return upload, nil
-}
-
-// Remove returns and removes a pending upload
-func (uploads *MultipartUploads) Remove(bucket, object, uploadID string) (*MultipartUpload, error) {
-	uploads.mu.RLock()
-	defer uploads.mu.RUnlock()
-
-	upload, ok := uploads.pending[uploadID]
-	if !ok {
-		return nil, Error.New("pending upload %q missing", uploadID)
+	result = minio.ListMultipartsInfo{
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		MaxUploads:     maxUploads,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
 	}
-	if upload.Bucket != bucket || upload.Object != object {
-		return nil, Error.New("pending upload %q bucket/object name mismatch", uploadID)
-	}
-
-	delete(uploads.pending, uploadID)
 
-	return upload, nil
-}
-
-// RemoveByID removes pending upload by id
-func (uploads *MultipartUploads) RemoveByID(uploadID string) {
-	uploads.mu.RLock()
-	defer uploads.mu.RUnlock()
-	delete(uploads.pending, uploadID)
-}
+	count := 0
+	for _, upload := range uploads {
+		if !strings.HasPrefix(upload.Object, prefix) {
+			continue
+		}
+		if upload.Object < keyMarker || upload.Object == keyMarker && (uploadIDMarker == "" || upload.UploadID <= uploadIDMarker) {
+			continue
+		}
 
-// MultipartUpload is partial info about a pending upload
-type MultipartUpload struct {
-	ID       string
-	Bucket   string
-	Object   string
-	Metadata map[string]string
-	Done     chan (*MultipartUploadResult)
-	Stream   *MultipartStream
-
-	mu        sync.Mutex
-	completed []minio.PartInfo
-}
+		key, marker := upload.Object, upload.UploadID
+		if delimiter != "" {
+			if i := strings.Index(upload.Object[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := upload.Object[:len(prefix)+i+len(delimiter)]
+				if commonPrefix <= keyMarker {
+					continue
+				}
+				if len(result.CommonPrefixes) > 0 && result.CommonPrefixes[len(result.CommonPrefixes)-1] == commonPrefix {
+					continue
+				}
+				key, marker = commonPrefix, ""
+			}
+		}
 
-// MultipartUploadResult contains either an Error or the uploaded ObjectInfo
-type MultipartUploadResult struct {
-	Error error
-	Info  minio.ObjectInfo
-}
+		if maxUploads > 0 && count == maxUploads {
+			result.IsTruncated = true
+			break
+		}
+		count++
 
-// NewMultipartUpload creates a new MultipartUpload
-func NewMultipartUpload(uploadID string, bucket, object string, metadata map[string]string) *MultipartUpload {
-	upload := &MultipartUpload{
-		ID:       uploadID,
-		Bucket:   bucket,
-		Object:   object,
-		Metadata: metadata,
-		Done:     make(chan *MultipartUploadResult, 1),
-		Stream:   NewMultipartStream(),
-	}
-	return upload
-}
+		result.NextKeyMarker, result.NextUploadIDMarker = key, marker
+		if marker == "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, key)
+			continue
+		}
+		result.Uploads = append(result.Uploads, upload)
+	}
 
-// addCompletedPart adds a completed part to the list
-func (upload *MultipartUpload) addCompletedPart(part minio.PartInfo) {
-	upload.mu.Lock()
-	defer upload.mu.Unlock()
+	if !result.IsTruncated {
+		result.NextKeyMarker, result.NextUploadIDMarker = "", ""
+	}
 
-	upload.completed = append(upload.completed, part)
+	return result, nil
 }
 
-func (upload *MultipartUpload) getCompletedParts() []minio.PartInfo {
-	upload.mu.Lock()
-	defer upload.mu.Unlock()
-
-	return append([]minio.PartInfo{}, upload.completed...)
-}
+// ensureMultipartBucket creates the MultipartBucket if it does not exist yet
+func (layer *gatewayLayer) ensureMultipartBucket(ctx context.Context) error {
+	_, err := layer.gateway.metainfo.GetBucket(ctx, MultipartBucket)
+	if !storj.ErrBucketNotFound.Has(err) {
+		return err
+	}
 
-// fail aborts the upload with an error
-func (upload *MultipartUpload) fail(err error) {
-	upload.Done <- &MultipartUploadResult{Error: err}
-	close(upload.Done)
+	_, err = layer.gateway.metainfo.CreateBucket(ctx, MultipartBucket, &storj.Bucket{PathCipher: layer.gateway.pathCipher})
+	return err
 }
 
-// complete completes the upload
-func (upload *MultipartUpload) complete(info minio.ObjectInfo) {
-	upload.Done <- &MultipartUploadResult{Info: info}
-	close(upload.Done)
-}
+// getUpload returns the metadata of the object of an upload
+func (layer *gatewayLayer) getUpload(ctx context.Context, bucket, object, uploadID string) (_ pb.SerializableMeta, err error) {
+	mutableObject, err := layer.gateway.metainfo.ModifyPendingObject(ctx, MultipartBucket, uploadPath(bucket, uploadID))
+	if err != nil {
+		if storj.ErrBucketNotFound.Has(err) || storj.ErrObjectNotFound.Has(err) {
+			return pb.SerializableMeta{}, minio.InvalidUploadID{UploadID: uploadID}
+		}
+		return pb.SerializableMeta{}, err
+	}
 
-// MultipartStream serializes multiple readers into a single reader
-type MultipartStream struct {
-	mu          sync.Mutex
-	moreParts   sync.Cond
-	err         error
-	closed      bool
-	finished    bool
-	nextID      int
-	nextNumber  int
-	currentPart *StreamPart
-	parts       []*StreamPart
-}
+	info := mutableObject.Info()
+	if info.Metadata[uploadObjectKey] != object {
+		return pb.SerializableMeta{}, minio.InvalidUploadID{UploadID: uploadID}
+	}
 
-// StreamPart is a reader waiting in MultipartStream
-type StreamPart struct {
-	Number int
-	ID     int
-	Size   int64
-	Reader *hash.Reader
-	Done   chan error
-}
+	metadata := make(map[string]string, len(info.Metadata))
+	for key, value := range info.Metadata {
+		if key != uploadObjectKey {
+			metadata[key] = value
+		}
+	}
 
-// NewMultipartStream creates a new MultipartStream
-func NewMultipartStream() *MultipartStream {
-	stream := &MultipartStream{}
-	stream.moreParts.L = &stream.mu
-	stream.nextID = 1
-	return stream
+	return pb.SerializableMeta{
+		ContentType: info.ContentType,
+		UserDefined: metadata,
+	}, nil
 }
 
-// Abort aborts the stream reading
-func (stream *MultipartStream) Abort(err error) {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+// deleteUpload deletes the upload and its parts
+func (layer *gatewayLayer) deleteUpload(ctx context.Context, bucket, uploadID string) error {
+	mutableObject, err := layer.gateway.metainfo.ModifyPendingObject(ctx, MultipartBucket, uploadPath(bucket, uploadID))
+	if err != nil {
+		return err
+	}
 
-	if stream.finished {
-		return
+	// delete the upload first, so it is not listed with missing parts
+	err = mutableObject.DeleteStream(ctx)
+	if err != nil {
+		return err
 	}
 
-	if stream.err == nil {
-		stream.err = err
+	parts, err := layer.listParts(ctx, bucket, uploadID)
+	if err != nil {
+		return err
 	}
-	stream.finished = true
-	stream.closed = true
 
-	for _, part := range stream.parts {
-		part.Done <- err
-		close(part.Done)
+	for _, part := range parts {
+		err = layer.gateway.metainfo.DeleteObject(ctx, MultipartBucket, partPath(bucket, uploadID, part.PartNumber))
+		if err != nil && !storj.ErrObjectNotFound.Has(err) {
+			return err
+		}
 	}
-	stream.parts = nil
 
-	stream.moreParts.Broadcast()
+	return nil
 }
 
-// Close closes the stream, but lets it complete
-func (stream *MultipartStream) Close() {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+// listUploads returns the uploads to the bucket sorted by object and upload ID
+func (layer *gatewayLayer) listUploads(ctx context.Context, bucket string) (uploads []minio.MultipartInfo, err error) {
+	err = layer.listMultipartBucket(ctx, layer.gateway.metainfo.ListPendingObjects, bucket, false, func(item storj.Object) {
+		if item.IsPrefix {
+			return
+		}
+		uploads = append(uploads, minio.MultipartInfo{
+			Object:    item.Metadata[uploadObjectKey],
+			UploadID:  item.Path,
+			Initiated: item.Modified,
+		})
+	})
+
+	sort.Slice(uploads, func(i, k int) bool {
+		if uploads[i].Object != uploads[k].Object {
+			return uploads[i].Object < uploads[k].Object
+		}
+		return uploads[i].UploadID < uploads[k].UploadID
+	})
 
-	stream.closed = true
-	stream.moreParts.Broadcast()
+	return uploads, err
 }
 
-// Read implements io.Reader interface, blocking when there's no part
-func (stream *MultipartStream) Read(data []byte) (n int, err error) {
-	stream.mu.Lock()
-	for {
-		// has an error occurred?
-		if stream.err != nil {
-			stream.mu.Unlock()
-			return 0, Error.Wrap(err)
-		}
-		// still uploading the current part?
-		if stream.currentPart != nil {
-			break
-		}
-		// do we have the next part?
-		if len(stream.parts) > 0 && stream.nextID == stream.parts[0].ID {
-			stream.currentPart = stream.parts[0]
-			stream.parts = stream.parts[1:]
-			stream.nextID++
-			break
-		}
-		// we don't have the next part and are closed, hence we are complete
-		if stream.closed {
-			stream.finished = true
-			stream.mu.Unlock()
-			return 0, io.EOF
+// listParts returns the stored parts of the upload sorted by their number
+func (layer *gatewayLayer) listParts(ctx context.Context, bucket, uploadID string) (parts []minio.PartInfo, err error) {
+	err = layer.listMultipartBucket(ctx, layer.gateway.metainfo.ListObjects, uploadPath(bucket, uploadID), true, func(item storj.Object) {
+		partID, err := strconv.Atoi(item.Path)
+		if err != nil {
+			return
 		}
+		parts = append(parts, partInfo(partID, item))
+	})
 
-		stream.moreParts.Wait()
-	}
-	stream.mu.Unlock()
+	sort.Slice(parts, func(i, k int) bool {
+		return parts[i].PartNumber < parts[k].PartNumber
+	})
 
-	// read as much as we can
-	n, err = stream.currentPart.Reader.Read(data)
-	atomic.AddInt64(&stream.currentPart.Size, int64(n))
+	return parts, err
+}
 
-	if err == io.EOF {
-		// the part completed, hence advance to the next one
-		err = nil
-		close(stream.currentPart.Done)
-		stream.currentPart = nil
-	} else if err != nil {
-		// something bad happened, abort the whole thing
-		stream.Abort(err)
-		return n, Error.Wrap(err)
+// listMultipartBucket calls fn for every item under prefix in the MultipartBucket
+// listed by list, which lists either the committed or the pending objects
+func (layer *gatewayLayer) listMultipartBucket(ctx context.Context, list func(context.Context, string, storj.ListOptions) (storj.ObjectList, error), prefix string, recursive bool, fn func(item storj.Object)) error {
+	options := storj.ListOptions{
+		Direction: storj.After,
+		Prefix:    prefix + "/",
+		Recursive: recursive,
 	}
 
-	return n, err
-}
-
-// AddPart adds a new part to the stream to wait
-func (stream *MultipartStream) AddPart(partID int, data *hash.Reader) (*StreamPart, error) {
-	stream.mu.Lock()
-	defer stream.mu.Unlock()
+	for {
+		page, err := list(ctx, MultipartBucket, options)
+		if err != nil {
+			if storj.ErrBucketNotFound.Has(err) {
+				return nil
+			}
+			return err
+		}
 
-	if partID < stream.nextID {
-		return nil, Error.New("part %d already uploaded, next part ID is %d", partID, stream.nextID)
-	}
+		for _, item := range page.Items {
+			fn(item)
+		}
 
-	for _, p := range stream.parts {
-		if p.ID == partID {
-			// Replace the reader of this part with the new one.
-			// This could happen if the read timeout for this part has expired
-			// and the client tries to upload the part again.
-			p.Reader = data
-			return p, nil
+		if !page.More {
+			return nil
 		}
-	}
 
-	stream.nextNumber++
-	part := &StreamPart{
-		Number: stream.nextNumber - 1,
-		ID:     partID,
-		Size:   0,
-		Reader: data,
-		Done:   make(chan error, 1),
+		options = options.NextPage(page)
+		options.Recursive = recursive
 	}
+}
 
-	stream.parts = append(stream.parts, part)
-	sort.Slice(stream.parts, func(i, k int) bool {
-		return stream.parts[i].ID < stream.parts[k].ID
-	})
+// uploadPath returns the path of an upload in the MultipartBucket
+func uploadPath(bucket, uploadID string) storj.Path {
+	return storj.JoinPaths(bucket, uploadID)
+}
 
-	stream.moreParts.Broadcast()
+// partPath returns the path of a part of an upload in the MultipartBucket
+func partPath(bucket, uploadID string, partID int) storj.Path {
+	return storj.JoinPaths(bucket, uploadID, fmt.Sprintf("%05d", partID))
+}
 
-	return part, nil
+func partInfo(partID int, object storj.Object) minio.PartInfo {
+	return minio.PartInfo{
+		PartNumber:   partID,
+		LastModified: object.Modified,
+		ETag:         objectETag(object.Stream),
+		Size:         object.Size,
+	}
 }
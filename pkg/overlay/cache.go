@@ -127,12 +127,63 @@ func (cache *Cache) Put(ctx context.Context, nodeID storj.NodeID, value pb.Node)
 		UptimeCount:        stats.UptimeCount,
 	}
 
-	data, err := proto.Marshal(&value)
-	if err != nil {
+	return cache.update(nodeID, func(existing *pb.Node) (*pb.Node, error) {
+		node := value
+		// the graceful exit of the node is kept over the updates from the network
+		if existing != nil {
+			node.ExitStatus = existing.ExitStatus
+		}
+		return &node, nil
+	})
+}
+
+// UpdateExitStatus records the state of the graceful exit of the node
+func (cache *Cache) UpdateExitStatus(ctx context.Context, nodeID storj.NodeID, status pb.NodeExitStatus) error {
+	return cache.update(nodeID, func(existing *pb.Node) (*pb.Node, error) {
+		if existing == nil {
+			return nil, ErrNodeNotFound
+		}
+		existing.ExitStatus = status
+		return existing, nil
+	})
+}
+
+// update stores the node returned by fn for the stored node, nil if there is
+// none. The node is only replaced if it was not changed since it was read, by
+// a concurrent Put or exit status update, and the update is retried otherwise.
+func (cache *Cache) update(nodeID storj.NodeID, fn func(existing *pb.Node) (*pb.Node, error)) error {
+	for {
+		value, err := cache.db.Get(nodeID.Bytes())
+		if storage.ErrKeyNotFound.Has(err) {
+			value = nil
+		} else if err != nil {
+			return err
+		}
+
+		var existing *pb.Node
+		if value != nil {
+			existing = &pb.Node{}
+			if err := proto.Unmarshal(value, existing); err != nil {
+				return err
+			}
+		}
+
+		node, err := fn(existing)
+		if err != nil {
+			return err
+		}
+
+		data, err := proto.Marshal(node)
+		if err != nil {
+			return err
+		}
+
+		err = cache.db.CompareAndSwap(nodeID.Bytes(), value, data)
+		if storage.ErrValueChanged.Has(err) {
+			continue
+		}
 		return err
 	}
-
-	return cache.db.Put(nodeID.Bytes(), data)
 }
 
 // Delete will remove the node from the cache. Used when a node hard disconnects or fails
@@ -161,6 +212,24 @@ func (cache *Cache) ConnFailure(ctx context.Context, node *pb.Node, failureError
 	}
 }
 
+// PieceCorrupted implements the ecclient CorruptionObserver `PieceCorrupted`
+// function. A node serving corrupted data is penalized as failing an audit.
+func (cache *Cache) PieceCorrupted(ctx context.Context, node *pb.Node, corruptionError error) {
+	_, err := cache.statDB.UpdateAuditSuccess(ctx, node.Id, false)
+	if err != nil {
+		zap.L().Debug("error updating audit stats for node in statDB", zap.Error(err))
+	}
+}
+
+// PieceSlow implements the ecclient SlowObserver `PieceSlow` function. A
+// slow node is still online, so it is counted apart from the uptime stats.
+func (cache *Cache) PieceSlow(ctx context.Context, node *pb.Node) {
+	_, err := cache.statDB.ReportSlow(ctx, node.Id)
+	if err != nil {
+		zap.L().Debug("error recording slow node in statDB", zap.Error(err))
+	}
+}
+
 // ConnSuccess implements the Transport Observer `ConnSuccess` function
 func (cache *Cache) ConnSuccess(ctx context.Context, node *pb.Node) {
 	err := cache.Put(ctx, node.Id, *node)
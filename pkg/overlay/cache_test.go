@@ -99,6 +99,55 @@ func testCache(ctx context.Context, t *testing.T, store storage.KeyValueStore, s
 		}
 	}
 
+	{ // UpdateExitStatus
+		err := cache.UpdateExitStatus(ctx, valid2ID, pb.NodeExitStatus_EXITING)
+		assert.NoError(t, err)
+
+		// the exit status is kept when the node is updated from the network
+		err = cache.Put(ctx, valid2ID, pb.Node{Id: valid2ID})
+		assert.NoError(t, err)
+
+		valid2, err := cache.Get(ctx, valid2ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, pb.NodeExitStatus_EXITING, valid2.ExitStatus)
+		}
+
+		err = cache.UpdateExitStatus(ctx, missingID, pb.NodeExitStatus_EXITED)
+		assert.True(t, err == overlay.ErrNodeNotFound)
+
+		// the exit status is kept when it changes while the node is updated
+		racing := &racingStore{KeyValueStore: store}
+		racing.race = func() {
+			err := cache.UpdateExitStatus(ctx, valid2ID, pb.NodeExitStatus_EXITED)
+			assert.NoError(t, err)
+		}
+		err = overlay.NewCache(racing, sdb).Put(ctx, valid2ID, pb.Node{Id: valid2ID})
+		assert.NoError(t, err)
+
+		valid2, err = cache.Get(ctx, valid2ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, pb.NodeExitStatus_EXITED, valid2.ExitStatus)
+		}
+	}
+
+	{ // PieceSlow
+		before, err := sdb.CreateEntryIfNotExists(ctx, valid1ID)
+		assert.NoError(t, err)
+
+		cache.PieceSlow(ctx, &pb.Node{Id: valid1ID})
+
+		// the node is counted as slow, not as offline
+		slowCount, err := sdb.ReportSlow(ctx, valid1ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(2), slowCount)
+		}
+
+		after, err := sdb.Get(ctx, valid1ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, before.UptimeCount, after.UptimeCount)
+		}
+	}
+
 	{ // Delete
 		// Test standard delete
 		err := cache.Delete(ctx, valid1ID)
@@ -121,6 +170,21 @@ func testCache(ctx context.Context, t *testing.T, store storage.KeyValueStore, s
 	}
 }
 
+// racingStore runs race once, after the first value is read from the store
+type racingStore struct {
+	storage.KeyValueStore
+	race func()
+}
+
+func (store *racingStore) Get(key storage.Key) (storage.Value, error) {
+	value, err := store.KeyValueStore.Get(key)
+	if race := store.race; race != nil {
+		store.race = nil
+		race()
+	}
+	return value, err
+}
+
 func TestCache_Masterdb(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
@@ -21,6 +22,8 @@ import (
 // 	space is the storage and bandwidth requested consumption in bytes.
 //
 // Lookup finds a Node with the provided identifier.
+//
+// PieceCorrupted reports a node that served corrupted erasure shares.
 
 // ClientError creates class of errors for stack traces
 var ClientError = errs.Class("Client Error")
@@ -30,6 +33,7 @@ type Client interface {
 	Choose(ctx context.Context, op Options) ([]*pb.Node, error)
 	Lookup(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error)
 	BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb.Node, error)
+	PieceCorrupted(ctx context.Context, node *pb.Node, corruptionError error)
 }
 
 // client is the overlay concrete implementation of the client interface
@@ -115,3 +119,12 @@ func (client *client) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList)
 	}
 	return nodes, nil
 }
+
+// PieceCorrupted implements the ecclient CorruptionObserver `PieceCorrupted`
+// function, reporting the node to the overlay
+func (client *client) PieceCorrupted(ctx context.Context, node *pb.Node, corruptionError error) {
+	_, err := client.conn.ReportCorruption(ctx, &pb.CorruptionReport{NodeId: node.Id})
+	if err != nil {
+		zap.L().Debug("error reporting corrupted node to the overlay", zap.Error(err))
+	}
+}
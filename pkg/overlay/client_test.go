@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testidentity"
@@ -249,6 +250,45 @@ func TestBulkLookupV2(t *testing.T) {
 	}
 }
 
+func TestPieceCorrupted(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, cleanup := getPlanet(ctx, t)
+	defer cleanup()
+	oc := getOverlayClient(t, planet)
+
+	statDB := planet.Satellites[0].StatDB
+	node := planet.StorageNodes[0].Info
+
+	before, err := statDB.CreateEntryIfNotExists(ctx, node.Id)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// an uplink report does not fail an audit
+	oc.PieceCorrupted(ctx, &node, errs.New("corrupted"))
+
+	after, err := statDB.Get(ctx, node.Id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, before.AuditCount, after.AuditCount)
+		assert.Equal(t, before.AuditSuccessCount, after.AuditSuccessCount)
+	}
+
+	// the reports are counted once per uplink
+	oc.PieceCorrupted(ctx, &node, errs.New("corrupted"))
+
+	reports, err := statDB.ReportCorruption(ctx, node.Id, planet.Uplinks[0].ID())
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), reports)
+	}
+
+	reports, err = statDB.ReportCorruption(ctx, node.Id, planet.StorageNodes[1].ID())
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), reports)
+	}
+}
+
 func getPlanet(ctx *testcontext.Context, t *testing.T) (planet *testplanet.Planet, f func()) {
 	planet, err := testplanet.New(t, 1, 4, 1)
 	if err != nil {
@@ -76,3 +76,13 @@ func (m *MockClient) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) (
 func (mr *MockClientMockRecorder) BulkLookup(ctx, nodeIDs interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkLookup", reflect.TypeOf((*MockClient)(nil).BulkLookup), ctx, nodeIDs)
 }
+
+// PieceCorrupted mocks base method
+func (m *MockClient) PieceCorrupted(ctx context.Context, node *pb.Node, corruptionError error) {
+	m.ctrl.Call(m, "PieceCorrupted", ctx, node, corruptionError)
+}
+
+// PieceCorrupted indicates an expected call of PieceCorrupted
+func (mr *MockClientMockRecorder) PieceCorrupted(ctx, node, corruptionError interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PieceCorrupted", reflect.TypeOf((*MockClient)(nil).PieceCorrupted), ctx, node, corruptionError)
+}
@@ -69,6 +69,11 @@ func (mo *Overlay) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (
 	return &pb.LookupResponses{LookupResponse: responses}, nil
 }
 
+// ReportCorruption ignores the reported node
+func (mo *Overlay) ReportCorruption(ctx context.Context, req *pb.CorruptionReport) (*pb.CorruptionReportResponse, error) {
+	return &pb.CorruptionReportResponse{}, nil
+}
+
 // Config specifies static nodes for mock overlay
 type Config struct {
 	Nodes string `help:"a comma-separated list of <node-id>:<ip>:<port>" default:""`
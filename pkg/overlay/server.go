@@ -16,6 +16,7 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
@@ -64,6 +65,26 @@ func (server *Server) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (
 	return nodesToLookupResponses(ns), nil
 }
 
+// ReportCorruption records the calling uplink as suspecting the node of
+// serving corrupted erasure shares. Reports carry no proof, so they are
+// counted once per reporter, apart from the audit stats that only the
+// satellite's own audits and repairs update.
+func (server *Server) ReportCorruption(ctx context.Context, req *pb.CorruptionReport) (*pb.CorruptionReportResponse, error) {
+	reporter, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	reports, err := server.cache.statDB.ReportCorruption(ctx, req.NodeId, reporter.ID)
+	if err != nil {
+		server.log.Error("Error reporting corrupted node", zap.Error(err), zap.String("nodeID", req.NodeId.String()))
+		return nil, err
+	}
+	mon.IntVal("corruption_reporters").Observe(reports)
+
+	return &pb.CorruptionReportResponse{}, nil
+}
+
 // FindStorageNodes searches the overlay network for nodes that meet the provided requirements
 func (server *Server) FindStorageNodes(ctx context.Context, req *pb.FindStorageNodesRequest) (resp *pb.FindStorageNodesResponse, err error) {
 	opts := req.GetOpts()
@@ -169,6 +190,11 @@ func (server *Server) populate(ctx context.Context, startID storj.NodeID, maxNod
 			continue
 		}
 
+		// the nodes leaving the network store no new pieces
+		if v.ExitStatus != pb.NodeExitStatus_NOT_EXITING {
+			continue
+		}
+
 		restrictions := v.GetRestrictions()
 		reputation := v.GetReputation()
 
@@ -46,7 +46,34 @@ func (x NodeType) String() string {
 	return proto.EnumName(NodeType_name, int32(x))
 }
 func (NodeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{0}
+	return fileDescriptor_node_952924fa7c93b580, []int{0}
+}
+
+// NodeExitStatus is an enum of the states of the graceful exit of a node
+type NodeExitStatus int32
+
+const (
+	NodeExitStatus_NOT_EXITING NodeExitStatus = 0
+	NodeExitStatus_EXITING     NodeExitStatus = 1
+	NodeExitStatus_EXITED      NodeExitStatus = 2
+)
+
+var NodeExitStatus_name = map[int32]string{
+	0: "NOT_EXITING",
+	1: "EXITING",
+	2: "EXITED",
+}
+var NodeExitStatus_value = map[string]int32{
+	"NOT_EXITING": 0,
+	"EXITING":     1,
+	"EXITED":      2,
+}
+
+func (x NodeExitStatus) String() string {
+	return proto.EnumName(NodeExitStatus_name, int32(x))
+}
+func (NodeExitStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_node_952924fa7c93b580, []int{1}
 }
 
 // NodeTransport is an enum of possible transports for the overlay network
@@ -67,10 +94,10 @@ func (x NodeTransport) String() string {
 	return proto.EnumName(NodeTransport_name, int32(x))
 }
 func (NodeTransport) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{1}
+	return fileDescriptor_node_952924fa7c93b580, []int{2}
 }
 
-//  NodeRestrictions contains all relevant data about a nodes ability to store data
+// NodeRestrictions contains all relevant data about a nodes ability to store data
 type NodeRestrictions struct {
 	FreeBandwidth        int64    `protobuf:"varint,1,opt,name=free_bandwidth,json=freeBandwidth,proto3" json:"free_bandwidth,omitempty"`
 	FreeDisk             int64    `protobuf:"varint,2,opt,name=free_disk,json=freeDisk,proto3" json:"free_disk,omitempty"`
@@ -83,7 +110,7 @@ func (m *NodeRestrictions) Reset()         { *m = NodeRestrictions{} }
 func (m *NodeRestrictions) String() string { return proto.CompactTextString(m) }
 func (*NodeRestrictions) ProtoMessage()    {}
 func (*NodeRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{0}
+	return fileDescriptor_node_952924fa7c93b580, []int{0}
 }
 func (m *NodeRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeRestrictions.Unmarshal(m, b)
@@ -133,6 +160,7 @@ type Node struct {
 	UpdateLatency        bool              `protobuf:"varint,10,opt,name=update_latency,json=updateLatency,proto3" json:"update_latency,omitempty"`
 	UpdateAuditSuccess   bool              `protobuf:"varint,11,opt,name=update_audit_success,json=updateAuditSuccess,proto3" json:"update_audit_success,omitempty"`
 	UpdateUptime         bool              `protobuf:"varint,12,opt,name=update_uptime,json=updateUptime,proto3" json:"update_uptime,omitempty"`
+	ExitStatus           NodeExitStatus    `protobuf:"varint,13,opt,name=exit_status,json=exitStatus,proto3,enum=node.NodeExitStatus" json:"exit_status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -142,7 +170,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{1}
+	return fileDescriptor_node_952924fa7c93b580, []int{1}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -239,6 +267,13 @@ func (m *Node) GetUpdateUptime() bool {
 	return false
 }
 
+func (m *Node) GetExitStatus() NodeExitStatus {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return NodeExitStatus_NOT_EXITING
+}
+
 // NodeAddress contains the information needed to communicate with a node on the network
 type NodeAddress struct {
 	Transport            NodeTransport `protobuf:"varint,1,opt,name=transport,proto3,enum=node.NodeTransport" json:"transport,omitempty"`
@@ -252,7 +287,7 @@ func (m *NodeAddress) Reset()         { *m = NodeAddress{} }
 func (m *NodeAddress) String() string { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()    {}
 func (*NodeAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{2}
+	return fileDescriptor_node_952924fa7c93b580, []int{2}
 }
 func (m *NodeAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeAddress.Unmarshal(m, b)
@@ -305,7 +340,7 @@ func (m *NodeStats) Reset()         { *m = NodeStats{} }
 func (m *NodeStats) String() string { return proto.CompactTextString(m) }
 func (*NodeStats) ProtoMessage()    {}
 func (*NodeStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{3}
+	return fileDescriptor_node_952924fa7c93b580, []int{3}
 }
 func (m *NodeStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeStats.Unmarshal(m, b)
@@ -386,7 +421,7 @@ func (m *NodeMetadata) Reset()         { *m = NodeMetadata{} }
 func (m *NodeMetadata) String() string { return proto.CompactTextString(m) }
 func (*NodeMetadata) ProtoMessage()    {}
 func (*NodeMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_9b12b02e5677631b, []int{4}
+	return fileDescriptor_node_952924fa7c93b580, []int{4}
 }
 func (m *NodeMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeMetadata.Unmarshal(m, b)
@@ -427,52 +462,56 @@ func init() {
 	proto.RegisterType((*NodeStats)(nil), "node.NodeStats")
 	proto.RegisterType((*NodeMetadata)(nil), "node.NodeMetadata")
 	proto.RegisterEnum("node.NodeType", NodeType_name, NodeType_value)
+	proto.RegisterEnum("node.NodeExitStatus", NodeExitStatus_name, NodeExitStatus_value)
 	proto.RegisterEnum("node.NodeTransport", NodeTransport_name, NodeTransport_value)
 }
 
-func init() { proto.RegisterFile("node.proto", fileDescriptor_node_9b12b02e5677631b) }
-
-var fileDescriptor_node_9b12b02e5677631b = []byte{
-	// 642 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x4e, 0xdb, 0x40,
-	0x10, 0xc6, 0x49, 0x62, 0x9c, 0x78, 0xec, 0xa4, 0x66, 0x40, 0xc8, 0x6a, 0xd5, 0x12, 0x82, 0xaa,
-	0x46, 0x54, 0x4a, 0x29, 0x3d, 0x51, 0x55, 0xaa, 0xc2, 0x1f, 0xa1, 0xa8, 0x2e, 0x45, 0x9b, 0xc0,
-	0x81, 0x8b, 0x65, 0xe2, 0x2d, 0x5d, 0x11, 0x62, 0xcb, 0x5e, 0x0b, 0xf1, 0x86, 0x3d, 0xf4, 0x09,
-	0x7a, 0xe0, 0x15, 0xfa, 0x0a, 0xd5, 0xce, 0x3a, 0xc4, 0x56, 0xd5, 0x5b, 0xf6, 0xfb, 0x7e, 0x9e,
-	0xf1, 0xce, 0x37, 0x31, 0xc0, 0x3c, 0x8e, 0xf8, 0x20, 0x49, 0x63, 0x19, 0xa3, 0xa1, 0x7e, 0x3f,
-	0x87, 0x9b, 0xf8, 0x26, 0xd6, 0x4a, 0xef, 0x12, 0xdc, 0xb3, 0x38, 0xe2, 0x8c, 0x67, 0x32, 0x15,
-	0x53, 0x29, 0xe2, 0x79, 0x86, 0xaf, 0xa1, 0xf3, 0x3d, 0xe5, 0x3c, 0xb8, 0x0e, 0xe7, 0xd1, 0xbd,
-	0x88, 0xe4, 0x0f, 0xaf, 0xd6, 0xad, 0xf5, 0x1b, 0xac, 0xad, 0xd4, 0xc3, 0x85, 0x88, 0x2f, 0xc0,
-	0x22, 0x2c, 0x12, 0xd9, 0xad, 0x57, 0x27, 0xa2, 0xa5, 0x84, 0x63, 0x91, 0xdd, 0xf6, 0xfe, 0x34,
-	0xc0, 0x50, 0x85, 0xf1, 0x15, 0xd4, 0x45, 0x44, 0x05, 0x9c, 0xc3, 0xce, 0xcf, 0xc7, 0xad, 0x95,
-	0xdf, 0x8f, 0x5b, 0xa6, 0x72, 0x46, 0xc7, 0xac, 0x2e, 0x22, 0x7c, 0x0b, 0xcd, 0x30, 0x8a, 0x52,
-	0x9e, 0x65, 0x54, 0xc3, 0xde, 0x5f, 0x1b, 0xd0, 0x0b, 0x2b, 0x64, 0xa8, 0x0d, 0xb6, 0x20, 0xb0,
-	0x07, 0x86, 0x7c, 0x48, 0xb8, 0xd7, 0xe8, 0xd6, 0xfa, 0x9d, 0xfd, 0xce, 0x92, 0x9c, 0x3c, 0x24,
-	0x9c, 0x91, 0x87, 0x1f, 0xc1, 0x49, 0x4b, 0xb7, 0xf1, 0x0c, 0xaa, 0xba, 0xb9, 0x64, 0xcb, 0x77,
-	0x65, 0x15, 0x16, 0xdf, 0x01, 0xa4, 0x3c, 0xc9, 0x65, 0xa8, 0x8e, 0xde, 0x2a, 0x3d, 0xf9, 0x6c,
-	0xf9, 0xe4, 0x58, 0x86, 0x32, 0x63, 0x25, 0x04, 0x07, 0xd0, 0xba, 0xe3, 0x32, 0x8c, 0x42, 0x19,
-	0x7a, 0x26, 0xe1, 0xb8, 0xc4, 0xbf, 0x16, 0x0e, 0x7b, 0x62, 0x70, 0x1b, 0x9c, 0x59, 0x28, 0xf9,
-	0x7c, 0xfa, 0x10, 0xcc, 0x44, 0x26, 0xbd, 0x66, 0xb7, 0xd1, 0x6f, 0x30, 0xbb, 0xd0, 0x7c, 0x91,
-	0x49, 0xdc, 0x81, 0x76, 0x98, 0x47, 0x42, 0x06, 0x59, 0x3e, 0x9d, 0xaa, 0xb1, 0xb4, 0xba, 0xb5,
-	0x7e, 0x8b, 0x39, 0x24, 0x8e, 0xb5, 0x86, 0xeb, 0xb0, 0x2a, 0xb2, 0x20, 0x4f, 0x3c, 0x8b, 0x4c,
-	0x43, 0x64, 0x17, 0x89, 0xca, 0x2d, 0x4f, 0xa2, 0x50, 0xf2, 0xa0, 0xa8, 0xe7, 0x01, 0xb9, 0x6d,
-	0xad, 0xfa, 0x5a, 0xc4, 0x3d, 0xd8, 0x28, 0xb0, 0x6a, 0x1f, 0x9b, 0x60, 0xd4, 0xde, 0xb0, 0xdc,
-	0x6d, 0x07, 0x8a, 0x12, 0x41, 0x9e, 0x48, 0x71, 0xc7, 0x3d, 0x47, 0xbf, 0x92, 0x16, 0x2f, 0x48,
-	0xeb, 0x5d, 0x81, 0x5d, 0xca, 0x0c, 0xdf, 0x83, 0x25, 0xd3, 0x70, 0x9e, 0x25, 0x71, 0x2a, 0x29,
-	0xfe, 0xce, 0xfe, 0x7a, 0x29, 0xaf, 0x85, 0xc5, 0x96, 0x14, 0x7a, 0xd5, 0x55, 0xb0, 0x9e, 0x72,
-	0xef, 0xfd, 0xaa, 0x83, 0xf5, 0x14, 0x00, 0xbe, 0x81, 0xa6, 0x2a, 0x14, 0xfc, 0x77, 0xaf, 0x4c,
-	0x65, 0x8f, 0x22, 0x7c, 0x09, 0xb0, 0x98, 0xf6, 0xc1, 0x5e, 0xb1, 0xa2, 0x56, 0xa1, 0x1c, 0xec,
-	0xe1, 0x00, 0xd6, 0x2b, 0x13, 0x08, 0x52, 0x15, 0x2a, 0x2d, 0x57, 0x8d, 0xad, 0x95, 0xe7, 0xcd,
-	0x94, 0xa1, 0xc2, 0xd3, 0xf7, 0x2f, 0x40, 0x83, 0x40, 0x5b, 0x6b, 0x1a, 0xd9, 0x02, 0x5b, 0x97,
-	0x9c, 0xc6, 0xf9, 0x5c, 0xd2, 0x06, 0x35, 0x18, 0x90, 0x74, 0xa4, 0x94, 0x7f, 0x7b, 0x6a, 0xd0,
-	0x24, 0xb0, 0xd2, 0x53, 0xf3, 0xcb, 0x9e, 0x1a, 0x6c, 0x12, 0x58, 0xf4, 0xd4, 0x08, 0xe5, 0x49,
-	0x48, 0xb5, 0x66, 0x8b, 0x50, 0xd4, 0x5e, 0xb9, 0x68, 0xef, 0x13, 0x38, 0xe5, 0xfd, 0xc4, 0x0d,
-	0x58, 0xe5, 0x77, 0xa1, 0x98, 0xd1, 0x38, 0x2d, 0xa6, 0x0f, 0xb8, 0x09, 0xe6, 0x7d, 0x38, 0x9b,
-	0x71, 0x59, 0xa4, 0x51, 0x9c, 0x76, 0x3f, 0x43, 0x6b, 0xf1, 0x97, 0x43, 0x1b, 0x9a, 0xa3, 0xb3,
-	0xcb, 0xa1, 0x3f, 0x3a, 0x76, 0x57, 0xb0, 0x0d, 0xd6, 0x78, 0x38, 0x39, 0xf1, 0xfd, 0xd1, 0xe4,
-	0xc4, 0xad, 0x29, 0x6f, 0x3c, 0xf9, 0xc6, 0x86, 0xa7, 0x27, 0x6e, 0x1d, 0x01, 0xcc, 0x8b, 0x73,
-	0x7f, 0x74, 0xf6, 0xc5, 0x6d, 0xec, 0x6e, 0x43, 0xbb, 0xb2, 0x03, 0xe8, 0x82, 0x33, 0x39, 0x3a,
-	0x0f, 0x26, 0xfe, 0x38, 0x38, 0x65, 0xe7, 0x47, 0xee, 0xca, 0xa1, 0x71, 0x55, 0x4f, 0xae, 0xaf,
-	0x4d, 0xfa, 0x46, 0x7d, 0xf8, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xa4, 0x40, 0x85, 0x29, 0xc3, 0x04,
-	0x00, 0x00,
+func init() { proto.RegisterFile("node.proto", fileDescriptor_node_952924fa7c93b580) }
+
+var fileDescriptor_node_952924fa7c93b580 = []byte{
+	// 699 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x4e, 0xdb, 0x4c,
+	0x14, 0xc5, 0xf3, 0xc7, 0x24, 0xf1, 0x75, 0x12, 0xcc, 0x05, 0x21, 0xeb, 0xfb, 0xd4, 0x12, 0x82,
+	0xaa, 0x46, 0x54, 0x4a, 0x29, 0x55, 0x17, 0xa0, 0x4a, 0x55, 0x20, 0x11, 0xb2, 0x9a, 0x06, 0x34,
+	0x31, 0xa8, 0x62, 0x63, 0x99, 0x78, 0x4a, 0x47, 0x84, 0xd8, 0xb2, 0xc7, 0x02, 0x5e, 0xa8, 0xcf,
+	0xd2, 0x45, 0x9f, 0xa0, 0x0b, 0x9e, 0xa5, 0x9a, 0x19, 0x27, 0xb6, 0x55, 0x75, 0xe7, 0x39, 0xe7,
+	0x37, 0x67, 0xec, 0xb9, 0x27, 0x01, 0x58, 0x04, 0x3e, 0xed, 0x87, 0x51, 0xc0, 0x03, 0xd4, 0xc4,
+	0xf3, 0x7f, 0x70, 0x1b, 0xdc, 0x06, 0x4a, 0xe9, 0x5e, 0x81, 0x39, 0x09, 0x7c, 0x4a, 0x68, 0xcc,
+	0x23, 0x36, 0xe3, 0x2c, 0x58, 0xc4, 0xf8, 0x0a, 0xda, 0xdf, 0x22, 0x4a, 0xdd, 0x1b, 0x6f, 0xe1,
+	0x3f, 0x30, 0x9f, 0x7f, 0xb7, 0xca, 0x9d, 0x72, 0xaf, 0x4a, 0x5a, 0x42, 0x3d, 0x59, 0x8a, 0xf8,
+	0x3f, 0xe8, 0x12, 0xf3, 0x59, 0x7c, 0x67, 0x55, 0x24, 0xd1, 0x10, 0xc2, 0x90, 0xc5, 0x77, 0xdd,
+	0x1f, 0x1a, 0x68, 0x22, 0x18, 0x5f, 0x42, 0x85, 0xf9, 0x32, 0xa0, 0x79, 0xd2, 0xfe, 0xf9, 0xbc,
+	0x53, 0xfa, 0xfd, 0xbc, 0x53, 0x13, 0x8e, 0x3d, 0x24, 0x15, 0xe6, 0xe3, 0x1b, 0xa8, 0x7b, 0xbe,
+	0x1f, 0xd1, 0x38, 0x96, 0x19, 0xc6, 0xe1, 0x46, 0x5f, 0xbe, 0xb0, 0x40, 0x06, 0xca, 0x20, 0x4b,
+	0x02, 0xbb, 0xa0, 0xf1, 0xa7, 0x90, 0x5a, 0xd5, 0x4e, 0xb9, 0xd7, 0x3e, 0x6c, 0x67, 0xa4, 0xf3,
+	0x14, 0x52, 0x22, 0x3d, 0x3c, 0x86, 0x66, 0x94, 0xfb, 0x1a, 0x4b, 0x93, 0xa9, 0xdb, 0x19, 0x9b,
+	0xff, 0x56, 0x52, 0x60, 0xf1, 0x2d, 0x40, 0x44, 0xc3, 0x84, 0x7b, 0x62, 0x69, 0xad, 0xc9, 0x9d,
+	0xeb, 0xd9, 0xce, 0x29, 0xf7, 0x78, 0x4c, 0x72, 0x08, 0xf6, 0xa1, 0x71, 0x4f, 0xb9, 0xe7, 0x7b,
+	0xdc, 0xb3, 0x6a, 0x12, 0xc7, 0x0c, 0xff, 0x92, 0x3a, 0x64, 0xc5, 0xe0, 0x2e, 0x34, 0xe7, 0x1e,
+	0xa7, 0x8b, 0xd9, 0x93, 0x3b, 0x67, 0x31, 0xb7, 0xea, 0x9d, 0x6a, 0xaf, 0x4a, 0x8c, 0x54, 0x1b,
+	0xb3, 0x98, 0xe3, 0x1e, 0xb4, 0xbc, 0xc4, 0x67, 0xdc, 0x8d, 0x93, 0xd9, 0x4c, 0x5c, 0x4b, 0xa3,
+	0x53, 0xee, 0x35, 0x48, 0x53, 0x8a, 0x53, 0xa5, 0xe1, 0x26, 0xac, 0xb1, 0xd8, 0x4d, 0x42, 0x4b,
+	0x97, 0xa6, 0xc6, 0xe2, 0xcb, 0x50, 0xcc, 0x2d, 0x09, 0x7d, 0x8f, 0x53, 0x37, 0xcd, 0xb3, 0x40,
+	0xba, 0x2d, 0xa5, 0x8e, 0x95, 0x88, 0x07, 0xb0, 0x95, 0x62, 0xc5, 0x73, 0x0c, 0x09, 0xa3, 0xf2,
+	0x06, 0xf9, 0xd3, 0xf6, 0x20, 0x8d, 0x70, 0x93, 0x90, 0xb3, 0x7b, 0x6a, 0x35, 0xd5, 0x2b, 0x29,
+	0xf1, 0x52, 0x6a, 0xf8, 0x01, 0x0c, 0xfa, 0x28, 0xe2, 0xb8, 0xc7, 0x93, 0xd8, 0x6a, 0xc9, 0x11,
+	0x6d, 0x65, 0xb7, 0x31, 0x7a, 0x64, 0x7c, 0x2a, 0x3d, 0x02, 0x74, 0xf5, 0xdc, 0xbd, 0x06, 0x23,
+	0x37, 0x6a, 0x7c, 0x07, 0x3a, 0x8f, 0xbc, 0x45, 0x1c, 0x06, 0x11, 0x97, 0xad, 0x69, 0x1f, 0x6e,
+	0xe6, 0xc6, 0xbc, 0xb4, 0x48, 0x46, 0xa1, 0x55, 0x6c, 0x90, 0xbe, 0xaa, 0x4b, 0xf7, 0x57, 0x05,
+	0xf4, 0xd5, 0xdc, 0xf0, 0x35, 0xd4, 0x45, 0x90, 0xfb, 0xcf, 0x3a, 0xd6, 0x84, 0x6d, 0xfb, 0xf8,
+	0x02, 0x60, 0x39, 0xa4, 0xa3, 0x83, 0xb4, 0xd9, 0x7a, 0xaa, 0x1c, 0x1d, 0x60, 0x1f, 0x36, 0x0b,
+	0x17, 0xe7, 0x46, 0xa2, 0x0b, 0xb2, 0x93, 0x65, 0xb2, 0x91, 0x1f, 0x13, 0x11, 0x86, 0x98, 0xb9,
+	0xba, 0xb6, 0x14, 0xd4, 0x24, 0x68, 0x28, 0x4d, 0x21, 0x3b, 0x60, 0xa8, 0xc8, 0x59, 0x90, 0x2c,
+	0xb8, 0x2c, 0x5e, 0x95, 0x80, 0x94, 0x4e, 0x85, 0xf2, 0xf7, 0x99, 0x0a, 0xac, 0x49, 0xb0, 0x70,
+	0xa6, 0xe2, 0xb3, 0x33, 0x15, 0x58, 0x97, 0x60, 0x7a, 0xa6, 0x42, 0x64, 0x0d, 0x24, 0x52, 0xcc,
+	0x6c, 0x48, 0x14, 0x95, 0x97, 0x0f, 0xed, 0x7e, 0x84, 0x66, 0xbe, 0xd6, 0xb8, 0x05, 0x6b, 0xf4,
+	0xde, 0x63, 0x73, 0x79, 0x9d, 0x3a, 0x51, 0x0b, 0xdc, 0x86, 0xda, 0x83, 0x37, 0x9f, 0x53, 0x9e,
+	0x4e, 0x23, 0x5d, 0xed, 0x7f, 0x82, 0xc6, 0xf2, 0x97, 0x8a, 0x06, 0xd4, 0xed, 0xc9, 0xd5, 0x60,
+	0x6c, 0x0f, 0xcd, 0x12, 0xb6, 0x40, 0x9f, 0x0e, 0x9c, 0xd1, 0x78, 0x6c, 0x3b, 0x23, 0xb3, 0x2c,
+	0xbc, 0xa9, 0x73, 0x4e, 0x06, 0x67, 0x23, 0xb3, 0x82, 0x00, 0xb5, 0xcb, 0x8b, 0xb1, 0x3d, 0xf9,
+	0x6c, 0x56, 0xf7, 0x8f, 0xa1, 0x5d, 0xec, 0x11, 0xae, 0x83, 0x31, 0x39, 0x77, 0xdc, 0xd1, 0x57,
+	0xdb, 0xb1, 0x27, 0x67, 0x66, 0x49, 0xec, 0x5d, 0x2e, 0xca, 0x62, 0xaf, 0x58, 0x8c, 0x86, 0x66,
+	0x65, 0x7f, 0x17, 0x5a, 0x85, 0xfe, 0xa0, 0x09, 0x4d, 0xe7, 0xf4, 0xc2, 0x75, 0xc6, 0x53, 0xf7,
+	0x8c, 0x5c, 0x9c, 0x9a, 0xa5, 0x13, 0xed, 0xba, 0x12, 0xde, 0xdc, 0xd4, 0xe4, 0xdf, 0xe2, 0xfb,
+	0x3f, 0x03, 0x00, 0xcc, 0xd4, 0x0a, 0x1a, 0x36, 0x05, 0x00, 0x00,
 }
@@ -54,7 +54,7 @@ func (x Restriction_Operator) String() string {
 	return proto.EnumName(Restriction_Operator_name, int32(x))
 }
 func (Restriction_Operator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{11, 0}
+	return fileDescriptor_overlay_419175fb85356732, []int{13, 0}
 }
 
 type Restriction_Operand int32
@@ -77,7 +77,7 @@ func (x Restriction_Operand) String() string {
 	return proto.EnumName(Restriction_Operand_name, int32(x))
 }
 func (Restriction_Operand) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{11, 1}
+	return fileDescriptor_overlay_419175fb85356732, []int{13, 1}
 }
 
 // LookupRequest is is request message for the lookup rpc call
@@ -92,7 +92,7 @@ func (m *LookupRequest) Reset()         { *m = LookupRequest{} }
 func (m *LookupRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupRequest) ProtoMessage()    {}
 func (*LookupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{0}
+	return fileDescriptor_overlay_419175fb85356732, []int{0}
 }
 func (m *LookupRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequest.Unmarshal(m, b)
@@ -124,7 +124,7 @@ func (m *LookupResponse) Reset()         { *m = LookupResponse{} }
 func (m *LookupResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupResponse) ProtoMessage()    {}
 func (*LookupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{1}
+	return fileDescriptor_overlay_419175fb85356732, []int{1}
 }
 func (m *LookupResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponse.Unmarshal(m, b)
@@ -163,7 +163,7 @@ func (m *LookupRequests) Reset()         { *m = LookupRequests{} }
 func (m *LookupRequests) String() string { return proto.CompactTextString(m) }
 func (*LookupRequests) ProtoMessage()    {}
 func (*LookupRequests) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{2}
+	return fileDescriptor_overlay_419175fb85356732, []int{2}
 }
 func (m *LookupRequests) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequests.Unmarshal(m, b)
@@ -202,7 +202,7 @@ func (m *LookupResponses) Reset()         { *m = LookupResponses{} }
 func (m *LookupResponses) String() string { return proto.CompactTextString(m) }
 func (*LookupResponses) ProtoMessage()    {}
 func (*LookupResponses) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{3}
+	return fileDescriptor_overlay_419175fb85356732, []int{3}
 }
 func (m *LookupResponses) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponses.Unmarshal(m, b)
@@ -241,7 +241,7 @@ func (m *FindStorageNodesResponse) Reset()         { *m = FindStorageNodesRespon
 func (m *FindStorageNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesResponse) ProtoMessage()    {}
 func (*FindStorageNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{4}
+	return fileDescriptor_overlay_419175fb85356732, []int{4}
 }
 func (m *FindStorageNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesResponse.Unmarshal(m, b)
@@ -284,7 +284,7 @@ func (m *FindStorageNodesRequest) Reset()         { *m = FindStorageNodesRequest
 func (m *FindStorageNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesRequest) ProtoMessage()    {}
 func (*FindStorageNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{5}
+	return fileDescriptor_overlay_419175fb85356732, []int{5}
 }
 func (m *FindStorageNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesRequest.Unmarshal(m, b)
@@ -332,6 +332,69 @@ func (m *FindStorageNodesRequest) GetMaxNodes() int64 {
 	return 0
 }
 
+// CorruptionReport is request message for the ReportCorruption rpc call
+type CorruptionReport struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptionReport) Reset()         { *m = CorruptionReport{} }
+func (m *CorruptionReport) String() string { return proto.CompactTextString(m) }
+func (*CorruptionReport) ProtoMessage()    {}
+func (*CorruptionReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_419175fb85356732, []int{6}
+}
+func (m *CorruptionReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionReport.Unmarshal(m, b)
+}
+func (m *CorruptionReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionReport.Marshal(b, m, deterministic)
+}
+func (dst *CorruptionReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionReport.Merge(dst, src)
+}
+func (m *CorruptionReport) XXX_Size() int {
+	return xxx_messageInfo_CorruptionReport.Size(m)
+}
+func (m *CorruptionReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionReport proto.InternalMessageInfo
+
+// CorruptionReportResponse is response message for the ReportCorruption rpc call
+type CorruptionReportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptionReportResponse) Reset()         { *m = CorruptionReportResponse{} }
+func (m *CorruptionReportResponse) String() string { return proto.CompactTextString(m) }
+func (*CorruptionReportResponse) ProtoMessage()    {}
+func (*CorruptionReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_419175fb85356732, []int{7}
+}
+func (m *CorruptionReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionReportResponse.Unmarshal(m, b)
+}
+func (m *CorruptionReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionReportResponse.Marshal(b, m, deterministic)
+}
+func (dst *CorruptionReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionReportResponse.Merge(dst, src)
+}
+func (m *CorruptionReportResponse) XXX_Size() int {
+	return xxx_messageInfo_CorruptionReportResponse.Size(m)
+}
+func (m *CorruptionReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionReportResponse proto.InternalMessageInfo
+
 // OverlayOptions is a set of criteria that a node must meet to be considered for a storage opportunity
 type OverlayOptions struct {
 	MaxLatency           *duration.Duration `protobuf:"bytes,1,opt,name=max_latency,json=maxLatency" json:"max_latency,omitempty"`
@@ -349,7 +412,7 @@ func (m *OverlayOptions) Reset()         { *m = OverlayOptions{} }
 func (m *OverlayOptions) String() string { return proto.CompactTextString(m) }
 func (*OverlayOptions) ProtoMessage()    {}
 func (*OverlayOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{6}
+	return fileDescriptor_overlay_419175fb85356732, []int{8}
 }
 func (m *OverlayOptions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OverlayOptions.Unmarshal(m, b)
@@ -418,7 +481,7 @@ func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
 func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()    {}
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{7}
+	return fileDescriptor_overlay_419175fb85356732, []int{9}
 }
 func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryRequest.Unmarshal(m, b)
@@ -478,7 +541,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{8}
+	return fileDescriptor_overlay_419175fb85356732, []int{10}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -522,7 +585,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{9}
+	return fileDescriptor_overlay_419175fb85356732, []int{11}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
@@ -552,7 +615,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{10}
+	return fileDescriptor_overlay_419175fb85356732, []int{12}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
@@ -585,7 +648,7 @@ func (m *Restriction) Reset()         { *m = Restriction{} }
 func (m *Restriction) String() string { return proto.CompactTextString(m) }
 func (*Restriction) ProtoMessage()    {}
 func (*Restriction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_55263e8e6ceef0b4, []int{11}
+	return fileDescriptor_overlay_419175fb85356732, []int{13}
 }
 func (m *Restriction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Restriction.Unmarshal(m, b)
@@ -633,6 +696,8 @@ func init() {
 	proto.RegisterType((*LookupResponses)(nil), "overlay.LookupResponses")
 	proto.RegisterType((*FindStorageNodesResponse)(nil), "overlay.FindStorageNodesResponse")
 	proto.RegisterType((*FindStorageNodesRequest)(nil), "overlay.FindStorageNodesRequest")
+	proto.RegisterType((*CorruptionReport)(nil), "overlay.CorruptionReport")
+	proto.RegisterType((*CorruptionReportResponse)(nil), "overlay.CorruptionReportResponse")
 	proto.RegisterType((*OverlayOptions)(nil), "overlay.OverlayOptions")
 	proto.RegisterType((*QueryRequest)(nil), "overlay.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "overlay.QueryResponse")
@@ -661,6 +726,8 @@ type OverlayClient interface {
 	BulkLookup(ctx context.Context, in *LookupRequests, opts ...grpc.CallOption) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(ctx context.Context, in *FindStorageNodesRequest, opts ...grpc.CallOption) (*FindStorageNodesResponse, error)
+	// ReportCorruption reports a node that served corrupted erasure shares
+	ReportCorruption(ctx context.Context, in *CorruptionReport, opts ...grpc.CallOption) (*CorruptionReportResponse, error)
 }
 
 type overlayClient struct {
@@ -698,6 +765,15 @@ func (c *overlayClient) FindStorageNodes(ctx context.Context, in *FindStorageNod
 	return out, nil
 }
 
+func (c *overlayClient) ReportCorruption(ctx context.Context, in *CorruptionReport, opts ...grpc.CallOption) (*CorruptionReportResponse, error) {
+	out := new(CorruptionReportResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Overlay/ReportCorruption", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OverlayServer is the server API for Overlay service.
 type OverlayServer interface {
 	// Lookup finds a nodes address from the network
@@ -706,6 +782,8 @@ type OverlayServer interface {
 	BulkLookup(context.Context, *LookupRequests) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(context.Context, *FindStorageNodesRequest) (*FindStorageNodesResponse, error)
+	// ReportCorruption reports a node that served corrupted erasure shares
+	ReportCorruption(context.Context, *CorruptionReport) (*CorruptionReportResponse, error)
 }
 
 func RegisterOverlayServer(s *grpc.Server, srv OverlayServer) {
@@ -766,6 +844,24 @@ func _Overlay_FindStorageNodes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Overlay_ReportCorruption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptionReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayServer).ReportCorruption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Overlay/ReportCorruption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayServer).ReportCorruption(ctx, req.(*CorruptionReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Overlay_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "overlay.Overlay",
 	HandlerType: (*OverlayServer)(nil),
@@ -782,6 +878,10 @@ var _Overlay_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FindStorageNodes",
 			Handler:    _Overlay_FindStorageNodes_Handler,
 		},
+		{
+			MethodName: "ReportCorruption",
+			Handler:    _Overlay_ReportCorruption_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "overlay.proto",
@@ -884,61 +984,64 @@ var _Nodes_serviceDesc = grpc.ServiceDesc{
 	Metadata: "overlay.proto",
 }
 
-func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_55263e8e6ceef0b4) }
-
-var fileDescriptor_overlay_55263e8e6ceef0b4 = []byte{
-	// 845 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xe7, 0xc7, 0xc9, 0x9e, 0x24, 0x5e, 0x6b, 0xd4, 0xee, 0x06, 0x03, 0xdd, 0x60, 0x55,
-	0xb0, 0x12, 0x55, 0x0a, 0x29, 0xaa, 0x68, 0x05, 0x02, 0xa2, 0xa4, 0x65, 0xd5, 0xa8, 0x4b, 0x27,
-	0x91, 0x2a, 0xc1, 0x85, 0xe5, 0xc4, 0x83, 0x31, 0xeb, 0x78, 0x8c, 0x67, 0x5c, 0xed, 0xf6, 0x09,
-	0x78, 0x13, 0x5e, 0x85, 0x67, 0xe0, 0x62, 0x1f, 0x81, 0x07, 0xe0, 0x0a, 0xcd, 0x8f, 0x1d, 0x67,
-	0x77, 0x53, 0xf5, 0x6a, 0xe6, 0x9c, 0xf3, 0x7d, 0x67, 0xe6, 0x3b, 0x73, 0xe6, 0x40, 0x8f, 0xbe,
-	0x21, 0x59, 0xec, 0x5f, 0x0e, 0xd3, 0x8c, 0x72, 0x8a, 0x5a, 0xda, 0x74, 0xee, 0x85, 0x94, 0x86,
-	0x31, 0x79, 0x28, 0xdd, 0xcb, 0xfc, 0xd7, 0x87, 0x41, 0x9e, 0xf9, 0x3c, 0xa2, 0x89, 0x02, 0x3a,
-	0x10, 0xd2, 0x90, 0x16, 0xfb, 0x84, 0x06, 0x44, 0xed, 0xdd, 0xaf, 0xa1, 0x37, 0xa3, 0xf4, 0x3c,
-	0x4f, 0x31, 0xf9, 0x23, 0x27, 0x8c, 0xa3, 0xcf, 0xa0, 0x25, 0xc2, 0x5e, 0x14, 0xf4, 0x8d, 0x81,
-	0x71, 0xd2, 0x1d, 0x5b, 0x7f, 0x5f, 0x1d, 0xef, 0xfd, 0x73, 0x75, 0x6c, 0xbe, 0xa4, 0x01, 0x39,
-	0x9d, 0x60, 0x53, 0x84, 0x4f, 0x03, 0xf7, 0x0b, 0xb0, 0x0a, 0x26, 0x4b, 0x69, 0xc2, 0x08, 0xba,
-	0x07, 0x0d, 0x11, 0x93, 0xbc, 0xce, 0x08, 0x86, 0xf2, 0x18, 0xc1, 0xc2, 0xd2, 0xef, 0x9e, 0x6d,
-	0x18, 0xf2, 0x2c, 0x86, 0xbe, 0x05, 0x2b, 0x96, 0x1e, 0x2f, 0x53, 0xae, 0xbe, 0x31, 0xa8, 0x9f,
-	0x74, 0x46, 0x87, 0xc3, 0x42, 0xe6, 0x16, 0x01, 0xf7, 0xe2, 0xaa, 0xe9, 0xce, 0xe1, 0x60, 0xfb,
-	0x0a, 0x0c, 0x7d, 0x0f, 0x07, 0x65, 0x46, 0xe5, 0xd3, 0x29, 0x8f, 0x6e, 0xa4, 0x54, 0x61, 0x6c,
-	0xc5, 0x5b, 0xb6, 0xfb, 0x0d, 0xf4, 0x9f, 0x45, 0x49, 0x30, 0xe7, 0x34, 0xf3, 0x43, 0x22, 0xae,
-	0xcf, 0x4a, 0x85, 0x03, 0x68, 0x0a, 0x25, 0x4c, 0xe7, 0xac, 0x4a, 0x54, 0x01, 0xf7, 0x5f, 0x03,
-	0x8e, 0x6e, 0xd2, 0x55, 0x69, 0x8f, 0xa1, 0x43, 0x97, 0xbf, 0x93, 0x15, 0xf7, 0x58, 0xf4, 0x56,
-	0x95, 0xa9, 0x8e, 0x41, 0xb9, 0xe6, 0xd1, 0x5b, 0x82, 0xc6, 0x70, 0xb0, 0xa2, 0x09, 0xcf, 0xfc,
-	0x15, 0xf7, 0x62, 0x92, 0x84, 0xfc, 0xb7, 0x7e, 0x4d, 0xd6, 0xf2, 0x83, 0xa1, 0x7a, 0xde, 0x61,
-	0xf1, 0xbc, 0xc3, 0x89, 0x7e, 0x5e, 0x6c, 0x15, 0x8c, 0x99, 0x24, 0xa0, 0xcf, 0xa1, 0x41, 0x53,
-	0xce, 0xfa, 0x75, 0x49, 0xdc, 0xa8, 0x3e, 0x53, 0xeb, 0x59, 0x2a, 0x58, 0x0c, 0x4b, 0x10, 0xba,
-	0x0f, 0x4d, 0xc6, 0xfd, 0x8c, 0xf7, 0x1b, 0xb7, 0x3e, 0xb5, 0x0a, 0xa2, 0x0f, 0x61, 0x7f, 0xed,
-	0x5f, 0x78, 0x4a, 0x79, 0x53, 0xde, 0xba, 0xbd, 0xf6, 0x2f, 0xa4, 0x36, 0xf7, 0xaf, 0x1a, 0x58,
-	0xdb, 0xb9, 0xd1, 0x53, 0xe8, 0x08, 0x7c, 0xec, 0x73, 0x92, 0xac, 0x2e, 0x75, 0x3b, 0xbc, 0x43,
-	0x02, 0xac, 0xfd, 0x8b, 0x99, 0x02, 0xa3, 0x07, 0xb0, 0xbf, 0x8e, 0x12, 0x8f, 0x71, 0x9f, 0x33,
-	0x2d, 0xfe, 0x60, 0x53, 0xe5, 0xb9, 0x70, 0xe3, 0xf6, 0x3a, 0x4a, 0xe4, 0x0e, 0xdd, 0x07, 0x4b,
-	0xa2, 0x53, 0x42, 0x02, 0xef, 0x7c, 0x99, 0x2a, 0xd9, 0x75, 0xdc, 0x15, 0x08, 0xe1, 0x7c, 0xb1,
-	0x4c, 0x19, 0x3a, 0x04, 0xd3, 0x5f, 0xd3, 0x3c, 0x51, 0x32, 0xeb, 0x58, 0x5b, 0xe8, 0x29, 0x74,
-	0x33, 0xc2, 0x78, 0x16, 0xad, 0xe4, 0xbd, 0xa5, 0x34, 0xd1, 0x7b, 0x9b, 0x47, 0xad, 0x44, 0xf1,
-	0x16, 0x16, 0x7d, 0x09, 0x16, 0xb9, 0x58, 0xc5, 0x79, 0x40, 0x02, 0x5d, 0x18, 0x73, 0x50, 0x3f,
-	0xe9, 0x8e, 0xa1, 0x52, 0xbe, 0x5e, 0x81, 0x50, 0x95, 0xfa, 0xd3, 0x80, 0xee, 0xab, 0x9c, 0x64,
-	0x97, 0x45, 0x3f, 0xb8, 0x60, 0x32, 0x92, 0x04, 0x24, 0xbb, 0xe5, 0xc7, 0xe8, 0x88, 0xc0, 0x70,
-	0x3f, 0x0b, 0x09, 0xd7, 0xc5, 0xd8, 0xc2, 0xa8, 0x08, 0xba, 0x03, 0xcd, 0x38, 0x5a, 0x47, 0x5c,
-	0x8b, 0x57, 0x06, 0x72, 0xa0, 0x9d, 0x46, 0x49, 0xb8, 0xf4, 0x57, 0xe7, 0x52, 0x77, 0x1b, 0x97,
-	0xb6, 0xfb, 0x0b, 0xf4, 0xf4, 0x4d, 0x74, 0x63, 0xbf, 0xcf, 0x55, 0x3e, 0x85, 0x76, 0xf9, 0xa7,
-	0x6a, 0x37, 0xfa, 0xbf, 0x8c, 0xb9, 0x3d, 0xe8, 0xfc, 0x14, 0x25, 0x61, 0xf1, 0x49, 0x2d, 0xe8,
-	0x2a, 0x53, 0x87, 0xff, 0x33, 0xa0, 0x53, 0x29, 0x2c, 0x7a, 0x02, 0x6d, 0x9a, 0x92, 0xcc, 0xe7,
-	0x54, 0x1d, 0x6e, 0x8d, 0x3e, 0x2e, 0x9b, 0xb6, 0x82, 0x1b, 0x9e, 0x69, 0x10, 0x2e, 0xe1, 0xe8,
-	0x31, 0xb4, 0xe4, 0x3e, 0x09, 0x64, 0x75, 0xac, 0xd1, 0x47, 0xbb, 0x99, 0x49, 0x80, 0x0b, 0xb0,
-	0x28, 0xd8, 0x1b, 0x3f, 0xce, 0x49, 0x51, 0x30, 0x69, 0xb8, 0x5f, 0x41, 0xbb, 0x38, 0x03, 0x99,
-	0x50, 0x9b, 0x2d, 0xec, 0x3d, 0xb1, 0x4e, 0x5f, 0xd9, 0x86, 0x58, 0x9f, 0x2f, 0xec, 0x1a, 0x6a,
-	0x41, 0x7d, 0xb6, 0x98, 0xda, 0x75, 0xb1, 0x79, 0xbe, 0x98, 0xda, 0x0d, 0xf7, 0x01, 0xb4, 0x74,
-	0x7e, 0x84, 0xc0, 0x7a, 0x86, 0xa7, 0x53, 0x6f, 0xfc, 0xc3, 0xcb, 0xc9, 0xeb, 0xd3, 0xc9, 0xe2,
-	0x47, 0x7b, 0x0f, 0xf5, 0x60, 0x5f, 0xfa, 0x26, 0xa7, 0xf3, 0x17, 0xb6, 0x31, 0xba, 0x32, 0xa0,
-	0xa5, 0x7f, 0x0b, 0x7a, 0x02, 0xa6, 0x1a, 0x45, 0x68, 0xc7, 0xb8, 0x73, 0x76, 0xcd, 0x2c, 0xf4,
-	0x1d, 0xc0, 0x38, 0x8f, 0xcf, 0x35, 0xfd, 0xe8, 0x76, 0x3a, 0x73, 0xfa, 0x3b, 0xf8, 0x0c, 0xbd,
-	0x06, 0xfb, 0xfa, 0x94, 0x42, 0x83, 0x12, 0xbd, 0x63, 0x80, 0x39, 0x9f, 0xbc, 0x03, 0xa1, 0x32,
-	0x8f, 0x38, 0x34, 0x55, 0xb6, 0xc7, 0xd0, 0x94, 0x2d, 0x86, 0xee, 0x96, 0xa4, 0x6a, 0xf3, 0x3b,
-	0x87, 0xd7, 0xdd, 0x5a, 0xda, 0x23, 0x68, 0x88, 0x76, 0x41, 0x77, 0xca, 0x78, 0xa5, 0x99, 0x9c,
-	0xbb, 0xd7, 0xbc, 0x8a, 0x34, 0x6e, 0xfc, 0x5c, 0x4b, 0x97, 0x4b, 0x53, 0x8e, 0x96, 0x47, 0xff,
-	0x07, 0x00, 0x00, 0xff, 0xff, 0xae, 0x57, 0x27, 0x55, 0x24, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_419175fb85356732) }
+
+var fileDescriptor_overlay_419175fb85356732 = []byte{
+	// 885 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x5e, 0xe7, 0x7f, 0x4f, 0x12, 0xaf, 0x35, 0x6a, 0x77, 0x5d, 0x03, 0xdd, 0xd4, 0xaa, 0x60,
+	0x25, 0xaa, 0x14, 0x52, 0x54, 0xd1, 0x02, 0x02, 0x42, 0xd2, 0xb2, 0x6a, 0xd4, 0x6d, 0x27, 0x91,
+	0x2a, 0xc1, 0x45, 0xe4, 0xc4, 0x83, 0x31, 0xeb, 0x78, 0x8c, 0x67, 0x5c, 0xed, 0xf6, 0x09, 0x78,
+	0x13, 0x6e, 0x79, 0x0c, 0x9e, 0x81, 0x8b, 0x3e, 0x02, 0x0f, 0xc0, 0x15, 0x9a, 0x1f, 0x7b, 0x9d,
+	0x64, 0xc3, 0xcf, 0x95, 0xe7, 0x9c, 0xf3, 0x7d, 0xe3, 0x73, 0xbe, 0x39, 0xe7, 0x40, 0x97, 0xbe,
+	0x26, 0x69, 0xe4, 0x5d, 0xf6, 0x93, 0x94, 0x72, 0x8a, 0x9a, 0xda, 0x74, 0x6e, 0x07, 0x94, 0x06,
+	0x11, 0xb9, 0x2f, 0xdd, 0x8b, 0xec, 0x87, 0xfb, 0x7e, 0x96, 0x7a, 0x3c, 0xa4, 0xb1, 0x02, 0x3a,
+	0x10, 0xd0, 0x80, 0xe6, 0xe7, 0x98, 0xfa, 0x44, 0x9d, 0xdd, 0x4f, 0xa1, 0x3b, 0xa1, 0xf4, 0x3c,
+	0x4b, 0x30, 0xf9, 0x39, 0x23, 0x8c, 0xa3, 0x0f, 0xa0, 0x29, 0xc2, 0xf3, 0xd0, 0xb7, 0x8d, 0x9e,
+	0x71, 0xd2, 0x19, 0x9a, 0xbf, 0xbf, 0x3d, 0xde, 0xfb, 0xe3, 0xed, 0x71, 0xe3, 0x39, 0xf5, 0xc9,
+	0xe9, 0x08, 0x37, 0x44, 0xf8, 0xd4, 0x77, 0x3f, 0x02, 0x33, 0x67, 0xb2, 0x84, 0xc6, 0x8c, 0xa0,
+	0xdb, 0x50, 0x13, 0x31, 0xc9, 0x6b, 0x0f, 0xa0, 0x2f, 0x7f, 0x23, 0x58, 0x58, 0xfa, 0xdd, 0x33,
+	0x30, 0xd7, 0xfe, 0xc5, 0xd0, 0x17, 0x60, 0x46, 0xd2, 0x33, 0x4f, 0x95, 0xcb, 0x36, 0x7a, 0xd5,
+	0x93, 0xf6, 0xe0, 0xb0, 0x9f, 0x97, 0xb9, 0x46, 0xc0, 0xdd, 0xa8, 0x6c, 0xba, 0x53, 0x38, 0x58,
+	0x4f, 0x81, 0xa1, 0xaf, 0xe0, 0xa0, 0xb8, 0x51, 0xf9, 0xf4, 0x95, 0x47, 0x5b, 0x57, 0xaa, 0x30,
+	0x36, 0xa3, 0x35, 0xdb, 0xfd, 0x1c, 0xec, 0x27, 0x61, 0xec, 0x4f, 0x39, 0x4d, 0xbd, 0x80, 0x88,
+	0xf4, 0x59, 0x51, 0x61, 0x0f, 0xea, 0xa2, 0x12, 0xa6, 0xef, 0x2c, 0x97, 0xa8, 0x02, 0xee, 0x9f,
+	0x06, 0x1c, 0x6d, 0xd3, 0x95, 0xb4, 0xc7, 0xd0, 0xa6, 0x8b, 0x9f, 0xc8, 0x92, 0xcf, 0x59, 0xf8,
+	0x46, 0xc9, 0x54, 0xc5, 0xa0, 0x5c, 0xd3, 0xf0, 0x0d, 0x41, 0x43, 0x38, 0x58, 0xd2, 0x98, 0xa7,
+	0xde, 0x92, 0xcf, 0x23, 0x12, 0x07, 0xfc, 0x47, 0xbb, 0x22, 0xb5, 0xbc, 0xd5, 0x57, 0xcf, 0xdb,
+	0xcf, 0x9f, 0xb7, 0x3f, 0xd2, 0xcf, 0x8b, 0xcd, 0x9c, 0x31, 0x91, 0x04, 0xf4, 0x21, 0xd4, 0x68,
+	0xc2, 0x99, 0x5d, 0xed, 0x19, 0x6b, 0x55, 0x9f, 0xa9, 0xef, 0x59, 0x22, 0x58, 0x0c, 0x4b, 0x10,
+	0xba, 0x0b, 0x75, 0xc6, 0xbd, 0x94, 0xdb, 0xb5, 0x6b, 0x9f, 0x5a, 0x05, 0xd1, 0x3b, 0xb0, 0xbf,
+	0xf2, 0x2e, 0xe6, 0xaa, 0xf2, 0xba, 0xcc, 0xba, 0xb5, 0xf2, 0x2e, 0x64, 0x6d, 0xee, 0x67, 0x60,
+	0x7d, 0x43, 0xd3, 0x34, 0x93, 0xf7, 0x62, 0x92, 0xd0, 0xf4, 0x7f, 0xf4, 0x90, 0x03, 0xf6, 0x26,
+	0xb9, 0x78, 0x87, 0x5f, 0x2b, 0x60, 0xae, 0x27, 0x8d, 0x1e, 0x43, 0x5b, 0x24, 0x12, 0x79, 0x9c,
+	0xc4, 0xcb, 0x4b, 0xdb, 0xf8, 0x37, 0x6d, 0x60, 0xe5, 0x5d, 0x4c, 0x14, 0x18, 0xdd, 0x83, 0xfd,
+	0x55, 0x18, 0xcf, 0x19, 0xf7, 0x38, 0xd3, 0xaa, 0x1e, 0x5c, 0x3d, 0xdf, 0x54, 0xb8, 0x71, 0x6b,
+	0x15, 0xc6, 0xf2, 0x84, 0xee, 0x82, 0x29, 0xd1, 0x09, 0x21, 0xfe, 0xfc, 0x7c, 0x91, 0x28, 0x3d,
+	0xab, 0xb8, 0x23, 0x10, 0xc2, 0xf9, 0x6c, 0x91, 0x30, 0x74, 0x08, 0x0d, 0x6f, 0x45, 0xb3, 0x58,
+	0xe9, 0x57, 0xc5, 0xda, 0x42, 0x8f, 0xa1, 0x93, 0x12, 0xc6, 0xd3, 0x70, 0x29, 0xf3, 0x96, 0x9a,
+	0x89, 0xa6, 0xbe, 0xea, 0x96, 0x52, 0x14, 0xaf, 0x61, 0xd1, 0xc7, 0x60, 0x92, 0x8b, 0x65, 0x94,
+	0xf9, 0xc4, 0xd7, 0x8a, 0x37, 0x7a, 0xd5, 0x93, 0xce, 0x10, 0x4a, 0xf2, 0x75, 0x73, 0x84, 0x7a,
+	0x82, 0x5f, 0x0c, 0xe8, 0xbc, 0xcc, 0x48, 0x7a, 0x99, 0x37, 0x9a, 0x0b, 0x0d, 0x46, 0x62, 0x9f,
+	0xa4, 0xd7, 0x8c, 0xa2, 0x8e, 0x08, 0x0c, 0xf7, 0xd2, 0x80, 0x70, 0xbb, 0xb2, 0x8d, 0x51, 0x11,
+	0x74, 0x03, 0xea, 0x51, 0xb8, 0x0a, 0xb9, 0x2e, 0x5e, 0x19, 0xc8, 0x81, 0x56, 0x12, 0xc6, 0xc1,
+	0xc2, 0x5b, 0x9e, 0xcb, 0xba, 0x5b, 0xb8, 0xb0, 0xdd, 0xef, 0xa1, 0xab, 0x33, 0xd1, 0x13, 0xf3,
+	0x5f, 0x52, 0x79, 0x1f, 0x5a, 0xc5, 0xb0, 0x56, 0xb6, 0x06, 0xab, 0x88, 0xb9, 0x5d, 0x68, 0xbf,
+	0x08, 0xe3, 0x20, 0x9f, 0x7e, 0x13, 0x3a, 0xca, 0xd4, 0xe1, 0xbf, 0x0c, 0x68, 0x97, 0x84, 0x45,
+	0x8f, 0xa0, 0x45, 0x13, 0x92, 0x7a, 0x9c, 0xaa, 0x9f, 0x9b, 0x83, 0xf7, 0x8a, 0x69, 0x28, 0xe1,
+	0xfa, 0x67, 0x1a, 0x84, 0x0b, 0x38, 0x7a, 0x08, 0x4d, 0x79, 0x8e, 0x7d, 0xa9, 0x8e, 0x39, 0x78,
+	0x77, 0x37, 0x33, 0xf6, 0x71, 0x0e, 0x16, 0x82, 0xbd, 0xf6, 0xa2, 0x8c, 0xe4, 0x82, 0x49, 0xc3,
+	0xfd, 0x04, 0x5a, 0xf9, 0x3f, 0x50, 0x03, 0x2a, 0x93, 0x99, 0xb5, 0x27, 0xbe, 0xe3, 0x97, 0x96,
+	0x21, 0xbe, 0x4f, 0x67, 0x56, 0x05, 0x35, 0xa1, 0x3a, 0x99, 0x8d, 0xad, 0xaa, 0x38, 0x3c, 0x9d,
+	0x8d, 0xad, 0x9a, 0x7b, 0x0f, 0x9a, 0xfa, 0x7e, 0x84, 0xc0, 0x7c, 0x82, 0xc7, 0xe3, 0xf9, 0xf0,
+	0xeb, 0xe7, 0xa3, 0x57, 0xa7, 0xa3, 0xd9, 0xb7, 0xd6, 0x1e, 0xea, 0xc2, 0xbe, 0xf4, 0x8d, 0x4e,
+	0xa7, 0xcf, 0x2c, 0x63, 0xf0, 0x5b, 0x05, 0x9a, 0x7a, 0x5a, 0xd0, 0x23, 0x68, 0xa8, 0x1d, 0x87,
+	0x76, 0xec, 0x51, 0x67, 0xd7, 0x32, 0x44, 0x5f, 0x02, 0x0c, 0xb3, 0xe8, 0x5c, 0xd3, 0x8f, 0xae,
+	0xa7, 0x33, 0xc7, 0xde, 0xc1, 0x67, 0xe8, 0x15, 0x58, 0x9b, 0xeb, 0x0f, 0xf5, 0x0a, 0xf4, 0x8e,
+	0xcd, 0xe8, 0xdc, 0xf9, 0x07, 0x84, 0xce, 0xec, 0x05, 0x58, 0x6a, 0x41, 0x5c, 0x2d, 0x0c, 0x74,
+	0xab, 0xa0, 0x6d, 0x6e, 0x11, 0xe7, 0xce, 0xce, 0x50, 0x7e, 0xe3, 0x80, 0x43, 0x5d, 0xe5, 0xf7,
+	0x10, 0xea, 0xb2, 0x69, 0xd1, 0xcd, 0x82, 0x54, 0x1e, 0x27, 0xe7, 0x70, 0xd3, 0xad, 0x53, 0x7a,
+	0x00, 0x35, 0xd1, 0x80, 0xe8, 0x46, 0x11, 0x2f, 0xb5, 0xa7, 0x73, 0x73, 0xc3, 0xab, 0x48, 0xc3,
+	0xda, 0x77, 0x95, 0x64, 0xb1, 0x68, 0xc8, 0x65, 0xf5, 0xe0, 0xef, 0x01, 0x00, 0x3d, 0xe7, 0x8e,
+	0x29, 0xcf, 0x07, 0x00, 0x00,
 }
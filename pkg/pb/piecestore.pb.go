@@ -44,7 +44,7 @@ func (x PayerBandwidthAllocation_Action) String() string {
 	return proto.EnumName(PayerBandwidthAllocation_Action_name, int32(x))
 }
 func (PayerBandwidthAllocation_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{0, 0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{0, 0}
 }
 
 type PayerBandwidthAllocation struct {
@@ -59,7 +59,7 @@ func (m *PayerBandwidthAllocation) Reset()         { *m = PayerBandwidthAllocati
 func (m *PayerBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation) ProtoMessage()    {}
 func (*PayerBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{0}
 }
 func (m *PayerBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation.Unmarshal(m, b)
@@ -111,7 +111,7 @@ func (m *PayerBandwidthAllocation_Data) Reset()         { *m = PayerBandwidthAll
 func (m *PayerBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation_Data) ProtoMessage()    {}
 func (*PayerBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{0, 0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{0, 0}
 }
 func (m *PayerBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation_Data.Unmarshal(m, b)
@@ -185,7 +185,7 @@ func (m *RenterBandwidthAllocation) Reset()         { *m = RenterBandwidthAlloca
 func (m *RenterBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation) ProtoMessage()    {}
 func (*RenterBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{1}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{1}
 }
 func (m *RenterBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation.Unmarshal(m, b)
@@ -232,7 +232,7 @@ func (m *RenterBandwidthAllocation_Data) Reset()         { *m = RenterBandwidthA
 func (m *RenterBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation_Data) ProtoMessage()    {}
 func (*RenterBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{1, 0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{1, 0}
 }
 func (m *RenterBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation_Data.Unmarshal(m, b)
@@ -279,7 +279,7 @@ func (m *PieceStore) Reset()         { *m = PieceStore{} }
 func (m *PieceStore) String() string { return proto.CompactTextString(m) }
 func (*PieceStore) ProtoMessage()    {}
 func (*PieceStore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{2}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{2}
 }
 func (m *PieceStore) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore.Unmarshal(m, b)
@@ -322,9 +322,12 @@ func (m *PieceStore) GetAuthorization() *SignedMessage {
 
 type PieceStore_PieceData struct {
 	// TODO: may want to use customtype and fixed-length byte slice
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ExpirationUnixSec    int64    `protobuf:"varint,2,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
-	Content              []byte   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpirationUnixSec int64  `protobuf:"varint,2,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	Content           []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// share_size, if set, is the size of the erasure shares of the piece,
+	// whose hashes are kept to prove the integrity of the retrieved ranges
+	ShareSize            int64    `protobuf:"varint,4,opt,name=share_size,json=shareSize,proto3" json:"share_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -334,7 +337,7 @@ func (m *PieceStore_PieceData) Reset()         { *m = PieceStore_PieceData{} }
 func (m *PieceStore_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceStore_PieceData) ProtoMessage()    {}
 func (*PieceStore_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{2, 0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{2, 0}
 }
 func (m *PieceStore_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore_PieceData.Unmarshal(m, b)
@@ -375,6 +378,13 @@ func (m *PieceStore_PieceData) GetContent() []byte {
 	return nil
 }
 
+func (m *PieceStore_PieceData) GetShareSize() int64 {
+	if m != nil {
+		return m.ShareSize
+	}
+	return 0
+}
+
 type PieceId struct {
 	// TODO: may want to use customtype and fixed-length byte slice
 	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -388,7 +398,7 @@ func (m *PieceId) Reset()         { *m = PieceId{} }
 func (m *PieceId) String() string { return proto.CompactTextString(m) }
 func (*PieceId) ProtoMessage()    {}
 func (*PieceId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{3}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{3}
 }
 func (m *PieceId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceId.Unmarshal(m, b)
@@ -435,7 +445,7 @@ func (m *PieceSummary) Reset()         { *m = PieceSummary{} }
 func (m *PieceSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceSummary) ProtoMessage()    {}
 func (*PieceSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{4}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{4}
 }
 func (m *PieceSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceSummary.Unmarshal(m, b)
@@ -489,7 +499,7 @@ func (m *PieceRetrieval) Reset()         { *m = PieceRetrieval{} }
 func (m *PieceRetrieval) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval) ProtoMessage()    {}
 func (*PieceRetrieval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{5}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{5}
 }
 func (m *PieceRetrieval) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval.Unmarshal(m, b)
@@ -532,9 +542,12 @@ func (m *PieceRetrieval) GetAuthorization() *SignedMessage {
 
 type PieceRetrieval_PieceData struct {
 	// TODO: may want to use customtype and fixed-length byte slice
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	PieceSize            int64    `protobuf:"varint,2,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
-	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PieceSize int64  `protobuf:"varint,2,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
+	Offset    int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// proof requests the hashes of the erasure shares of the range, with the
+	// proof linking them to the root hash of the piece
+	Proof                bool     `protobuf:"varint,4,opt,name=proof,proto3" json:"proof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -544,7 +557,7 @@ func (m *PieceRetrieval_PieceData) Reset()         { *m = PieceRetrieval_PieceDa
 func (m *PieceRetrieval_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval_PieceData) ProtoMessage()    {}
 func (*PieceRetrieval_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{5, 0}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{5, 0}
 }
 func (m *PieceRetrieval_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval_PieceData.Unmarshal(m, b)
@@ -585,9 +598,21 @@ func (m *PieceRetrieval_PieceData) GetOffset() int64 {
 	return 0
 }
 
+func (m *PieceRetrieval_PieceData) GetProof() bool {
+	if m != nil {
+		return m.Proof
+	}
+	return false
+}
+
 type PieceRetrievalStream struct {
-	PieceSize            int64    `protobuf:"varint,1,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
-	Content              []byte   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	PieceSize int64  `protobuf:"varint,1,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
+	Content   []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// share_hashes and proof are sent with the first content of a range, when
+	// requested and the hashes of the erasure shares of the piece are known
+	ShareSize            int64    `protobuf:"varint,3,opt,name=share_size,json=shareSize,proto3" json:"share_size,omitempty"`
+	ShareHashes          [][]byte `protobuf:"bytes,4,rep,name=share_hashes,json=shareHashes" json:"share_hashes,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,5,rep,name=proof" json:"proof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -597,7 +622,7 @@ func (m *PieceRetrievalStream) Reset()         { *m = PieceRetrievalStream{} }
 func (m *PieceRetrievalStream) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrievalStream) ProtoMessage()    {}
 func (*PieceRetrievalStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{6}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{6}
 }
 func (m *PieceRetrievalStream) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrievalStream.Unmarshal(m, b)
@@ -631,6 +656,27 @@ func (m *PieceRetrievalStream) GetContent() []byte {
 	return nil
 }
 
+func (m *PieceRetrievalStream) GetShareSize() int64 {
+	if m != nil {
+		return m.ShareSize
+	}
+	return 0
+}
+
+func (m *PieceRetrievalStream) GetShareHashes() [][]byte {
+	if m != nil {
+		return m.ShareHashes
+	}
+	return nil
+}
+
+func (m *PieceRetrievalStream) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 type PieceDelete struct {
 	// TODO: may want to use customtype and fixed-length byte slice
 	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -644,7 +690,7 @@ func (m *PieceDelete) Reset()         { *m = PieceDelete{} }
 func (m *PieceDelete) String() string { return proto.CompactTextString(m) }
 func (*PieceDelete) ProtoMessage()    {}
 func (*PieceDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{7}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{7}
 }
 func (m *PieceDelete) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDelete.Unmarshal(m, b)
@@ -689,7 +735,7 @@ func (m *PieceDeleteSummary) Reset()         { *m = PieceDeleteSummary{} }
 func (m *PieceDeleteSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceDeleteSummary) ProtoMessage()    {}
 func (*PieceDeleteSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{8}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{8}
 }
 func (m *PieceDeleteSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDeleteSummary.Unmarshal(m, b)
@@ -728,7 +774,7 @@ func (m *PieceStoreSummary) Reset()         { *m = PieceStoreSummary{} }
 func (m *PieceStoreSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceStoreSummary) ProtoMessage()    {}
 func (*PieceStoreSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{9}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{9}
 }
 func (m *PieceStoreSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStoreSummary.Unmarshal(m, b)
@@ -772,7 +818,7 @@ func (m *StatsReq) Reset()         { *m = StatsReq{} }
 func (m *StatsReq) String() string { return proto.CompactTextString(m) }
 func (*StatsReq) ProtoMessage()    {}
 func (*StatsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{10}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{10}
 }
 func (m *StatsReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatsReq.Unmarshal(m, b)
@@ -793,20 +839,21 @@ func (m *StatsReq) XXX_DiscardUnknown() {
 var xxx_messageInfo_StatsReq proto.InternalMessageInfo
 
 type StatSummary struct {
-	UsedSpace            int64    `protobuf:"varint,1,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
-	AvailableSpace       int64    `protobuf:"varint,2,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
-	UsedBandwidth        int64    `protobuf:"varint,3,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
-	AvailableBandwidth   int64    `protobuf:"varint,4,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UsedSpace            int64             `protobuf:"varint,1,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	AvailableSpace       int64             `protobuf:"varint,2,opt,name=available_space,json=availableSpace,proto3" json:"available_space,omitempty"`
+	UsedBandwidth        int64             `protobuf:"varint,3,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
+	AvailableBandwidth   int64             `protobuf:"varint,4,opt,name=available_bandwidth,json=availableBandwidth,proto3" json:"available_bandwidth,omitempty"`
+	Satellites           []*SatelliteStats `protobuf:"bytes,5,rep,name=satellites" json:"satellites,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StatSummary) Reset()         { *m = StatSummary{} }
 func (m *StatSummary) String() string { return proto.CompactTextString(m) }
 func (*StatSummary) ProtoMessage()    {}
 func (*StatSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{11}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{11}
 }
 func (m *StatSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummary.Unmarshal(m, b)
@@ -854,10 +901,100 @@ func (m *StatSummary) GetAvailableBandwidth() int64 {
 	return 0
 }
 
+func (m *StatSummary) GetSatellites() []*SatelliteStats {
+	if m != nil {
+		return m.Satellites
+	}
+	return nil
+}
+
+// SatelliteStats are the statistics of the usage of a satellite, either
+// trusted or which pieces are still stored, the allocations of 0 not being
+// limited besides the total
+type SatelliteStats struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Trusted              bool     `protobuf:"varint,3,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	UsedSpace            int64    `protobuf:"varint,4,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	UsedBandwidth        int64    `protobuf:"varint,5,opt,name=used_bandwidth,json=usedBandwidth,proto3" json:"used_bandwidth,omitempty"`
+	AllocatedSpace       int64    `protobuf:"varint,6,opt,name=allocated_space,json=allocatedSpace,proto3" json:"allocated_space,omitempty"`
+	AllocatedBandwidth   int64    `protobuf:"varint,7,opt,name=allocated_bandwidth,json=allocatedBandwidth,proto3" json:"allocated_bandwidth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SatelliteStats) Reset()         { *m = SatelliteStats{} }
+func (m *SatelliteStats) String() string { return proto.CompactTextString(m) }
+func (*SatelliteStats) ProtoMessage()    {}
+func (*SatelliteStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{12}
+}
+func (m *SatelliteStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteStats.Unmarshal(m, b)
+}
+func (m *SatelliteStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteStats.Marshal(b, m, deterministic)
+}
+func (dst *SatelliteStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteStats.Merge(dst, src)
+}
+func (m *SatelliteStats) XXX_Size() int {
+	return xxx_messageInfo_SatelliteStats.Size(m)
+}
+func (m *SatelliteStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteStats proto.InternalMessageInfo
+
+func (m *SatelliteStats) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SatelliteStats) GetTrusted() bool {
+	if m != nil {
+		return m.Trusted
+	}
+	return false
+}
+
+func (m *SatelliteStats) GetUsedSpace() int64 {
+	if m != nil {
+		return m.UsedSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetUsedBandwidth() int64 {
+	if m != nil {
+		return m.UsedBandwidth
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAllocatedSpace() int64 {
+	if m != nil {
+		return m.AllocatedSpace
+	}
+	return 0
+}
+
+func (m *SatelliteStats) GetAllocatedBandwidth() int64 {
+	if m != nil {
+		return m.AllocatedBandwidth
+	}
+	return 0
+}
+
 type SignedMessage struct {
 	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	PublicKey            []byte   `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Chain                [][]byte `protobuf:"bytes,4,rep,name=chain" json:"chain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -867,7 +1004,7 @@ func (m *SignedMessage) Reset()         { *m = SignedMessage{} }
 func (m *SignedMessage) String() string { return proto.CompactTextString(m) }
 func (*SignedMessage) ProtoMessage()    {}
 func (*SignedMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_c429af1d114864ed, []int{12}
+	return fileDescriptor_piecestore_781a3f1451d8ce12, []int{13}
 }
 func (m *SignedMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedMessage.Unmarshal(m, b)
@@ -908,6 +1045,13 @@ func (m *SignedMessage) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *SignedMessage) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PayerBandwidthAllocation)(nil), "piecestoreroutes.PayerBandwidthAllocation")
 	proto.RegisterType((*PayerBandwidthAllocation_Data)(nil), "piecestoreroutes.PayerBandwidthAllocation.Data")
@@ -925,6 +1069,7 @@ func init() {
 	proto.RegisterType((*PieceStoreSummary)(nil), "piecestoreroutes.PieceStoreSummary")
 	proto.RegisterType((*StatsReq)(nil), "piecestoreroutes.StatsReq")
 	proto.RegisterType((*StatSummary)(nil), "piecestoreroutes.StatSummary")
+	proto.RegisterType((*SatelliteStats)(nil), "piecestoreroutes.SatelliteStats")
 	proto.RegisterType((*SignedMessage)(nil), "piecestoreroutes.SignedMessage")
 	proto.RegisterEnum("piecestoreroutes.PayerBandwidthAllocation_Action", PayerBandwidthAllocation_Action_name, PayerBandwidthAllocation_Action_value)
 }
@@ -1200,67 +1345,77 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	Metadata: "piecestore.proto",
 }
 
-func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_c429af1d114864ed) }
-
-var fileDescriptor_piecestore_c429af1d114864ed = []byte{
-	// 944 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
-	0x13, 0x36, 0x29, 0x5b, 0x7f, 0x46, 0x96, 0xac, 0xac, 0x8d, 0xdf, 0x8f, 0x26, 0xe2, 0x5a, 0x60,
-	0x9a, 0x54, 0x48, 0x00, 0xb5, 0x71, 0x81, 0xde, 0x63, 0xd8, 0x28, 0x84, 0xa0, 0x8e, 0xb1, 0xb2,
-	0x2f, 0x39, 0x94, 0x59, 0x91, 0x13, 0x65, 0x11, 0x8a, 0x64, 0xc9, 0xa5, 0x2b, 0xf9, 0x55, 0xfa,
-	0x08, 0x45, 0xdf, 0xa3, 0xb7, 0xde, 0x7a, 0xe8, 0x21, 0x40, 0x81, 0xbe, 0x45, 0x2f, 0x05, 0x77,
-	0x29, 0xd2, 0xb2, 0x44, 0xa9, 0x08, 0x9a, 0x1b, 0x77, 0x66, 0xf6, 0x9b, 0x99, 0x8f, 0xdf, 0x70,
-	0x08, 0x9d, 0x90, 0xa3, 0x83, 0xb1, 0x08, 0x22, 0xec, 0x87, 0x51, 0x20, 0x02, 0x72, 0xc7, 0x12,
-	0x05, 0x89, 0xc0, 0xd8, 0x84, 0x71, 0x30, 0x0e, 0x94, 0xd7, 0xfa, 0xad, 0x02, 0xc6, 0x25, 0x9b,
-	0x61, 0x74, 0xca, 0x7c, 0xf7, 0x47, 0xee, 0x8a, 0x77, 0x2f, 0x3c, 0x2f, 0x70, 0x98, 0xe0, 0x81,
-	0x4f, 0x1e, 0x42, 0x23, 0xe6, 0x63, 0x9f, 0x89, 0x24, 0x42, 0x43, 0xeb, 0x6a, 0xbd, 0x5d, 0x5a,
-	0x18, 0x08, 0x81, 0x6d, 0x97, 0x09, 0x66, 0xe8, 0xd2, 0x21, 0x9f, 0xcd, 0xbf, 0x74, 0xd8, 0x3e,
-	0x63, 0x82, 0x91, 0xe7, 0xb0, 0x1b, 0x33, 0x81, 0x9e, 0xc7, 0x05, 0xda, 0xdc, 0x55, 0xb7, 0x4f,
-	0xdb, 0xbf, 0x7e, 0x38, 0xde, 0xfa, 0xe3, 0xc3, 0x71, 0xf5, 0x22, 0x70, 0x71, 0x70, 0x46, 0x9b,
-	0x79, 0xcc, 0xc0, 0x25, 0xcf, 0xa0, 0x91, 0x84, 0x1e, 0xf7, 0xdf, 0xa7, 0xf1, 0xfa, 0xca, 0xf8,
-	0xba, 0x0a, 0x18, 0xb8, 0xe4, 0x10, 0xea, 0x13, 0x36, 0xb5, 0x63, 0x7e, 0x8b, 0x46, 0xa5, 0xab,
-	0xf5, 0x2a, 0xb4, 0x36, 0x61, 0xd3, 0x21, 0xbf, 0x45, 0xd2, 0x87, 0x7d, 0x9c, 0x86, 0x3c, 0x92,
-	0x3d, 0xd8, 0x89, 0xcf, 0xa7, 0x76, 0x8c, 0x8e, 0xb1, 0x2d, 0xa3, 0x1e, 0x14, 0xae, 0x6b, 0x9f,
-	0x4f, 0x87, 0xe8, 0x90, 0x47, 0xd0, 0x8a, 0x31, 0xe2, 0xcc, 0xb3, 0xfd, 0x64, 0x32, 0xc2, 0xc8,
-	0xd8, 0xe9, 0x6a, 0xbd, 0x06, 0xdd, 0x55, 0xc6, 0x0b, 0x69, 0x23, 0x03, 0xa8, 0x32, 0x27, 0xbd,
-	0x65, 0x54, 0xbb, 0x5a, 0xaf, 0x7d, 0xf2, 0xbc, 0x7f, 0x9f, 0xd6, 0x7e, 0x19, 0x8d, 0xfd, 0x17,
-	0xf2, 0x22, 0xcd, 0x00, 0x48, 0x0f, 0x3a, 0x4e, 0x84, 0x4c, 0xa0, 0x5b, 0x14, 0x57, 0x93, 0xc5,
-	0xb5, 0x33, 0xfb, 0xbc, 0xb2, 0xff, 0x43, 0x2d, 0x4c, 0x46, 0xf6, 0x7b, 0x9c, 0x19, 0x75, 0x49,
-	0x72, 0x35, 0x4c, 0x46, 0x2f, 0x71, 0x66, 0x99, 0x50, 0x55, 0xa0, 0xa4, 0x06, 0x95, 0xcb, 0xeb,
-	0xab, 0xce, 0x56, 0xfa, 0xf0, 0xed, 0xf9, 0x55, 0x47, 0xb3, 0xfe, 0xd6, 0xe0, 0x90, 0xa2, 0x2f,
-	0xfe, 0xab, 0x57, 0xfa, 0xb3, 0x96, 0xbd, 0xd2, 0x6b, 0xe8, 0x84, 0x69, 0x8b, 0x36, 0xcb, 0xe1,
-	0x24, 0x42, 0xf3, 0xe4, 0xe9, 0xbf, 0x27, 0x83, 0xee, 0x49, 0x8c, 0x3b, 0x15, 0x1d, 0xc0, 0x8e,
-	0x08, 0x04, 0xf3, 0x64, 0xd2, 0x0a, 0x55, 0x07, 0xf2, 0x0d, 0xec, 0xa5, 0x70, 0x6c, 0x8c, 0xb6,
-	0x1f, 0xb8, 0x52, 0x42, 0x95, 0x95, 0x92, 0x68, 0x65, 0x61, 0xf2, 0xe8, 0x5a, 0x7f, 0xea, 0x00,
-	0x97, 0x69, 0x31, 0xc3, 0xb4, 0x18, 0xf2, 0x3d, 0x1c, 0x8c, 0xe6, 0x45, 0x2c, 0xd7, 0xfd, 0x6c,
-	0xb9, 0xee, 0x52, 0xe6, 0xe8, 0xfe, 0x68, 0x05, 0x9d, 0xe7, 0x00, 0x12, 0xc2, 0xce, 0x69, 0x6b,
-	0x9e, 0x3c, 0x59, 0xc1, 0x46, 0x5e, 0x91, 0x7a, 0x4c, 0xf9, 0xa4, 0x8d, 0x70, 0xfe, 0x48, 0xce,
-	0xa1, 0xc5, 0x12, 0xf1, 0x2e, 0x88, 0xf8, 0xad, 0xaa, 0xaf, 0x22, 0x91, 0x8e, 0x97, 0x91, 0x86,
-	0x7c, 0xec, 0xa3, 0xfb, 0x1d, 0xc6, 0x31, 0x1b, 0x23, 0x5d, 0xbc, 0x65, 0x22, 0x34, 0x72, 0x78,
-	0xd2, 0x06, 0x3d, 0x9b, 0xbb, 0x06, 0xd5, 0xb9, 0x5b, 0x36, 0x16, 0x7a, 0xd9, 0x58, 0x18, 0x50,
-	0x73, 0x02, 0x5f, 0xa0, 0x2f, 0x14, 0xf3, 0x74, 0x7e, 0xb4, 0xde, 0x40, 0x4d, 0xa6, 0x19, 0xb8,
-	0x4b, 0x49, 0x96, 0x1a, 0xd1, 0x3f, 0xa6, 0x11, 0x6b, 0x02, 0xbb, 0x8a, 0xb2, 0x64, 0x32, 0x61,
-	0xd1, 0x6c, 0x29, 0xcd, 0xd1, 0x9c, 0x76, 0x39, 0xff, 0xaa, 0x05, 0x45, 0xe7, 0xba, 0x2f, 0x40,
-	0xa5, 0xa4, 0x55, 0xeb, 0x77, 0x1d, 0xda, 0x32, 0x1f, 0x45, 0x11, 0x71, 0xbc, 0x61, 0xde, 0x27,
-	0x17, 0xce, 0x60, 0x85, 0x70, 0x9e, 0x96, 0x08, 0x27, 0xaf, 0xea, 0x93, 0x8a, 0x87, 0xae, 0x13,
-	0xcf, 0x06, 0xc2, 0xff, 0x07, 0xd5, 0xe0, 0xed, 0xdb, 0x18, 0x45, 0xc6, 0x71, 0x76, 0xb2, 0x5e,
-	0xc1, 0xc1, 0x62, 0x07, 0x43, 0x11, 0x21, 0x9b, 0xdc, 0x83, 0xd3, 0xee, 0xc3, 0xdd, 0x91, 0x9e,
-	0xbe, 0x28, 0x3d, 0x17, 0x9a, 0xaa, 0x48, 0xf4, 0x50, 0xe0, 0x66, 0xf9, 0x7d, 0x14, 0x15, 0x56,
-	0x1f, 0xc8, 0x9d, 0x2c, 0x73, 0x11, 0x1a, 0x50, 0x9b, 0xa8, 0xf8, 0x2c, 0xe3, 0xfc, 0x68, 0x5d,
-	0xc1, 0x83, 0x62, 0xc2, 0x37, 0x86, 0x93, 0xc7, 0xd0, 0x96, 0x1f, 0x39, 0x3b, 0x42, 0x07, 0xf9,
-	0x0d, 0xba, 0x19, 0xa1, 0x2d, 0x69, 0xa5, 0x99, 0xd1, 0x02, 0xa8, 0x0f, 0x05, 0x13, 0x31, 0xc5,
-	0x1f, 0xac, 0x5f, 0x34, 0x68, 0xa6, 0x87, 0x39, 0xf8, 0x11, 0x40, 0x12, 0xa3, 0x6b, 0xc7, 0x21,
-	0x73, 0x72, 0x02, 0x53, 0xcb, 0x30, 0x35, 0x90, 0x2f, 0x60, 0x8f, 0xdd, 0x30, 0xee, 0xb1, 0x91,
-	0x87, 0x59, 0x8c, 0x4a, 0xd1, 0xce, 0xcd, 0x2a, 0xf0, 0x31, 0xb4, 0x25, 0x4e, 0x2e, 0xd1, 0xec,
-	0x05, 0xb6, 0x52, 0x6b, 0x2e, 0x66, 0xf2, 0x25, 0xec, 0x17, 0x78, 0x45, 0xac, 0x5a, 0xa9, 0x24,
-	0x77, 0xe5, 0x17, 0xac, 0x37, 0xd0, 0x5a, 0x60, 0x38, 0xdf, 0x2c, 0x5a, 0xb1, 0x59, 0x16, 0x77,
-	0x91, 0x7e, 0x7f, 0x17, 0xa5, 0x1a, 0x49, 0x46, 0x1e, 0x77, 0xe4, 0xfe, 0x53, 0x9f, 0xa0, 0x86,
-	0xb2, 0xbc, 0xc4, 0xd9, 0xc9, 0x4f, 0x15, 0xe8, 0x14, 0xa4, 0x53, 0xf9, 0x56, 0xc9, 0x19, 0xec,
-	0x48, 0x1b, 0x39, 0x2c, 0x19, 0xa5, 0x81, 0x6b, 0x7e, 0x56, 0xf6, 0x79, 0x56, 0xd4, 0x5a, 0x5b,
-	0xe4, 0x35, 0xd4, 0x33, 0xc1, 0x22, 0xe9, 0x6e, 0x9a, 0x49, 0xf3, 0xc9, 0xa6, 0x08, 0xa5, 0x79,
-	0x6b, 0xab, 0xa7, 0x7d, 0xa5, 0x91, 0x0b, 0xd8, 0x51, 0x9b, 0xe9, 0xe1, 0xba, 0x2d, 0x61, 0x3e,
-	0x5a, 0xe7, 0xcd, 0x2b, 0xed, 0x69, 0xe4, 0x15, 0x54, 0xb3, 0x59, 0x38, 0x2a, 0xb9, 0xa2, 0xdc,
-	0xe6, 0xe7, 0x6b, 0xdd, 0x45, 0xf3, 0x67, 0x69, 0x81, 0x4c, 0xc4, 0xc4, 0x5c, 0x31, 0x34, 0x99,
-	0x1c, 0xcd, 0xa3, 0xd5, 0xbe, 0x1c, 0xe5, 0x74, 0xfb, 0xb5, 0x1e, 0x8e, 0x46, 0x55, 0xf9, 0x8f,
-	0xf9, 0xf5, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x5b, 0x25, 0xce, 0x20, 0x95, 0x0a, 0x00, 0x00,
+func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_781a3f1451d8ce12) }
+
+var fileDescriptor_piecestore_781a3f1451d8ce12 = []byte{
+	// 1101 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x4b, 0x6f, 0xe3, 0x54,
+	0x14, 0xae, 0xed, 0x3c, 0x4f, 0x1e, 0xcd, 0xdc, 0x56, 0xe0, 0x46, 0x53, 0x1a, 0x3c, 0xcc, 0x10,
+	0xcd, 0x48, 0x81, 0x29, 0x12, 0x6b, 0xa6, 0x6a, 0x05, 0xd1, 0x88, 0x52, 0x39, 0xed, 0x66, 0x16,
+	0x98, 0x1b, 0xfb, 0x34, 0xb9, 0x1a, 0xc7, 0x36, 0xf6, 0x75, 0x69, 0xbb, 0x43, 0xe2, 0x3f, 0xb0,
+	0xe0, 0x07, 0xb0, 0xe0, 0x8f, 0xb0, 0x63, 0xcf, 0x62, 0x56, 0x2c, 0x91, 0xf8, 0x01, 0x6c, 0x90,
+	0xef, 0xf5, 0xa3, 0xcd, 0xab, 0xa8, 0x9a, 0xd9, 0xf9, 0x3c, 0xee, 0xb9, 0xdf, 0xfd, 0xce, 0x23,
+	0x27, 0xd0, 0x09, 0x18, 0xda, 0x18, 0x71, 0x3f, 0xc4, 0x41, 0x10, 0xfa, 0xdc, 0x27, 0x37, 0x34,
+	0xa1, 0x1f, 0x73, 0x8c, 0xba, 0x30, 0xf1, 0x27, 0xbe, 0xb4, 0x1a, 0x7f, 0x68, 0xa0, 0x9f, 0xd0,
+	0x2b, 0x0c, 0x0f, 0xa8, 0xe7, 0xfc, 0xc0, 0x1c, 0x3e, 0x7d, 0xe1, 0xba, 0xbe, 0x4d, 0x39, 0xf3,
+	0x3d, 0xf2, 0x10, 0xea, 0x11, 0x9b, 0x78, 0x94, 0xc7, 0x21, 0xea, 0x4a, 0x4f, 0xe9, 0x37, 0xcd,
+	0x42, 0x41, 0x08, 0x94, 0x1c, 0xca, 0xa9, 0xae, 0x0a, 0x83, 0xf8, 0xee, 0xfe, 0xa5, 0x42, 0xe9,
+	0x90, 0x72, 0x4a, 0x9e, 0x43, 0x33, 0xa2, 0x1c, 0x5d, 0x97, 0x71, 0xb4, 0x98, 0x23, 0x4f, 0x1f,
+	0xb4, 0x7f, 0x7f, 0xb3, 0xb7, 0xf1, 0xe7, 0x9b, 0xbd, 0xca, 0xb1, 0xef, 0xe0, 0xf0, 0xd0, 0x6c,
+	0xe4, 0x3e, 0x43, 0x87, 0x3c, 0x83, 0x7a, 0x1c, 0xb8, 0xcc, 0x7b, 0x9d, 0xf8, 0xab, 0x4b, 0xfd,
+	0x6b, 0xd2, 0x61, 0xe8, 0x90, 0x1d, 0xa8, 0xcd, 0xe8, 0xa5, 0x15, 0xb1, 0x6b, 0xd4, 0xb5, 0x9e,
+	0xd2, 0xd7, 0xcc, 0xea, 0x8c, 0x5e, 0x8e, 0xd8, 0x35, 0x92, 0x01, 0x6c, 0xe1, 0x65, 0xc0, 0x42,
+	0xf1, 0x06, 0x2b, 0xf6, 0xd8, 0xa5, 0x15, 0xa1, 0xad, 0x97, 0x84, 0xd7, 0x83, 0xc2, 0x74, 0xe6,
+	0xb1, 0xcb, 0x11, 0xda, 0xe4, 0x11, 0xb4, 0x22, 0x0c, 0x19, 0x75, 0x2d, 0x2f, 0x9e, 0x8d, 0x31,
+	0xd4, 0xcb, 0x3d, 0xa5, 0x5f, 0x37, 0x9b, 0x52, 0x79, 0x2c, 0x74, 0x64, 0x08, 0x15, 0x6a, 0x27,
+	0xa7, 0xf4, 0x4a, 0x4f, 0xe9, 0xb7, 0xf7, 0x9f, 0x0f, 0xe6, 0x69, 0x1d, 0xac, 0xa2, 0x71, 0xf0,
+	0x42, 0x1c, 0x34, 0xd3, 0x00, 0xa4, 0x0f, 0x1d, 0x3b, 0x44, 0xca, 0xd1, 0x29, 0xc0, 0x55, 0x05,
+	0xb8, 0x76, 0xaa, 0xcf, 0x90, 0xbd, 0x0f, 0xd5, 0x20, 0x1e, 0x5b, 0xaf, 0xf1, 0x4a, 0xaf, 0x09,
+	0x92, 0x2b, 0x41, 0x3c, 0x7e, 0x89, 0x57, 0x46, 0x17, 0x2a, 0x32, 0x28, 0xa9, 0x82, 0x76, 0x72,
+	0x76, 0xda, 0xd9, 0x48, 0x3e, 0xbe, 0x3c, 0x3a, 0xed, 0x28, 0xc6, 0xbf, 0x0a, 0xec, 0x98, 0xe8,
+	0xf1, 0xb7, 0x95, 0xd2, 0xdf, 0x94, 0x34, 0xa5, 0x67, 0xd0, 0x09, 0x92, 0x27, 0x5a, 0x34, 0x0f,
+	0x27, 0x22, 0x34, 0xf6, 0x9f, 0xfe, 0x7f, 0x32, 0xcc, 0x4d, 0x11, 0xe3, 0x06, 0xa2, 0x6d, 0x28,
+	0x73, 0x9f, 0x53, 0x57, 0x5c, 0xaa, 0x99, 0x52, 0x20, 0x9f, 0xc3, 0x66, 0x12, 0x8e, 0x4e, 0xd0,
+	0xf2, 0x7c, 0x47, 0x94, 0x90, 0xb6, 0xb4, 0x24, 0x5a, 0xa9, 0x9b, 0x10, 0x1d, 0xe3, 0x47, 0x0d,
+	0xe0, 0x24, 0x01, 0x33, 0x4a, 0xc0, 0x90, 0x6f, 0x61, 0x7b, 0x9c, 0x81, 0x58, 0xc4, 0xfd, 0x6c,
+	0x11, 0xf7, 0x4a, 0xe6, 0xcc, 0xad, 0xf1, 0xa2, 0x92, 0x1c, 0x01, 0x88, 0x10, 0x56, 0x4e, 0x5b,
+	0x63, 0xff, 0xc9, 0x12, 0x36, 0x72, 0x44, 0xf2, 0x33, 0xe1, 0xd3, 0xac, 0x07, 0xd9, 0x27, 0x39,
+	0x82, 0x16, 0x8d, 0xf9, 0xd4, 0x0f, 0xd9, 0xb5, 0xc4, 0xa7, 0x89, 0x48, 0x7b, 0x8b, 0x91, 0x46,
+	0x6c, 0xe2, 0xa1, 0xf3, 0x35, 0x46, 0x11, 0x9d, 0xa0, 0x79, 0xfb, 0x54, 0xf7, 0x27, 0x05, 0xea,
+	0x79, 0x7c, 0xd2, 0x06, 0x35, 0x6d, 0xbc, 0xba, 0xa9, 0x32, 0x67, 0x55, 0x5f, 0xa8, 0xab, 0xfa,
+	0x42, 0x87, 0xaa, 0xed, 0x7b, 0x1c, 0x3d, 0x2e, 0xa9, 0x37, 0x33, 0x91, 0xec, 0x02, 0x44, 0x53,
+	0x1a, 0xa2, 0x6c, 0x3f, 0xd9, 0x58, 0x75, 0xa1, 0x49, 0x1a, 0xd0, 0xf8, 0x0e, 0xaa, 0x02, 0xc5,
+	0xd0, 0x59, 0xc0, 0xb0, 0xf0, 0x50, 0xf5, 0x3e, 0x0f, 0x35, 0x66, 0xd0, 0x94, 0x94, 0xc6, 0xb3,
+	0x19, 0x0d, 0xaf, 0x16, 0xae, 0xd9, 0xcd, 0xd2, 0x22, 0x00, 0xca, 0x17, 0x4a, 0xba, 0xd7, 0x4d,
+	0x08, 0x6d, 0x05, 0x13, 0xc6, 0xdf, 0x2a, 0xb4, 0xc5, 0x7d, 0x26, 0xf2, 0x90, 0xe1, 0x05, 0x75,
+	0xdf, 0x79, 0x61, 0x0d, 0x97, 0x14, 0xd6, 0xd3, 0x15, 0x85, 0x95, 0xa3, 0x7a, 0xa7, 0xc5, 0x35,
+	0x5d, 0x57, 0x5b, 0x77, 0x10, 0xfe, 0x1e, 0x54, 0xfc, 0xf3, 0xf3, 0x08, 0x79, 0xca, 0x71, 0x2a,
+	0x25, 0xbd, 0x1f, 0x84, 0xbe, 0x7f, 0x2e, 0x6a, 0xa8, 0x66, 0x4a, 0xc1, 0xf8, 0x55, 0x81, 0xed,
+	0xdb, 0x0f, 0x1b, 0xf1, 0x10, 0xe9, 0x6c, 0xee, 0x16, 0x65, 0xfe, 0x96, 0x1b, 0x05, 0xab, 0xae,
+	0x2b, 0x58, 0x6d, 0xae, 0x60, 0xc9, 0x87, 0xd0, 0x94, 0xe6, 0x29, 0x8d, 0xa6, 0x18, 0xe9, 0xa5,
+	0x9e, 0xd6, 0x6f, 0x9a, 0x0d, 0xa1, 0xfb, 0x4a, 0xa8, 0x0a, 0xa4, 0x65, 0x61, 0x4b, 0x91, 0x3a,
+	0xd0, 0x90, 0x9c, 0xa0, 0x8b, 0x1c, 0xef, 0xae, 0xf6, 0x7b, 0x31, 0x6f, 0x0c, 0x80, 0xdc, 0xb8,
+	0x25, 0xab, 0x79, 0x1d, 0xaa, 0x33, 0xe9, 0x9f, 0xde, 0x98, 0x89, 0xc6, 0x29, 0x3c, 0x28, 0x06,
+	0xce, 0x9d, 0xee, 0xe4, 0x31, 0xb4, 0xc5, 0xcc, 0xb5, 0x42, 0xb4, 0x91, 0x5d, 0xa0, 0x93, 0xe6,
+	0xaf, 0x25, 0xb4, 0x66, 0xaa, 0x34, 0x00, 0x6a, 0x23, 0x4e, 0x79, 0x64, 0xe2, 0xf7, 0xc6, 0x3f,
+	0x0a, 0x34, 0x12, 0x21, 0x0b, 0xbe, 0x0b, 0x10, 0x47, 0xe8, 0x58, 0x51, 0x40, 0xed, 0x3c, 0x31,
+	0x89, 0x66, 0x94, 0x28, 0xc8, 0xc7, 0xb0, 0x49, 0x2f, 0x28, 0x73, 0xe9, 0xd8, 0xc5, 0xd4, 0x47,
+	0x5e, 0xd1, 0xce, 0xd5, 0xd2, 0xf1, 0x31, 0xb4, 0x45, 0x9c, 0xbc, 0x23, 0xd2, 0x5c, 0xb5, 0x12,
+	0x6d, 0xde, 0x3b, 0xe4, 0x13, 0xd8, 0x2a, 0xe2, 0x15, 0xbe, 0x72, 0x10, 0x91, 0xdc, 0x54, 0x1c,
+	0xf8, 0x02, 0x20, 0xdf, 0x34, 0x22, 0x91, 0xc2, 0xc6, 0x7e, 0x6f, 0x49, 0x16, 0x32, 0x1f, 0xf9,
+	0xd0, 0x1b, 0x67, 0x8c, 0x9f, 0x55, 0x68, 0xdf, 0x36, 0xdf, 0x67, 0xc5, 0xd1, 0xa1, 0x4a, 0x1d,
+	0x27, 0xc4, 0x28, 0x12, 0x04, 0xd4, 0xcd, 0x4c, 0x4c, 0x2c, 0x3c, 0x8c, 0x23, 0x8e, 0xf2, 0x77,
+	0xae, 0x66, 0x66, 0xe2, 0x1c, 0xb7, 0xa5, 0x79, 0x6e, 0x17, 0x29, 0x2b, 0x2f, 0xa3, 0x2c, 0x49,
+	0x81, 0x9c, 0x2e, 0x79, 0xa8, 0x4a, 0x9a, 0x82, 0x4c, 0x2d, 0xe3, 0x25, 0xdc, 0xe6, 0x8e, 0x45,
+	0xd0, 0x6a, 0xca, 0x6d, 0x66, 0xca, 0x23, 0x1b, 0x17, 0xd0, 0xba, 0x55, 0xbd, 0xf9, 0x12, 0xa1,
+	0x14, 0x4b, 0xc4, 0xed, 0xb5, 0x43, 0x9d, 0x5f, 0x3b, 0x92, 0xbe, 0x8e, 0xc7, 0x2e, 0xb3, 0xc5,
+	0xaa, 0x23, 0x7f, 0x6c, 0xea, 0x52, 0xf3, 0x12, 0xaf, 0x92, 0xde, 0xb3, 0xa7, 0x94, 0x79, 0x69,
+	0x5f, 0x4a, 0x61, 0xff, 0x17, 0x0d, 0x3a, 0x45, 0x99, 0x9b, 0x22, 0x83, 0xe4, 0x10, 0xca, 0x42,
+	0x47, 0x76, 0x56, 0xcc, 0xca, 0xa1, 0xd3, 0xfd, 0x60, 0x85, 0x29, 0x2d, 0x66, 0x63, 0x83, 0xbc,
+	0x82, 0x5a, 0x3a, 0x7a, 0x90, 0xf4, 0xee, 0x1a, 0xba, 0xdd, 0x27, 0x77, 0x79, 0xc8, 0xe9, 0x65,
+	0x6c, 0xf4, 0x95, 0x4f, 0x15, 0x72, 0x0c, 0x65, 0xb9, 0x9a, 0x3c, 0x5c, 0xb7, 0x26, 0x74, 0x1f,
+	0xad, 0xb3, 0xe6, 0x48, 0xfb, 0x0a, 0xf9, 0x06, 0x2a, 0xe9, 0xf4, 0xd9, 0x5d, 0x71, 0x44, 0x9a,
+	0xbb, 0x1f, 0xad, 0x35, 0x17, 0x8f, 0x3f, 0x84, 0xb2, 0xac, 0xef, 0xee, 0x92, 0x06, 0x49, 0x07,
+	0x40, 0x77, 0x77, 0xb9, 0x2d, 0x8f, 0x72, 0x50, 0x7a, 0xa5, 0x06, 0xe3, 0x71, 0x45, 0xfc, 0xc9,
+	0xf8, 0xec, 0xbf, 0x01, 0x00, 0xc2, 0xd3, 0xd6, 0xd3, 0x96, 0x0c, 0x00, 0x00,
 }
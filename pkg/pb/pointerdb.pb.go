@@ -28,21 +28,24 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type RedundancyScheme_SchemeType int32
 
 const (
-	RedundancyScheme_RS RedundancyScheme_SchemeType = 0
+	RedundancyScheme_RS          RedundancyScheme_SchemeType = 0
+	RedundancyScheme_REPLICATION RedundancyScheme_SchemeType = 1
 )
 
 var RedundancyScheme_SchemeType_name = map[int32]string{
 	0: "RS",
+	1: "REPLICATION",
 }
 var RedundancyScheme_SchemeType_value = map[string]int32{
-	"RS": 0,
+	"RS":          0,
+	"REPLICATION": 1,
 }
 
 func (x RedundancyScheme_SchemeType) String() string {
 	return proto.EnumName(RedundancyScheme_SchemeType_name, int32(x))
 }
 func (RedundancyScheme_SchemeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{0, 0}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{0, 0}
 }
 
 type Pointer_DataType int32
@@ -65,12 +68,12 @@ func (x Pointer_DataType) String() string {
 	return proto.EnumName(Pointer_DataType_name, int32(x))
 }
 func (Pointer_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{3, 0}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{3, 0}
 }
 
 type RedundancyScheme struct {
 	Type RedundancyScheme_SchemeType `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.RedundancyScheme_SchemeType" json:"type,omitempty"`
-	// these values apply to RS encoding
+	// these values apply to all the scheme types
 	MinReq               int32    `protobuf:"varint,2,opt,name=min_req,json=minReq,proto3" json:"min_req,omitempty"`
 	Total                int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	RepairThreshold      int32    `protobuf:"varint,4,opt,name=repair_threshold,json=repairThreshold,proto3" json:"repair_threshold,omitempty"`
@@ -85,7 +88,7 @@ func (m *RedundancyScheme) Reset()         { *m = RedundancyScheme{} }
 func (m *RedundancyScheme) String() string { return proto.CompactTextString(m) }
 func (*RedundancyScheme) ProtoMessage()    {}
 func (*RedundancyScheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{0}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{0}
 }
 func (m *RedundancyScheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedundancyScheme.Unmarshal(m, b)
@@ -150,6 +153,7 @@ func (m *RedundancyScheme) GetErasureShareSize() int32 {
 type RemotePiece struct {
 	PieceNum             int32    `protobuf:"varint,1,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
 	NodeId               NodeID   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Hash                 []byte   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -159,7 +163,7 @@ func (m *RemotePiece) Reset()         { *m = RemotePiece{} }
 func (m *RemotePiece) String() string { return proto.CompactTextString(m) }
 func (*RemotePiece) ProtoMessage()    {}
 func (*RemotePiece) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{1}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{1}
 }
 func (m *RemotePiece) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePiece.Unmarshal(m, b)
@@ -186,22 +190,37 @@ func (m *RemotePiece) GetPieceNum() int32 {
 	return 0
 }
 
+func (m *RemotePiece) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 type RemoteSegment struct {
 	Redundancy *RedundancyScheme `protobuf:"bytes,1,opt,name=redundancy" json:"redundancy,omitempty"`
 	// TODO: may want to use customtype and fixed-length byte slice
-	PieceId              string         `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
-	RemotePieces         []*RemotePiece `protobuf:"bytes,3,rep,name=remote_pieces,json=remotePieces" json:"remote_pieces,omitempty"`
-	MerkleRoot           []byte         `protobuf:"bytes,4,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	PieceId      string         `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	RemotePieces []*RemotePiece `protobuf:"bytes,3,rep,name=remote_pieces,json=remotePieces" json:"remote_pieces,omitempty"`
+	MerkleRoot   []byte         `protobuf:"bytes,4,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	// shared_paths are the paths of the other pointers referencing the same
+	// pieces (e.g. after a server-side copy), the pieces being deleted only
+	// with the last of them
+	SharedPaths []string `protobuf:"bytes,5,rep,name=shared_paths,json=sharedPaths" json:"shared_paths,omitempty"`
+	// piece_hashes holds the hashes of all of the pieces by piece number,
+	// empty for the pieces never stored, so they are kept when pieces are
+	// dropped from or moved between the remote pieces
+	PieceHashes          [][]byte `protobuf:"bytes,6,rep,name=piece_hashes,json=pieceHashes" json:"piece_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RemoteSegment) Reset()         { *m = RemoteSegment{} }
 func (m *RemoteSegment) String() string { return proto.CompactTextString(m) }
 func (*RemoteSegment) ProtoMessage()    {}
 func (*RemoteSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{2}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{2}
 }
 func (m *RemoteSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteSegment.Unmarshal(m, b)
@@ -249,6 +268,20 @@ func (m *RemoteSegment) GetMerkleRoot() []byte {
 	return nil
 }
 
+func (m *RemoteSegment) GetSharedPaths() []string {
+	if m != nil {
+		return m.SharedPaths
+	}
+	return nil
+}
+
+func (m *RemoteSegment) GetPieceHashes() [][]byte {
+	if m != nil {
+		return m.PieceHashes
+	}
+	return nil
+}
+
 type Pointer struct {
 	Type                 Pointer_DataType     `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.Pointer_DataType" json:"type,omitempty"`
 	InlineSegment        []byte               `protobuf:"bytes,3,opt,name=inline_segment,json=inlineSegment,proto3" json:"inline_segment,omitempty"`
@@ -266,7 +299,7 @@ func (m *Pointer) Reset()         { *m = Pointer{} }
 func (m *Pointer) String() string { return proto.CompactTextString(m) }
 func (*Pointer) ProtoMessage()    {}
 func (*Pointer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{3}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{3}
 }
 func (m *Pointer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pointer.Unmarshal(m, b)
@@ -348,7 +381,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{4}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{4}
 }
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutRequest.Unmarshal(m, b)
@@ -394,7 +427,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{5}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{5}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -438,7 +471,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{6}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{6}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -511,7 +544,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{7}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{7}
 }
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutResponse.Unmarshal(m, b)
@@ -546,7 +579,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{8}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{8}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -607,7 +640,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{9}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{9}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -654,7 +687,7 @@ func (m *ListResponse_Item) Reset()         { *m = ListResponse_Item{} }
 func (m *ListResponse_Item) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Item) ProtoMessage()    {}
 func (*ListResponse_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{9, 0}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{9, 0}
 }
 func (m *ListResponse_Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse_Item.Unmarshal(m, b)
@@ -706,7 +739,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{10}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{10}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -744,7 +777,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{11}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{11}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
@@ -779,7 +812,7 @@ func (m *IterateRequest) Reset()         { *m = IterateRequest{} }
 func (m *IterateRequest) String() string { return proto.CompactTextString(m) }
 func (*IterateRequest) ProtoMessage()    {}
 func (*IterateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{12}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{12}
 }
 func (m *IterateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IterateRequest.Unmarshal(m, b)
@@ -838,7 +871,7 @@ func (m *PayerBandwidthAllocationRequest) Reset()         { *m = PayerBandwidthA
 func (m *PayerBandwidthAllocationRequest) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationRequest) ProtoMessage()    {}
 func (*PayerBandwidthAllocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{13}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{13}
 }
 func (m *PayerBandwidthAllocationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationRequest.Unmarshal(m, b)
@@ -876,7 +909,7 @@ func (m *PayerBandwidthAllocationResponse) Reset()         { *m = PayerBandwidth
 func (m *PayerBandwidthAllocationResponse) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationResponse) ProtoMessage()    {}
 func (*PayerBandwidthAllocationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_37763013b07c9a27, []int{14}
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{14}
 }
 func (m *PayerBandwidthAllocationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationResponse.Unmarshal(m, b)
@@ -903,6 +936,110 @@ func (m *PayerBandwidthAllocationResponse) GetPba() *PayerBandwidthAllocation {
 	return nil
 }
 
+// UsageRequest is a request message for the Usage rpc call
+type UsageRequest struct {
+	// bucket limits the usage to a single bucket if set
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsageRequest) Reset()         { *m = UsageRequest{} }
+func (m *UsageRequest) String() string { return proto.CompactTextString(m) }
+func (*UsageRequest) ProtoMessage()    {}
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{15}
+}
+func (m *UsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageRequest.Unmarshal(m, b)
+}
+func (m *UsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageRequest.Marshal(b, m, deterministic)
+}
+func (dst *UsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageRequest.Merge(dst, src)
+}
+func (m *UsageRequest) XXX_Size() int {
+	return xxx_messageInfo_UsageRequest.Size(m)
+}
+func (m *UsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsageRequest proto.InternalMessageInfo
+
+func (m *UsageRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+// UsageResponse is a response message for the Usage rpc call
+type UsageResponse struct {
+	StoredBytes int64 `protobuf:"varint,1,opt,name=stored_bytes,json=storedBytes,proto3" json:"stored_bytes,omitempty"`
+	Objects     int64 `protobuf:"varint,2,opt,name=objects,proto3" json:"objects,omitempty"`
+	Segments    int64 `protobuf:"varint,3,opt,name=segments,proto3" json:"segments,omitempty"`
+	// egress_bytes is the bandwidth used for downloads by the uplink, in all buckets
+	EgressBytes          int64    `protobuf:"varint,4,opt,name=egress_bytes,json=egressBytes,proto3" json:"egress_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsageResponse) Reset()         { *m = UsageResponse{} }
+func (m *UsageResponse) String() string { return proto.CompactTextString(m) }
+func (*UsageResponse) ProtoMessage()    {}
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_3813278fbc22257b, []int{16}
+}
+func (m *UsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageResponse.Unmarshal(m, b)
+}
+func (m *UsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageResponse.Marshal(b, m, deterministic)
+}
+func (dst *UsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageResponse.Merge(dst, src)
+}
+func (m *UsageResponse) XXX_Size() int {
+	return xxx_messageInfo_UsageResponse.Size(m)
+}
+func (m *UsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsageResponse proto.InternalMessageInfo
+
+func (m *UsageResponse) GetStoredBytes() int64 {
+	if m != nil {
+		return m.StoredBytes
+	}
+	return 0
+}
+
+func (m *UsageResponse) GetObjects() int64 {
+	if m != nil {
+		return m.Objects
+	}
+	return 0
+}
+
+func (m *UsageResponse) GetSegments() int64 {
+	if m != nil {
+		return m.Segments
+	}
+	return 0
+}
+
+func (m *UsageResponse) GetEgressBytes() int64 {
+	if m != nil {
+		return m.EgressBytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*RedundancyScheme)(nil), "pointerdb.RedundancyScheme")
 	proto.RegisterType((*RemotePiece)(nil), "pointerdb.RemotePiece")
@@ -920,6 +1057,8 @@ func init() {
 	proto.RegisterType((*IterateRequest)(nil), "pointerdb.IterateRequest")
 	proto.RegisterType((*PayerBandwidthAllocationRequest)(nil), "pointerdb.PayerBandwidthAllocationRequest")
 	proto.RegisterType((*PayerBandwidthAllocationResponse)(nil), "pointerdb.PayerBandwidthAllocationResponse")
+	proto.RegisterType((*UsageRequest)(nil), "pointerdb.UsageRequest")
+	proto.RegisterType((*UsageResponse)(nil), "pointerdb.UsageResponse")
 	proto.RegisterEnum("pointerdb.RedundancyScheme_SchemeType", RedundancyScheme_SchemeType_name, RedundancyScheme_SchemeType_value)
 	proto.RegisterEnum("pointerdb.Pointer_DataType", Pointer_DataType_name, Pointer_DataType_value)
 }
@@ -946,6 +1085,8 @@ type PointerDBClient interface {
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
 	PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error)
+	// Usage returns the storage and bandwidth usage of all buckets or a single bucket
+	Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
 }
 
 type pointerDBClient struct {
@@ -1001,6 +1142,15 @@ func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *Paye
 	return out, nil
 }
 
+func (c *pointerDBClient) Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Usage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PointerDBServer is the server API for PointerDB service.
 type PointerDBServer interface {
 	// Put formats and hands off a file path to be saved to boltdb
@@ -1013,6 +1163,8 @@ type PointerDBServer interface {
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
 	PayerBandwidthAllocation(context.Context, *PayerBandwidthAllocationRequest) (*PayerBandwidthAllocationResponse, error)
+	// Usage returns the storage and bandwidth usage of all buckets or a single bucket
+	Usage(context.Context, *UsageRequest) (*UsageResponse, error)
 }
 
 func RegisterPointerDBServer(s *grpc.Server, srv PointerDBServer) {
@@ -1109,6 +1261,24 @@ func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/Usage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).Usage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PointerDB_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pointerdb.PointerDB",
 	HandlerType: (*PointerDBServer)(nil),
@@ -1133,82 +1303,95 @@ var _PointerDB_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PayerBandwidthAllocation",
 			Handler:    _PointerDB_PayerBandwidthAllocation_Handler,
 		},
+		{
+			MethodName: "Usage",
+			Handler:    _PointerDB_Usage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pointerdb.proto",
 }
 
-func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_37763013b07c9a27) }
-
-var fileDescriptor_pointerdb_37763013b07c9a27 = []byte{
-	// 1092 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5d, 0x6f, 0x1b, 0x45,
-	0x17, 0xae, 0xbf, 0xe3, 0xb3, 0x76, 0xea, 0x77, 0xd4, 0x37, 0xdd, 0xba, 0x45, 0x09, 0x8b, 0x80,
-	0xd2, 0x56, 0x5b, 0x30, 0x95, 0x90, 0x28, 0x08, 0x35, 0x24, 0x54, 0x96, 0xda, 0x10, 0x8d, 0x73,
-	0xc5, 0xcd, 0x32, 0xf1, 0x1e, 0xdb, 0x23, 0xbc, 0x1f, 0x9d, 0x99, 0x2d, 0x4d, 0xff, 0x09, 0xff,
-	0x84, 0x1b, 0x2e, 0x91, 0xf8, 0x0d, 0x5c, 0xf4, 0x02, 0xf1, 0x33, 0xb8, 0x40, 0xf3, 0xb1, 0xf6,
-	0xba, 0x21, 0x69, 0x05, 0x37, 0xc9, 0x9e, 0x73, 0x9e, 0x73, 0xe6, 0x9c, 0xf3, 0x3c, 0x33, 0x86,
-	0xab, 0x79, 0xc6, 0x53, 0x85, 0x22, 0x3e, 0x0d, 0x73, 0x91, 0xa9, 0x8c, 0x74, 0x57, 0x8e, 0xe1,
-	0xee, 0x3c, 0xcb, 0xe6, 0x4b, 0xbc, 0x6f, 0x02, 0xa7, 0xc5, 0xec, 0xbe, 0xe2, 0x09, 0x4a, 0xc5,
-	0x92, 0xdc, 0x62, 0x87, 0x30, 0xcf, 0xe6, 0x59, 0xf9, 0x9d, 0x66, 0x31, 0xba, 0xef, 0x41, 0xce,
-	0x71, 0x8a, 0x52, 0x65, 0xc2, 0x79, 0x82, 0x9f, 0xea, 0x30, 0xa0, 0x18, 0x17, 0x69, 0xcc, 0xd2,
-	0xe9, 0xd9, 0x64, 0xba, 0xc0, 0x04, 0xc9, 0xe7, 0xd0, 0x54, 0x67, 0x39, 0xfa, 0xb5, 0xbd, 0xda,
-	0xed, 0xed, 0xd1, 0x07, 0xe1, 0xba, 0x95, 0xd7, 0xa1, 0xa1, 0xfd, 0x77, 0x72, 0x96, 0x23, 0x35,
-	0x39, 0xe4, 0x3a, 0x74, 0x12, 0x9e, 0x46, 0x02, 0x9f, 0xf9, 0xf5, 0xbd, 0xda, 0xed, 0x16, 0x6d,
-	0x27, 0x3c, 0xa5, 0xf8, 0x8c, 0x5c, 0x83, 0x96, 0xca, 0x14, 0x5b, 0xfa, 0x0d, 0xe3, 0xb6, 0x06,
-	0xf9, 0x08, 0x06, 0x02, 0x73, 0xc6, 0x45, 0xa4, 0x16, 0x02, 0xe5, 0x22, 0x5b, 0xc6, 0x7e, 0xd3,
-	0x00, 0xae, 0x5a, 0xff, 0x49, 0xe9, 0x26, 0x77, 0xe1, 0x7f, 0xb2, 0x98, 0x4e, 0x51, 0xca, 0x0a,
-	0xb6, 0x65, 0xb0, 0x03, 0x17, 0x58, 0x83, 0xef, 0x01, 0x41, 0xc1, 0x64, 0x21, 0x30, 0x92, 0x0b,
-	0xa6, 0xff, 0xf2, 0x97, 0xe8, 0xb7, 0x2d, 0xda, 0x45, 0x26, 0x3a, 0x30, 0xe1, 0x2f, 0x31, 0xb8,
-	0x06, 0xb0, 0x1e, 0x84, 0xb4, 0xa1, 0x4e, 0x27, 0x83, 0x2b, 0xc1, 0x04, 0x3c, 0x8a, 0x49, 0xa6,
-	0xf0, 0x58, 0x6f, 0x8d, 0xdc, 0x84, 0xae, 0x59, 0x5f, 0x94, 0x16, 0x89, 0x59, 0x4d, 0x8b, 0x6e,
-	0x19, 0xc7, 0x51, 0x91, 0x90, 0x0f, 0xa1, 0xa3, 0xf7, 0x1c, 0xf1, 0xd8, 0x8c, 0xdd, 0xdb, 0xdf,
-	0xfe, 0xed, 0xd5, 0xee, 0x95, 0xdf, 0x5f, 0xed, 0xb6, 0x8f, 0xb2, 0x18, 0xc7, 0x07, 0xb4, 0xad,
-	0xc3, 0xe3, 0x38, 0xf8, 0xb5, 0x06, 0x7d, 0x5b, 0x75, 0x82, 0xf3, 0x04, 0x53, 0x45, 0x1e, 0x02,
-	0x88, 0xd5, 0x5a, 0x4d, 0x61, 0x6f, 0x74, 0xf3, 0x92, 0x9d, 0xd3, 0x0a, 0x9c, 0xdc, 0x00, 0xdb,
-	0x43, 0x79, 0x70, 0x97, 0x76, 0x8c, 0x3d, 0x8e, 0xc9, 0x43, 0xe8, 0x0b, 0x73, 0x50, 0x64, 0x59,
-	0xf7, 0x1b, 0x7b, 0x8d, 0xdb, 0xde, 0x68, 0x67, 0xa3, 0xf4, 0x6a, 0x3c, 0xda, 0x13, 0x6b, 0x43,
-	0x92, 0x5d, 0xf0, 0x12, 0x14, 0x3f, 0x2c, 0x31, 0x12, 0x59, 0xa6, 0x0c, 0x25, 0x3d, 0x0a, 0xd6,
-	0x45, 0xb3, 0x4c, 0x05, 0x7f, 0xd5, 0xa1, 0x73, 0x6c, 0x0b, 0x91, 0xfb, 0x1b, 0x7a, 0xa9, 0xf6,
-	0xee, 0x10, 0xe1, 0x01, 0x53, 0xac, 0x22, 0x92, 0xf7, 0x61, 0x9b, 0xa7, 0x4b, 0x9e, 0x62, 0x24,
-	0xed, 0x12, 0x8c, 0x28, 0x7a, 0xb4, 0x6f, 0xbd, 0xe5, 0x66, 0x3e, 0x86, 0xb6, 0x6d, 0xca, 0x9c,
-	0xef, 0x8d, 0xfc, 0x73, 0xad, 0x3b, 0x24, 0x75, 0x38, 0xf2, 0x2e, 0xf4, 0x5c, 0x45, 0x4b, 0xb8,
-	0x96, 0x47, 0x83, 0x7a, 0xce, 0xa7, 0xb9, 0x26, 0x5f, 0x41, 0x7f, 0x2a, 0x90, 0x29, 0x9e, 0xa5,
-	0x51, 0xcc, 0x94, 0x15, 0x85, 0x37, 0x1a, 0x86, 0xf6, 0x52, 0x85, 0xe5, 0xa5, 0x0a, 0x4f, 0xca,
-	0x4b, 0x45, 0x7b, 0x65, 0xc2, 0x01, 0x53, 0x48, 0xbe, 0x86, 0xab, 0xf8, 0x22, 0xe7, 0xa2, 0x52,
-	0xa2, 0xf3, 0xc6, 0x12, 0xdb, 0xeb, 0x14, 0x53, 0x64, 0x08, 0x5b, 0x09, 0x2a, 0x16, 0x33, 0xc5,
-	0xfc, 0x2d, 0x33, 0xfb, 0xca, 0x0e, 0x02, 0xd8, 0x2a, 0xf7, 0x45, 0x00, 0xda, 0xe3, 0xa3, 0x27,
-	0xe3, 0xa3, 0xc3, 0xc1, 0x15, 0xfd, 0x4d, 0x0f, 0x9f, 0x7e, 0x7b, 0x72, 0x38, 0xa8, 0x05, 0x47,
-	0x00, 0xc7, 0x85, 0xa2, 0xf8, 0xac, 0x40, 0xa9, 0x08, 0x81, 0x66, 0xce, 0xd4, 0xc2, 0x10, 0xd0,
-	0xa5, 0xe6, 0x9b, 0xdc, 0x83, 0x8e, 0xdb, 0x96, 0x11, 0x86, 0x37, 0x22, 0xe7, 0x79, 0xa1, 0x25,
-	0x24, 0xd8, 0x03, 0x78, 0x8c, 0x97, 0xd5, 0x0b, 0x7e, 0xae, 0x81, 0xf7, 0x84, 0xcb, 0x15, 0x66,
-	0x07, 0xda, 0xb9, 0xc0, 0x19, 0x7f, 0xe1, 0x50, 0xce, 0xd2, 0xca, 0x91, 0x8a, 0x09, 0x15, 0xb1,
-	0x59, 0x79, 0x76, 0x97, 0x82, 0x71, 0x3d, 0xd2, 0x1e, 0xf2, 0x0e, 0x00, 0xa6, 0x71, 0x74, 0x8a,
-	0xb3, 0x4c, 0xa0, 0x21, 0xbe, 0x4b, 0xbb, 0x98, 0xc6, 0xfb, 0xc6, 0x41, 0x6e, 0x41, 0x57, 0xe0,
-	0xb4, 0x10, 0x92, 0x3f, 0xb7, 0xbc, 0x6f, 0xd1, 0xb5, 0x43, 0xbf, 0x22, 0x4b, 0x9e, 0x70, 0xe5,
-	0x2e, 0xbe, 0x35, 0x74, 0x49, 0xbd, 0xbd, 0x68, 0xb6, 0x64, 0x73, 0x69, 0x08, 0xed, 0xd0, 0xae,
-	0xf6, 0x7c, 0xa3, 0x1d, 0x41, 0x1f, 0x3c, 0xb3, 0x2c, 0x99, 0x67, 0xa9, 0xc4, 0xe0, 0x8f, 0x1a,
-	0x78, 0x66, 0x58, 0x6b, 0x57, 0x37, 0x55, 0x7b, 0xe3, 0xa6, 0xc8, 0x1e, 0xb4, 0xf4, 0x55, 0x96,
-	0x7e, 0xdd, 0x5c, 0x27, 0x08, 0xcd, 0xfb, 0xaa, 0x6f, 0x39, 0xb5, 0x01, 0xf2, 0x05, 0x34, 0xf2,
-	0x53, 0x66, 0x26, 0xf3, 0x46, 0x77, 0xc2, 0xf5, 0x9b, 0x2b, 0xb2, 0x42, 0xa1, 0x0c, 0x8f, 0xd9,
-	0x19, 0x8a, 0x7d, 0x96, 0xc6, 0x3f, 0xf2, 0x58, 0x2d, 0x1e, 0x2d, 0x97, 0xd9, 0xd4, 0x08, 0x83,
-	0xea, 0x34, 0x72, 0x08, 0x7d, 0x56, 0xa8, 0x45, 0x26, 0xf8, 0x4b, 0xe3, 0x75, 0xda, 0xdf, 0x3d,
-	0x5f, 0x67, 0xc2, 0xe7, 0x29, 0xc6, 0x4f, 0x51, 0x4a, 0x36, 0x47, 0xba, 0x99, 0x15, 0xfc, 0x52,
-	0x83, 0x9e, 0xa5, 0xcb, 0x4d, 0x39, 0x82, 0x16, 0x57, 0x98, 0x48, 0xbf, 0x66, 0xfa, 0xbe, 0x55,
-	0x99, 0xb1, 0x8a, 0x0b, 0xc7, 0x0a, 0x13, 0x6a, 0xa1, 0x5a, 0x07, 0x89, 0x26, 0xa9, 0x6e, 0x68,
-	0x30, 0xdf, 0x43, 0x84, 0xa6, 0x86, 0xfc, 0x77, 0xcd, 0xe9, 0x07, 0x95, 0xcb, 0xc8, 0x89, 0xa8,
-	0x61, 0x8e, 0xd8, 0xe2, 0xf2, 0xd8, 0xd8, 0xc1, 0x7b, 0xd0, 0x3f, 0xc0, 0x25, 0x2a, 0xbc, 0x4c,
-	0x93, 0x03, 0xd8, 0x2e, 0x41, 0x8e, 0x5b, 0x01, 0xdb, 0x63, 0x85, 0x82, 0xad, 0xf3, 0x2e, 0xd2,
-	0xe9, 0x35, 0x68, 0xcd, 0xb8, 0x90, 0xca, 0x29, 0xd4, 0x1a, 0xc4, 0x87, 0x8e, 0x15, 0x1b, 0xba,
-	0x8e, 0x4a, 0xd3, 0x46, 0x9e, 0xa3, 0x8e, 0x34, 0xcb, 0x88, 0x31, 0x83, 0x25, 0xec, 0x5e, 0x48,
-	0xa9, 0x6b, 0x62, 0x0c, 0x6d, 0x36, 0x35, 0x6c, 0xda, 0x37, 0xf2, 0x93, 0xb7, 0x57, 0x45, 0xf8,
-	0xc8, 0x24, 0x52, 0x57, 0x20, 0xf8, 0x1e, 0xf6, 0x2e, 0x3e, 0xcd, 0x71, 0xed, 0x14, 0x58, 0xfb,
-	0x57, 0x0a, 0x1c, 0xfd, 0x59, 0x87, 0xae, 0x23, 0xeb, 0x60, 0x9f, 0x3c, 0x80, 0xc6, 0x71, 0xa1,
-	0xc8, 0xff, 0xab, 0x4c, 0xae, 0x5e, 0x9e, 0xe1, 0xce, 0xeb, 0x6e, 0xd7, 0xc1, 0x03, 0x68, 0x3c,
-	0xc6, 0xcd, 0xac, 0xf5, 0xfb, 0xb2, 0x91, 0x55, 0xbd, 0x89, 0x9f, 0x41, 0x53, 0x6b, 0x91, 0xec,
-	0x9c, 0x13, 0xa7, 0xcd, 0xbb, 0x7e, 0x81, 0x68, 0xc9, 0x97, 0xd0, 0xb6, 0x42, 0x20, 0xd5, 0xdf,
-	0x88, 0x0d, 0x01, 0x0d, 0x6f, 0xfc, 0x43, 0xc4, 0xa5, 0x4b, 0xf0, 0x2f, 0x5a, 0x09, 0xb9, 0x53,
-	0x9d, 0xf0, 0x72, 0x9a, 0x87, 0x77, 0xdf, 0x0a, 0x6b, 0x0f, 0xdd, 0x6f, 0x7e, 0x57, 0xcf, 0x4f,
-	0x4f, 0xdb, 0xe6, 0xc7, 0xe2, 0xd3, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x56, 0x48, 0xf9,
-	0xf0, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_3813278fbc22257b) }
+
+var fileDescriptor_pointerdb_3813278fbc22257b = []byte{
+	// 1242 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x72, 0x1b, 0x45,
+	0x10, 0x8e, 0xfe, 0xad, 0x5e, 0xc9, 0x16, 0x53, 0xc1, 0xd9, 0x28, 0xa1, 0x2c, 0x96, 0x4a, 0x30,
+	0x49, 0x4a, 0x01, 0x91, 0x2a, 0xaa, 0x12, 0x28, 0xca, 0x8e, 0x8d, 0x51, 0x55, 0xe2, 0xa8, 0xc6,
+	0xe6, 0xc2, 0x65, 0x19, 0x69, 0x5b, 0xd2, 0x12, 0xed, 0x4f, 0x66, 0x66, 0x43, 0x9c, 0x57, 0xc8,
+	0x4b, 0x70, 0xe6, 0x09, 0xb8, 0x70, 0xe7, 0x19, 0x38, 0xe4, 0xc0, 0x73, 0x70, 0xa0, 0xe6, 0x67,
+	0xa5, 0x75, 0x1c, 0x3b, 0x29, 0xb8, 0x24, 0xdb, 0x5f, 0xff, 0xcc, 0xf4, 0xd7, 0xdf, 0xb4, 0x0c,
+	0x1b, 0x69, 0x12, 0xc6, 0x12, 0x79, 0x30, 0xee, 0xa7, 0x3c, 0x91, 0x09, 0x69, 0x2e, 0x81, 0xee,
+	0xd6, 0x2c, 0x49, 0x66, 0x0b, 0xbc, 0xab, 0x1d, 0xe3, 0x6c, 0x7a, 0x57, 0x86, 0x11, 0x0a, 0xc9,
+	0xa2, 0xd4, 0xc4, 0x76, 0x61, 0x96, 0xcc, 0x92, 0xfc, 0x3b, 0x4e, 0x02, 0xb4, 0xdf, 0x9d, 0x34,
+	0xc4, 0x09, 0x0a, 0x99, 0x70, 0x8b, 0x78, 0xbf, 0x95, 0xa1, 0x43, 0x31, 0xc8, 0xe2, 0x80, 0xc5,
+	0x93, 0x93, 0xa3, 0xc9, 0x1c, 0x23, 0x24, 0xf7, 0xa1, 0x2a, 0x4f, 0x52, 0x74, 0x4b, 0xbd, 0xd2,
+	0xf6, 0xfa, 0xe0, 0x66, 0x7f, 0x75, 0x95, 0x37, 0x43, 0xfb, 0xe6, 0xbf, 0xe3, 0x93, 0x14, 0xa9,
+	0xce, 0x21, 0x57, 0xa0, 0x11, 0x85, 0xb1, 0xcf, 0xf1, 0x99, 0x5b, 0xee, 0x95, 0xb6, 0x6b, 0xb4,
+	0x1e, 0x85, 0x31, 0xc5, 0x67, 0xe4, 0x32, 0xd4, 0x64, 0x22, 0xd9, 0xc2, 0xad, 0x68, 0xd8, 0x18,
+	0xe4, 0x33, 0xe8, 0x70, 0x4c, 0x59, 0xc8, 0x7d, 0x39, 0xe7, 0x28, 0xe6, 0xc9, 0x22, 0x70, 0xab,
+	0x3a, 0x60, 0xc3, 0xe0, 0xc7, 0x39, 0x4c, 0x6e, 0xc3, 0x07, 0x22, 0x9b, 0x4c, 0x50, 0x88, 0x42,
+	0x6c, 0x4d, 0xc7, 0x76, 0xac, 0x63, 0x15, 0x7c, 0x07, 0x08, 0x72, 0x26, 0x32, 0x8e, 0xbe, 0x98,
+	0x33, 0xf5, 0x6f, 0xf8, 0x12, 0xdd, 0xba, 0x89, 0xb6, 0x9e, 0x23, 0xe5, 0x38, 0x0a, 0x5f, 0xa2,
+	0x77, 0x03, 0x60, 0xd5, 0x08, 0xa9, 0x43, 0x99, 0x1e, 0x75, 0x2e, 0x91, 0x0d, 0x70, 0xe8, 0xfe,
+	0xe8, 0xd1, 0xf0, 0xe1, 0xce, 0xf1, 0xf0, 0xc9, 0x61, 0xa7, 0xe4, 0xcd, 0xc0, 0xa1, 0x18, 0x25,
+	0x12, 0x47, 0x8a, 0x46, 0x72, 0x0d, 0x9a, 0x9a, 0x4f, 0x3f, 0xce, 0x22, 0xcd, 0x55, 0x8d, 0xae,
+	0x69, 0xe0, 0x30, 0x8b, 0xc8, 0xa7, 0xd0, 0x50, 0xc4, 0xfb, 0x61, 0xa0, 0x79, 0x68, 0xed, 0xae,
+	0xff, 0xf9, 0x7a, 0xeb, 0xd2, 0x5f, 0xaf, 0xb7, 0xea, 0x87, 0x49, 0x80, 0xc3, 0x3d, 0x5a, 0x57,
+	0xee, 0x61, 0x40, 0x08, 0x54, 0xe7, 0x4c, 0xcc, 0x35, 0x2d, 0x2d, 0xaa, 0xbf, 0xbd, 0x57, 0x65,
+	0x68, 0x9b, 0x93, 0x8e, 0x70, 0x16, 0x61, 0x2c, 0xc9, 0x03, 0x00, 0xbe, 0xe4, 0x5e, 0x1f, 0xe6,
+	0x0c, 0xae, 0x5d, 0x30, 0x18, 0x5a, 0x08, 0x27, 0x57, 0xc1, 0xdc, 0x2b, 0xbf, 0x4c, 0x93, 0x36,
+	0xb4, 0x3d, 0x0c, 0xc8, 0x03, 0x68, 0x73, 0x7d, 0x90, 0xaf, 0x11, 0xe1, 0x56, 0x7a, 0x95, 0x6d,
+	0x67, 0xb0, 0x79, 0xaa, 0xf4, 0xb2, 0x65, 0xda, 0xe2, 0x2b, 0x43, 0x90, 0x2d, 0x70, 0x22, 0xe4,
+	0x4f, 0x17, 0xe8, 0xf3, 0x24, 0x91, 0x7a, 0x6e, 0x2d, 0x0a, 0x06, 0xa2, 0x49, 0x22, 0xc9, 0xc7,
+	0xd0, 0xd2, 0xec, 0x07, 0x7e, 0xca, 0xe4, 0x5c, 0xb8, 0xb5, 0x5e, 0x65, 0xbb, 0x49, 0x1d, 0x83,
+	0x8d, 0x14, 0xa4, 0x42, 0xcc, 0xdd, 0x54, 0xe3, 0x28, 0xdc, 0x7a, 0xaf, 0xb2, 0xdd, 0xa2, 0x8e,
+	0xc6, 0xbe, 0xd7, 0x90, 0xf7, 0x4f, 0x19, 0x1a, 0x23, 0x73, 0x1d, 0x72, 0xf7, 0x94, 0x34, 0x8b,
+	0x0c, 0xd8, 0x88, 0xfe, 0x1e, 0x93, 0xac, 0xa0, 0xc7, 0x1b, 0xb0, 0x1e, 0xc6, 0x8b, 0x30, 0x46,
+	0x5f, 0x18, 0x2a, 0x2d, 0xd1, 0x6d, 0x83, 0xe6, 0xfc, 0x7e, 0x0e, 0x75, 0xd3, 0x9a, 0xee, 0xc2,
+	0x19, 0xb8, 0x67, 0x08, 0xb0, 0x91, 0xd4, 0xc6, 0xe9, 0xde, 0x0c, 0x64, 0xb4, 0xa5, 0x94, 0x58,
+	0xa1, 0x8e, 0xc5, 0x94, 0xac, 0xc8, 0xb7, 0xd0, 0x9e, 0x70, 0x64, 0x32, 0x4c, 0x62, 0x3f, 0x60,
+	0xd2, 0xe8, 0xcf, 0x19, 0x74, 0xfb, 0xe6, 0xfd, 0xf6, 0xf3, 0xf7, 0xdb, 0x3f, 0xce, 0xdf, 0x2f,
+	0x6d, 0xe5, 0x09, 0x7b, 0x4c, 0x22, 0x79, 0x08, 0x1b, 0xf8, 0x22, 0x0d, 0x79, 0xa1, 0x44, 0xe3,
+	0x9d, 0x25, 0xd6, 0x57, 0x29, 0xba, 0x48, 0x17, 0xd6, 0x22, 0x94, 0x2c, 0x60, 0x92, 0xb9, 0x6b,
+	0xba, 0xf7, 0xa5, 0xed, 0x79, 0xb0, 0x96, 0xf3, 0x45, 0x00, 0xea, 0xc3, 0xc3, 0x47, 0xc3, 0xc3,
+	0xfd, 0xce, 0x25, 0xf5, 0x4d, 0xf7, 0x1f, 0x3f, 0x39, 0xde, 0xef, 0x94, 0xbc, 0x43, 0x80, 0x51,
+	0x26, 0x29, 0x3e, 0xcb, 0x50, 0x48, 0x25, 0x57, 0x35, 0x4b, 0x3d, 0x80, 0x26, 0xd5, 0xdf, 0xe4,
+	0x0e, 0x34, 0x2c, 0x5b, 0x5a, 0x5e, 0xce, 0x80, 0x9c, 0x9d, 0x0b, 0xcd, 0x43, 0xbc, 0x1e, 0xc0,
+	0x01, 0x5e, 0x54, 0xcf, 0xfb, 0xbd, 0x04, 0xce, 0xa3, 0x50, 0x2c, 0x63, 0x36, 0xa1, 0x9e, 0x72,
+	0x9c, 0x86, 0x2f, 0x6c, 0x94, 0xb5, 0x94, 0xfe, 0x84, 0x64, 0x5c, 0xfa, 0x6c, 0x9a, 0x9f, 0xdd,
+	0xa4, 0xa0, 0xa1, 0x1d, 0x85, 0x90, 0x8f, 0x00, 0x30, 0x0e, 0xfc, 0x31, 0x4e, 0x13, 0x8e, 0x7a,
+	0xf0, 0x4d, 0xda, 0xc4, 0x38, 0xd8, 0xd5, 0x00, 0xb9, 0x0e, 0x4d, 0x8e, 0x93, 0x8c, 0x8b, 0xf0,
+	0xb9, 0x99, 0xfb, 0x1a, 0x5d, 0x01, 0x6a, 0x61, 0x2d, 0xc2, 0x28, 0x94, 0x76, 0xc7, 0x18, 0x43,
+	0x95, 0x54, 0xec, 0xf9, 0xd3, 0x05, 0x9b, 0x09, 0x3d, 0xd0, 0x06, 0x6d, 0x2a, 0xe4, 0x3b, 0x05,
+	0x78, 0x6d, 0x70, 0x34, 0x59, 0x22, 0x4d, 0x62, 0x81, 0xde, 0xdf, 0x25, 0x70, 0x0e, 0x70, 0x69,
+	0x17, 0x99, 0x2a, 0xbd, 0x93, 0x29, 0xd2, 0x83, 0x9a, 0x5a, 0x12, 0xc2, 0x2d, 0xeb, 0x47, 0x09,
+	0x7d, 0x65, 0xf5, 0xd5, 0xfe, 0xa0, 0xc6, 0x41, 0xbe, 0x86, 0x4a, 0x3a, 0x66, 0xba, 0x33, 0x67,
+	0x70, 0xab, 0xbf, 0x5a, 0xef, 0x3c, 0xc9, 0x24, 0x8a, 0xfe, 0x88, 0x9d, 0x20, 0xdf, 0x65, 0x71,
+	0xf0, 0x4b, 0x18, 0xc8, 0xf9, 0xce, 0x62, 0x91, 0x4c, 0xb4, 0x30, 0xa8, 0x4a, 0x23, 0xfb, 0xd0,
+	0x66, 0x99, 0x9c, 0x27, 0x3c, 0x7c, 0xa9, 0x51, 0xab, 0xfd, 0xad, 0xb3, 0x75, 0x8e, 0xc2, 0x59,
+	0x8c, 0xc1, 0x63, 0x14, 0x82, 0xcd, 0x90, 0x9e, 0xce, 0xf2, 0xfe, 0x28, 0x41, 0xcb, 0x8c, 0xcb,
+	0x76, 0x39, 0x80, 0x5a, 0x28, 0x31, 0x12, 0x6e, 0x49, 0xdf, 0xfb, 0x7a, 0xa1, 0xc7, 0x62, 0x5c,
+	0x7f, 0x28, 0x31, 0xa2, 0x26, 0x54, 0xe9, 0x20, 0x52, 0x43, 0x2a, 0xeb, 0x31, 0xe8, 0xef, 0x2e,
+	0x42, 0x55, 0x85, 0xfc, 0x7f, 0xcd, 0xa9, 0x55, 0x1d, 0x0a, 0xdf, 0x8a, 0xa8, 0xa2, 0x8f, 0x58,
+	0x0b, 0xc5, 0x48, 0xdb, 0xde, 0x27, 0xd0, 0xde, 0xc3, 0x05, 0x4a, 0xbc, 0x48, 0x93, 0x1d, 0x58,
+	0xcf, 0x83, 0xec, 0x6c, 0x39, 0xac, 0x0f, 0x25, 0x72, 0x26, 0xf1, 0x5d, 0x3a, 0xbd, 0x0c, 0xb5,
+	0x69, 0xc8, 0x85, 0xb4, 0x0a, 0x35, 0x06, 0x71, 0xa1, 0x61, 0xc4, 0x86, 0xf6, 0x46, 0xb9, 0x69,
+	0x3c, 0xcf, 0x51, 0x79, 0xaa, 0xb9, 0x47, 0x9b, 0xde, 0x02, 0xb6, 0xce, 0x1d, 0xa9, 0xbd, 0xc4,
+	0x10, 0xea, 0x6c, 0xa2, 0xa7, 0x69, 0x76, 0xe4, 0x17, 0xef, 0xaf, 0x8a, 0xfe, 0x8e, 0x4e, 0xa4,
+	0xb6, 0x80, 0xf7, 0x13, 0xf4, 0xce, 0x3f, 0xcd, 0xce, 0xda, 0x2a, 0xb0, 0xf4, 0x9f, 0x14, 0xe8,
+	0xdd, 0x84, 0xd6, 0x0f, 0x5a, 0x52, 0x2b, 0x06, 0xc7, 0xd9, 0xe4, 0x29, 0xca, 0x9c, 0x41, 0x63,
+	0x79, 0xaf, 0x4a, 0xd0, 0xb6, 0x81, 0xf6, 0x5c, 0xb5, 0x7e, 0xd5, 0x31, 0x81, 0x3f, 0x3e, 0x91,
+	0x28, 0xdc, 0x92, 0x5d, 0xbf, 0x1a, 0xdb, 0x55, 0x90, 0xa2, 0x31, 0x19, 0xff, 0x8c, 0x13, 0x29,
+	0x34, 0xf1, 0x15, 0x9a, 0x9b, 0x6a, 0x25, 0xda, 0x3d, 0x2d, 0x34, 0xf7, 0x15, 0xba, 0xb4, 0x55,
+	0x61, 0x9c, 0x71, 0xf5, 0x57, 0x86, 0x29, 0x5c, 0x35, 0x85, 0x0d, 0xa6, 0x0b, 0x0f, 0x7e, 0xad,
+	0x40, 0xd3, 0x4a, 0x6c, 0x6f, 0x97, 0xdc, 0x83, 0xca, 0x28, 0x93, 0xe4, 0xc3, 0xa2, 0xfe, 0x96,
+	0xfb, 0xb2, 0xbb, 0xf9, 0x26, 0x6c, 0xef, 0x7f, 0x0f, 0x2a, 0x07, 0x78, 0x3a, 0xeb, 0x00, 0xdf,
+	0x9a, 0x55, 0xdc, 0x1f, 0x5f, 0x41, 0x55, 0xbd, 0x20, 0xb2, 0x79, 0xe6, 0x49, 0x99, 0xbc, 0x2b,
+	0xe7, 0x3c, 0x35, 0xf2, 0x0d, 0xd4, 0x8d, 0x7c, 0x49, 0xf1, 0x97, 0xed, 0x94, 0xec, 0xbb, 0x57,
+	0xdf, 0xe2, 0xb1, 0xe9, 0x02, 0xdc, 0xf3, 0x06, 0x49, 0x6e, 0x15, 0x3b, 0xbc, 0x58, 0x9c, 0xdd,
+	0xdb, 0xef, 0x15, 0x6b, 0x0f, 0xbd, 0x0f, 0x35, 0x3d, 0x73, 0x52, 0xec, 0xaa, 0x28, 0x97, 0xae,
+	0x7b, 0xd6, 0x61, 0x72, 0x77, 0xab, 0x3f, 0x96, 0xd3, 0xf1, 0xb8, 0xae, 0x7f, 0x1e, 0xbf, 0xfc,
+	0x77, 0x00, 0x02, 0x82, 0x63, 0x69, 0x4d, 0x0b, 0x00, 0x00,
 }
@@ -19,18 +19,27 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type SegmentMeta struct {
-	EncryptedKey         []byte   `protobuf:"bytes,1,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
-	KeyNonce             []byte   `protobuf:"bytes,2,opt,name=key_nonce,json=keyNonce,proto3" json:"key_nonce,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EncryptedKey []byte `protobuf:"bytes,1,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	KeyNonce     []byte `protobuf:"bytes,2,opt,name=key_nonce,json=keyNonce,proto3" json:"key_nonce,omitempty"`
+	// size of the content of a segment before the last one,
+	// which is stored in the stream info for the last segment
+	ContentSize int64 `protobuf:"varint,3,opt,name=content_size,json=contentSize,proto3" json:"content_size,omitempty"`
+	// nonce the content was encrypted with, when it is not derived from
+	// the index of the segment, like for segments moved from other streams
+	ContentNonce []byte `protobuf:"bytes,4,opt,name=content_nonce,json=contentNonce,proto3" json:"content_nonce,omitempty"`
+	// marshaled ChecksumState of a segment before the last one, encrypted
+	// with its content key and the zero nonce
+	EncryptedChecksumState []byte   `protobuf:"bytes,5,opt,name=encrypted_checksum_state,json=encryptedChecksumState,proto3" json:"encrypted_checksum_state,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *SegmentMeta) Reset()         { *m = SegmentMeta{} }
 func (m *SegmentMeta) String() string { return proto.CompactTextString(m) }
 func (*SegmentMeta) ProtoMessage()    {}
 func (*SegmentMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_c0d9754174b032dc, []int{0}
+	return fileDescriptor_streams_e536a9c237d7a350, []int{0}
 }
 func (m *SegmentMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentMeta.Unmarshal(m, b)
@@ -64,21 +73,105 @@ func (m *SegmentMeta) GetKeyNonce() []byte {
 	return nil
 }
 
-type StreamInfo struct {
-	NumberOfSegments     int64    `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
-	SegmentsSize         int64    `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
-	LastSegmentSize      int64    `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
-	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (m *SegmentMeta) GetContentSize() int64 {
+	if m != nil {
+		return m.ContentSize
+	}
+	return 0
+}
+
+func (m *SegmentMeta) GetContentNonce() []byte {
+	if m != nil {
+		return m.ContentNonce
+	}
+	return nil
+}
+
+func (m *SegmentMeta) GetEncryptedChecksumState() []byte {
+	if m != nil {
+		return m.EncryptedChecksumState
+	}
+	return nil
+}
+
+// ChecksumState holds the checksums of a stream at the end of a segment,
+// so that an interrupted upload can be continued with them
+type ChecksumState struct {
+	// MD5 checksum of the content of the segment
+	SegmentChecksum []byte `protobuf:"bytes,1,opt,name=segment_checksum,json=segmentChecksum,proto3" json:"segment_checksum,omitempty"`
+	// marshaled state of the MD5 hash of the content of the stream
+	StreamHash           []byte   `protobuf:"bytes,2,opt,name=stream_hash,json=streamHash,proto3" json:"stream_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *ChecksumState) Reset()         { *m = ChecksumState{} }
+func (m *ChecksumState) String() string { return proto.CompactTextString(m) }
+func (*ChecksumState) ProtoMessage()    {}
+func (*ChecksumState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_streams_e536a9c237d7a350, []int{1}
+}
+func (m *ChecksumState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChecksumState.Unmarshal(m, b)
+}
+func (m *ChecksumState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChecksumState.Marshal(b, m, deterministic)
+}
+func (dst *ChecksumState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChecksumState.Merge(dst, src)
+}
+func (m *ChecksumState) XXX_Size() int {
+	return xxx_messageInfo_ChecksumState.Size(m)
+}
+func (m *ChecksumState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChecksumState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChecksumState proto.InternalMessageInfo
+
+func (m *ChecksumState) GetSegmentChecksum() []byte {
+	if m != nil {
+		return m.SegmentChecksum
+	}
+	return nil
+}
+
+func (m *ChecksumState) GetStreamHash() []byte {
+	if m != nil {
+		return m.StreamHash
+	}
+	return nil
+}
+
+type StreamInfo struct {
+	NumberOfSegments int64  `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
+	SegmentsSize     int64  `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
+	LastSegmentSize  int64  `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
+	Metadata         []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// checksum is the MD5 checksum of the content, or the MD5 checksum of
+	// the part checksums for a stream concatenated from checksum_parts parts
+	Checksum         []byte   `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	SegmentChecksums [][]byte `protobuf:"bytes,6,rep,name=segment_checksums,json=segmentChecksums" json:"segment_checksums,omitempty"`
+	// compression of the content of the segments before their encryption
+	CompressionType int32 `protobuf:"varint,7,opt,name=compression_type,json=compressionType,proto3" json:"compression_type,omitempty"`
+	// sizes of the segments before the last one, when they differ from
+	// segments_size, like for streams concatenated from other streams
+	SegmentSizes  []int64 `protobuf:"varint,8,rep,packed,name=segment_sizes,json=segmentSizes" json:"segment_sizes,omitempty"`
+	ChecksumParts int64   `protobuf:"varint,9,opt,name=checksum_parts,json=checksumParts,proto3" json:"checksum_parts,omitempty"`
+	// redundancy scheme of the segments, kept in the marker of a stream
+	// being uploaded, which has no remote segment recording it
+	Redundancy           *RedundancyScheme `protobuf:"bytes,10,opt,name=redundancy" json:"redundancy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
 func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
 func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
 func (*StreamInfo) ProtoMessage()    {}
 func (*StreamInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_c0d9754174b032dc, []int{1}
+	return fileDescriptor_streams_e536a9c237d7a350, []int{2}
 }
 func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamInfo.Unmarshal(m, b)
@@ -126,21 +219,65 @@ func (m *StreamInfo) GetMetadata() []byte {
 	return nil
 }
 
+func (m *StreamInfo) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetSegmentChecksums() [][]byte {
+	if m != nil {
+		return m.SegmentChecksums
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetCompressionType() int32 {
+	if m != nil {
+		return m.CompressionType
+	}
+	return 0
+}
+
+func (m *StreamInfo) GetSegmentSizes() []int64 {
+	if m != nil {
+		return m.SegmentSizes
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetChecksumParts() int64 {
+	if m != nil {
+		return m.ChecksumParts
+	}
+	return 0
+}
+
+func (m *StreamInfo) GetRedundancy() *RedundancyScheme {
+	if m != nil {
+		return m.Redundancy
+	}
+	return nil
+}
+
 type StreamMeta struct {
-	EncryptedStreamInfo  []byte       `protobuf:"bytes,1,opt,name=encrypted_stream_info,json=encryptedStreamInfo,proto3" json:"encrypted_stream_info,omitempty"`
-	EncryptionType       int32        `protobuf:"varint,2,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
-	EncryptionBlockSize  int32        `protobuf:"varint,3,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
-	LastSegmentMeta      *SegmentMeta `protobuf:"bytes,4,opt,name=last_segment_meta,json=lastSegmentMeta" json:"last_segment_meta,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	EncryptedStreamInfo []byte       `protobuf:"bytes,1,opt,name=encrypted_stream_info,json=encryptedStreamInfo,proto3" json:"encrypted_stream_info,omitempty"`
+	EncryptionType      int32        `protobuf:"varint,2,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
+	EncryptionBlockSize int32        `protobuf:"varint,3,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
+	LastSegmentMeta     *SegmentMeta `protobuf:"bytes,4,opt,name=last_segment_meta,json=lastSegmentMeta" json:"last_segment_meta,omitempty"`
+	// nonce of the encrypted stream info, the zero nonce when empty
+	StreamInfoNonce      []byte   `protobuf:"bytes,5,opt,name=stream_info_nonce,json=streamInfoNonce,proto3" json:"stream_info_nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StreamMeta) Reset()         { *m = StreamMeta{} }
 func (m *StreamMeta) String() string { return proto.CompactTextString(m) }
 func (*StreamMeta) ProtoMessage()    {}
 func (*StreamMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_streams_c0d9754174b032dc, []int{2}
+	return fileDescriptor_streams_e536a9c237d7a350, []int{3}
 }
 func (m *StreamMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StreamMeta.Unmarshal(m, b)
@@ -188,33 +325,55 @@ func (m *StreamMeta) GetLastSegmentMeta() *SegmentMeta {
 	return nil
 }
 
+func (m *StreamMeta) GetStreamInfoNonce() []byte {
+	if m != nil {
+		return m.StreamInfoNonce
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SegmentMeta)(nil), "streams.SegmentMeta")
+	proto.RegisterType((*ChecksumState)(nil), "streams.ChecksumState")
 	proto.RegisterType((*StreamInfo)(nil), "streams.StreamInfo")
 	proto.RegisterType((*StreamMeta)(nil), "streams.StreamMeta")
 }
 
-func init() { proto.RegisterFile("streams.proto", fileDescriptor_streams_c0d9754174b032dc) }
-
-var fileDescriptor_streams_c0d9754174b032dc = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x51, 0xcb, 0x4e, 0xc3, 0x30,
-	0x10, 0x54, 0x5f, 0x50, 0xb6, 0x29, 0x05, 0x03, 0x52, 0x04, 0x17, 0x14, 0x0e, 0x20, 0x84, 0x7a,
-	0x28, 0x3f, 0x80, 0x7a, 0x43, 0x08, 0x2a, 0x25, 0x9c, 0xb8, 0x58, 0x4e, 0xba, 0x41, 0x51, 0x1a,
-	0x3b, 0x8a, 0xcd, 0xc1, 0xfd, 0x21, 0x3e, 0x8b, 0x5f, 0x41, 0xb6, 0xf3, 0x82, 0xe3, 0xce, 0x8c,
-	0x66, 0x67, 0x76, 0x61, 0x2e, 0x55, 0x85, 0xac, 0x90, 0xcb, 0xb2, 0x12, 0x4a, 0x90, 0xc3, 0x7a,
-	0x0c, 0x36, 0x30, 0x8b, 0xf0, 0xb3, 0x40, 0xae, 0x5e, 0x51, 0x31, 0x72, 0x03, 0x73, 0xe4, 0x49,
-	0xa5, 0x4b, 0x85, 0x5b, 0x9a, 0xa3, 0xf6, 0x07, 0xd7, 0x83, 0x3b, 0x2f, 0xf4, 0x5a, 0xf0, 0x05,
-	0x35, 0xb9, 0x82, 0xa3, 0x1c, 0x35, 0xe5, 0x82, 0x27, 0xe8, 0x0f, 0xad, 0x60, 0x9a, 0xa3, 0x7e,
-	0x33, 0x73, 0xf0, 0x3d, 0x00, 0x88, 0xac, 0xf9, 0x33, 0x4f, 0x05, 0x79, 0x00, 0xc2, 0xbf, 0x8a,
-	0x18, 0x2b, 0x2a, 0x52, 0x2a, 0xdd, 0x26, 0x69, 0x5d, 0x47, 0xe1, 0x89, 0x63, 0x36, 0x69, 0x9d,
-	0x40, 0x9a, 0xf5, 0x8d, 0x86, 0xca, 0x6c, 0xef, 0xdc, 0x47, 0xa1, 0xd7, 0x80, 0x51, 0xb6, 0x47,
-	0x72, 0x0f, 0xa7, 0x3b, 0x26, 0x55, 0xe3, 0xe6, 0x84, 0x23, 0x2b, 0x5c, 0x18, 0xa2, 0x76, 0xb3,
-	0xda, 0x4b, 0x98, 0x16, 0xa8, 0xd8, 0x96, 0x29, 0xe6, 0x8f, 0x5d, 0xd2, 0x66, 0x0e, 0x7e, 0xda,
-	0xa4, 0xb6, 0xfa, 0x0a, 0x2e, 0xba, 0xea, 0xee, 0x3c, 0x34, 0xe3, 0xa9, 0xa8, 0x4f, 0x70, 0xd6,
-	0x92, 0xbd, 0x76, 0xb7, 0xb0, 0xa8, 0xe1, 0x4c, 0x70, 0xaa, 0x74, 0xe9, 0x12, 0x4f, 0xc2, 0xe3,
-	0x0e, 0x7e, 0xd7, 0x25, 0xf6, 0xcc, 0x8d, 0x30, 0xde, 0x89, 0x24, 0xef, 0x72, 0x4f, 0x5a, 0xf3,
-	0x4c, 0xf0, 0xb5, 0xe1, 0x6c, 0xf6, 0xa7, 0x7f, 0x3d, 0x4d, 0x70, 0x5b, 0x62, 0xb6, 0x3a, 0x5f,
-	0x36, 0xef, 0xec, 0x3d, 0xef, 0x4f, 0x7b, 0x03, 0xac, 0xc7, 0x1f, 0xc3, 0x32, 0x8e, 0x0f, 0xec,
-	0xcb, 0x1f, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x5e, 0x32, 0x31, 0x03, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("streams.proto", fileDescriptor_streams_e536a9c237d7a350) }
+
+var fileDescriptor_streams_e536a9c237d7a350 = []byte{
+	// 524 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0xe3, 0xa6, 0x4d, 0x27, 0x49, 0x93, 0x2c, 0x1f, 0x5a, 0xb5, 0x07, 0x4c, 0x10, 0x22,
+	0x14, 0x94, 0x43, 0xb8, 0x20, 0x71, 0x41, 0xe5, 0x02, 0x42, 0x7c, 0xc8, 0xe1, 0x04, 0x07, 0x6b,
+	0xe3, 0x4c, 0x88, 0xe5, 0x7a, 0xd7, 0xf2, 0x6e, 0x0e, 0xee, 0x5f, 0xe0, 0x6f, 0xf0, 0x87, 0xf8,
+	0x47, 0x68, 0x3f, 0xbc, 0x76, 0x73, 0x9c, 0xf7, 0x46, 0xb3, 0xfb, 0xde, 0x9b, 0x81, 0xb1, 0x54,
+	0x15, 0xb2, 0x42, 0x2e, 0xcb, 0x4a, 0x28, 0x41, 0xce, 0x5c, 0x79, 0x39, 0x29, 0x45, 0xc6, 0x15,
+	0x56, 0xdb, 0x8d, 0x65, 0xe6, 0xff, 0x02, 0x18, 0xae, 0xf1, 0x77, 0x81, 0x5c, 0x7d, 0x41, 0xc5,
+	0xc8, 0x33, 0x18, 0x23, 0x4f, 0xab, 0xba, 0x54, 0xb8, 0x4d, 0x72, 0xac, 0x69, 0x10, 0x05, 0x8b,
+	0x51, 0x3c, 0xf2, 0xe0, 0x67, 0xac, 0xc9, 0x15, 0x9c, 0xe7, 0x58, 0x27, 0x5c, 0xf0, 0x14, 0x69,
+	0xcf, 0x34, 0x0c, 0x72, 0xac, 0xbf, 0xea, 0x9a, 0x3c, 0x85, 0x51, 0x2a, 0xb8, 0x42, 0xae, 0x12,
+	0x99, 0xdd, 0x21, 0x0d, 0xa3, 0x60, 0x11, 0xc6, 0x43, 0x87, 0xad, 0xb3, 0x3b, 0xd4, 0x8f, 0x34,
+	0x2d, 0x76, 0xc6, 0x89, 0x7d, 0xc4, 0x81, 0x76, 0xce, 0x5b, 0xa0, 0xed, 0x4f, 0xd2, 0x3d, 0xa6,
+	0xb9, 0x3c, 0x14, 0x89, 0x54, 0x4c, 0x21, 0xed, 0x9b, 0xfe, 0xc7, 0x9e, 0xff, 0xe0, 0xe8, 0xb5,
+	0x66, 0xe7, 0xbf, 0x60, 0x7c, 0x0f, 0x20, 0x2f, 0x61, 0x2a, 0xad, 0x46, 0x3f, 0xc8, 0xe9, 0x9a,
+	0x38, 0xbc, 0xe9, 0x27, 0x4f, 0x60, 0x68, 0xbd, 0x4a, 0xf6, 0x4c, 0xee, 0x9d, 0x38, 0xb0, 0xd0,
+	0x47, 0x26, 0xf7, 0xf3, 0xbf, 0x21, 0xc0, 0xda, 0x94, 0x9f, 0xf8, 0x4e, 0x90, 0xd7, 0x40, 0xf8,
+	0xa1, 0xd8, 0x60, 0x95, 0x88, 0x5d, 0xe2, 0x86, 0x49, 0x33, 0x3c, 0x8c, 0xa7, 0x96, 0xf9, 0xb6,
+	0x73, 0x06, 0x4b, 0x2d, 0xbc, 0xe9, 0xb1, 0xe6, 0xf4, 0x4c, 0xe3, 0xa8, 0x01, 0x8d, 0x3b, 0xd7,
+	0x30, 0xbb, 0x65, 0x52, 0x35, 0xd3, 0xba, 0x2e, 0x4e, 0x34, 0xe1, 0xa6, 0x99, 0xde, 0x4b, 0x18,
+	0x14, 0xa8, 0xd8, 0x96, 0x29, 0xe6, 0x4c, 0xf4, 0xb5, 0xe6, 0xbc, 0x5a, 0x6b, 0x98, 0xaf, 0xc9,
+	0x2b, 0x98, 0x1d, 0x3b, 0x22, 0xe9, 0x69, 0x14, 0x2e, 0x46, 0xf1, 0xf4, 0xc8, 0x12, 0xa9, 0xed,
+	0x4b, 0x45, 0x51, 0x56, 0x28, 0x65, 0x26, 0x78, 0xa2, 0xea, 0x12, 0xe9, 0x59, 0x14, 0x2c, 0xfa,
+	0xf1, 0xa4, 0x83, 0xff, 0xa8, 0x4b, 0xec, 0x08, 0x34, 0xdf, 0x96, 0x74, 0x10, 0x85, 0x1d, 0x81,
+	0xfa, 0xcf, 0x92, 0x3c, 0x87, 0x0b, 0x9f, 0x67, 0xc9, 0x2a, 0x25, 0xe9, 0xb9, 0x51, 0x37, 0x6e,
+	0xd0, 0xef, 0x1a, 0x24, 0xef, 0x00, 0x2a, 0xdc, 0x1e, 0xf8, 0x96, 0xf1, 0xb4, 0xa6, 0x10, 0x05,
+	0x8b, 0xe1, 0xea, 0x6a, 0xd9, 0x2e, 0x70, 0xec, 0xc9, 0x75, 0xba, 0xc7, 0x02, 0xe3, 0x4e, 0xfb,
+	0xfc, 0x4f, 0xaf, 0x89, 0xc9, 0xac, 0xf5, 0x0a, 0x1e, 0xb5, 0xcb, 0xe4, 0x02, 0xce, 0xf8, 0x4e,
+	0xb8, 0x35, 0x78, 0xe0, 0xc9, 0x4e, 0xb4, 0x2f, 0x60, 0xe2, 0x60, 0xaf, 0xba, 0x67, 0x54, 0x5f,
+	0xb4, 0xb0, 0x11, 0xdd, 0x0e, 0xd7, 0x8d, 0x9b, 0x5b, 0x91, 0xe6, 0x6d, 0x68, 0x7d, 0x3f, 0x3c,
+	0x13, 0xfc, 0x46, 0x73, 0x26, 0xb8, 0xf7, 0x47, 0x21, 0x17, 0xe8, 0x12, 0x1c, 0xae, 0x1e, 0x2e,
+	0x9b, 0xe3, 0xed, 0x1c, 0xe6, 0xbd, 0xe8, 0x8d, 0xa4, 0x6b, 0x98, 0x75, 0x84, 0xb8, 0x43, 0xea,
+	0xbb, 0xad, 0xf6, 0x2a, 0xcc, 0x2d, 0xdd, 0x9c, 0xfc, 0xec, 0x95, 0x9b, 0xcd, 0xa9, 0x39, 0xf9,
+	0x37, 0xff, 0x07, 0x00, 0x26, 0x30, 0x70, 0x31, 0x1d, 0x04, 0x00, 0x00,
 }
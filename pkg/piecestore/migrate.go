@@ -0,0 +1,141 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Index keeps which blobs hold each stored piece
+type Index interface {
+	// HasPiece returns whether the piece with id is indexed
+	HasPiece(id string) (bool, error)
+	// AddPiece indexes the blobs of the piece with id
+	AddPiece(id string, piece Piece) error
+}
+
+// Migrate moves the pieces stored in the data directory dir before they
+// were kept in blob stores, whose paths are given by PathByID, into the
+// blob store without a namespace, as the satellites they were stored for
+// are unknown. The old files of a piece are removed only once the piece is
+// indexed, so an interrupted migration continues on the next start.
+func (s *Storage) Migrate(ctx context.Context, dir string, index Index) error {
+	folders1, err := readDirNames(dir)
+	if err != nil {
+		return err
+	}
+
+	var group errs.Group
+	for _, folder1 := range folders1 {
+		if len(folder1) != 2 {
+			continue
+		}
+		folders2, err := readDirNames(filepath.Join(dir, folder1))
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		for _, folder2 := range folders2 {
+			if len(folder2) != 2 {
+				continue
+			}
+			folder := filepath.Join(dir, folder1, folder2)
+			infos, err := ioutil.ReadDir(folder)
+			if err != nil {
+				group.Add(FSError.Wrap(err))
+				continue
+			}
+			for _, info := range infos {
+				if info.IsDir() || strings.HasSuffix(info.Name(), hashesSuffix) {
+					continue
+				}
+				id := folder1 + folder2 + info.Name()
+				group.Add(s.migratePiece(ctx, id, filepath.Join(folder, info.Name()), index))
+			}
+
+			// the folders are removed once empty
+			_ = os.Remove(folder)
+		}
+		_ = os.Remove(filepath.Join(dir, folder1))
+	}
+	return group.Err()
+}
+
+// hashesSuffix is the suffix of the old files of the hashes of the erasure
+// shares of the pieces
+const hashesSuffix = ".hashes"
+
+// migratePiece moves the piece with id stored at dataPath into the blob
+// store without a namespace
+func (s *Storage) migratePiece(ctx context.Context, id, dataPath string, index Index) error {
+	hashesPath := dataPath + hashesSuffix
+
+	indexed, err := index.HasPiece(id)
+	if err != nil {
+		return err
+	}
+	if !indexed {
+		piece, err := s.storeLegacyPiece(ctx, dataPath, hashesPath)
+		if err != nil {
+			return err
+		}
+		if err := index.AddPiece(id, piece); err != nil {
+			return errs.Combine(err, s.Delete(ctx, piece))
+		}
+	}
+
+	if err := os.Remove(hashesPath); err != nil && !os.IsNotExist(err) {
+		return FSError.Wrap(err)
+	}
+	return FSError.Wrap(os.Remove(dataPath))
+}
+
+// storeLegacyPiece stores the content and the hashes of the piece in the
+// old files at dataPath and hashesPath as blobs
+func (s *Storage) storeLegacyPiece(ctx context.Context, dataPath, hashesPath string) (piece Piece, err error) {
+	blobs, err := s.Blobs(nil)
+	if err != nil {
+		return Piece{}, err
+	}
+
+	file, err := os.Open(dataPath)
+	if err != nil {
+		return Piece{}, FSError.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	hasher := sha256.New()
+	ref, err := blobs.Store(ctx, io.TeeReader(file, hasher), -1)
+	if err != nil {
+		return Piece{}, err
+	}
+	piece = Piece{Blob: ref, Hash: hasher.Sum(nil)}
+
+	hashes, err := ioutil.ReadFile(hashesPath)
+	if os.IsNotExist(err) {
+		return piece, nil
+	}
+	if err != nil {
+		return Piece{}, errs.Combine(FSError.Wrap(err), blobs.Delete(ctx, ref))
+	}
+	if _, _, err := decodeHashes(hashes); err != nil {
+		return Piece{}, errs.Combine(err, blobs.Delete(ctx, ref))
+	}
+
+	hashesRef, err := blobs.Store(ctx, bytes.NewReader(hashes), int64(len(hashes)))
+	if err != nil {
+		return Piece{}, errs.Combine(err, blobs.Delete(ctx, ref))
+	}
+	piece.Hashes = &hashesRef
+	return piece, nil
+}
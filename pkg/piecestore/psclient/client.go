@@ -27,6 +27,10 @@ import (
 // ClientError is any error returned by the client
 var ClientError = errs.Class("piecestore client error")
 
+// ErrVerification is the error returned when downloaded erasure shares do not
+// match the root hash of their piece
+var ErrVerification = errs.Class("piece verification error")
+
 var (
 	defaultBandwidthMsgSize = flag.Int(
 		"piecestore.rpc.client.default-bandwidth-msg-size", 32*1024,
@@ -39,13 +43,20 @@ var (
 // Client is an interface describing the functions for interacting with piecestore nodes
 type Client interface {
 	Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, error)
-	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
-	Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
+	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int64) error
+	Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, hash *PieceHash) (ranger.Ranger, error)
 	Delete(ctx context.Context, pieceID PieceID, authorization *pb.SignedMessage) error
 	Stats(ctx context.Context) (*pb.StatSummary, error)
 	io.Closer
 }
 
+// PieceHash is the root hash of the erasure shares of a piece, against which
+// the ranges downloaded from the piece are verified
+type PieceHash struct {
+	Root      []byte
+	ShareSize int64
+}
+
 // PieceStore -- Struct Info needed for protobuf api calls
 type PieceStore struct {
 	closeFunc        func() error              // function that closes the transport connection
@@ -113,15 +124,17 @@ func (ps *PieceStore) Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, e
 	return ps.client.Piece(ctx, &pb.PieceId{Id: id.String()})
 }
 
-// Put uploads a Piece to a piece store Server
-func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error {
+// Put uploads a Piece to a piece store Server. If shareSize is larger than 0,
+// the server keeps the hashes of the erasure shares of the piece to prove the
+// integrity of the ranges downloaded from it.
+func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int64) error {
 	stream, err := ps.client.Store(ctx)
 	if err != nil {
 		return err
 	}
 
 	msg := &pb.PieceStore{
-		PieceData:     &pb.PieceStore_PieceData{Id: id.String(), ExpirationUnixSec: ttl.Unix()},
+		PieceData:     &pb.PieceStore_PieceData{Id: id.String(), ExpirationUnixSec: ttl.Unix(), ShareSize: shareSize},
 		Authorization: authorization,
 	}
 	if err = stream.Send(msg); err != nil {
@@ -158,14 +171,15 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 	return bufw.Flush()
 }
 
-// Get begins downloading a Piece from a piece store Server
-func (ps *PieceStore) Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error) {
+// Get begins downloading a Piece from a piece store Server. If hash is not
+// nil, the downloaded erasure shares are verified against it.
+func (ps *PieceStore) Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, hash *PieceHash) (ranger.Ranger, error) {
 	stream, err := ps.client.Retrieve(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return PieceRangerSize(ps, stream, id, size, ba, authorization), nil
+	return &pieceRanger{c: ps, id: id, size: size, stream: stream, pba: ba, authorization: authorization, hash: hash}, nil
 }
 
 // Delete a Piece from a piece store Server
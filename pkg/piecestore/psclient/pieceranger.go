@@ -25,6 +25,7 @@ type pieceRanger struct {
 	stream        pb.PieceStoreRoutes_RetrieveClient
 	pba           *pb.PayerBandwidthAllocation
 	authorization *pb.SignedMessage
+	hash          *PieceHash
 }
 
 // PieceRanger PieceRanger returns a Ranger from a PieceID.
@@ -63,10 +64,23 @@ func (r *pieceRanger) Range(ctx context.Context, offset, length int64) (io.ReadC
 		return ioutil.NopCloser(bytes.NewReader([]byte{})), nil
 	}
 
+	if r.hash != nil {
+		// only whole erasure shares can be verified
+		if offset%r.hash.ShareSize != 0 ||
+			((offset+length)%r.hash.ShareSize != 0 && offset+length != r.size) {
+			return nil, Error.New("range not aligned to erasure shares")
+		}
+	}
+
 	// send piece data
-	if err := r.stream.Send(&pb.PieceRetrieval{PieceData: &pb.PieceRetrieval_PieceData{Id: r.id.String(), PieceSize: length, Offset: offset}, Authorization: r.authorization}); err != nil {
+	pd := &pb.PieceRetrieval_PieceData{Id: r.id.String(), PieceSize: length, Offset: offset, Proof: r.hash != nil}
+	if err := r.stream.Send(&pb.PieceRetrieval{PieceData: pd, Authorization: r.authorization}); err != nil {
 		return nil, err
 	}
 
-	return NewStreamReader(r.c, r.stream, r.pba, r.size), nil
+	sr := NewStreamReader(r.c, r.stream, r.pba, r.size)
+	if r.hash != nil {
+		return newVerifiedReader(sr, r.hash, r.size, offset, length), nil
+	}
+	return sr, nil
 }
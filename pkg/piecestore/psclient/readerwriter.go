@@ -79,6 +79,11 @@ type StreamReader struct {
 	downloaded    int64
 	allocated     int64
 	size          int64
+
+	// the proof of the range, received with its first content
+	shareSize   int64
+	shareHashes [][]byte
+	proof       [][]byte
 }
 
 // NewStreamReader creates a StreamReader for reading data from the piece store server
@@ -157,6 +162,12 @@ func NewStreamReader(client *PieceStore, stream pb.PieceStoreRoutes_RetrieveClie
 			return nil, err
 		}
 
+		if sr.downloaded == 0 && resp.GetShareHashes() != nil {
+			sr.shareSize = resp.GetShareSize()
+			sr.shareHashes = resp.GetShareHashes()
+			sr.proof = resp.GetProof()
+		}
+
 		sr.downloaded += int64(len(resp.GetContent()))
 
 		err = sr.pendingAllocs.Consume(int64(len(resp.GetContent())))
@@ -0,0 +1,96 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"bytes"
+	"io"
+
+	"storj.io/storj/pkg/merkle"
+)
+
+// verifiedReader reads a range of a piece, returning the erasure shares only
+// once they are verified against the root hash of the piece
+type verifiedReader struct {
+	sr        *StreamReader
+	hash      *PieceHash
+	count     int   // number of erasure shares of the piece
+	start     int   // index of the first erasure share of the range
+	shares    int   // number of erasure shares of the range
+	remaining int64 // bytes of the range not read from sr yet
+	proven    bool
+	next      int // index in the range of the next erasure share to verify
+	share     []byte
+	buf       []byte // verified bytes not read yet
+	err       error
+}
+
+func newVerifiedReader(sr *StreamReader, hash *PieceHash, size, offset, length int64) *verifiedReader {
+	return &verifiedReader{
+		sr:        sr,
+		hash:      hash,
+		count:     int((size + hash.ShareSize - 1) / hash.ShareSize),
+		start:     int(offset / hash.ShareSize),
+		shares:    int((length + hash.ShareSize - 1) / hash.ShareSize),
+		remaining: length,
+		share:     make([]byte, hash.ShareSize),
+	}
+}
+
+// Read implements io.Reader
+func (r *verifiedReader) Read(p []byte) (n int, err error) {
+	if len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.remaining == 0 {
+			return 0, io.EOF
+		}
+		r.buf, r.err = r.readShare()
+		if r.err != nil {
+			return 0, r.err
+		}
+	}
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// readShare reads and verifies the next erasure share of the range
+func (r *verifiedReader) readShare() ([]byte, error) {
+	share := r.share
+	if int64(len(share)) > r.remaining {
+		share = share[:r.remaining]
+	}
+	_, err := io.ReadFull(r.sr, share)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.remaining -= int64(len(share))
+
+	// the hashes of the range are received with its first content
+	if !r.proven {
+		if r.sr.shareSize != r.hash.ShareSize || len(r.sr.shareHashes) != r.shares {
+			return nil, ErrVerification.New("missing hashes of the erasure shares")
+		}
+		if err := merkle.Verify(r.hash.Root, r.count, r.start, r.sr.shareHashes, r.sr.proof); err != nil {
+			return nil, ErrVerification.Wrap(err)
+		}
+		r.proven = true
+	}
+
+	if !bytes.Equal(merkle.LeafHash(share), r.sr.shareHashes[r.next]) {
+		return nil, ErrVerification.New("corrupted erasure share %d", r.start+r.next)
+	}
+	r.next++
+	return share, nil
+}
+
+// Close implements io.Closer
+func (r *verifiedReader) Close() error {
+	return r.sr.Close()
+}
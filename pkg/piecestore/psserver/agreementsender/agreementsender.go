@@ -15,6 +15,7 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
@@ -33,17 +34,19 @@ type AgreementSender struct {
 	DB       *psdb.DB
 	overlay  overlay.Client
 	identity *provider.FullIdentity
+	trusted  *trust.List
 	errs     []error
 }
 
-// Initialize the Agreement Sender
-func Initialize(DB *psdb.DB, identity *provider.FullIdentity) (*AgreementSender, error) {
+// Initialize the Agreement Sender, sending the agreements to the trusted
+// satellites only
+func Initialize(DB *psdb.DB, identity *provider.FullIdentity, trusted *trust.List) (*AgreementSender, error) {
 	overlay, err := overlay.NewClient(identity, *defaultOverlayAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AgreementSender{DB: DB, identity: identity, overlay: overlay}, nil
+	return &AgreementSender{DB: DB, identity: identity, overlay: overlay, trusted: trusted}, nil
 }
 
 // Run the afreement sender with a context to cehck for cancel
@@ -80,13 +83,23 @@ func (as *AgreementSender) Run(ctx context.Context) error {
 			return utils.CombineErrors(as.errs...)
 		case agreementGroup := <-c:
 			go func() {
+				if !as.trusted.IsTrusted(agreementGroup.satellite) {
+					zap.S().Warnf("Not sending %v agreements to untrusted satellite %s\n", len(agreementGroup.agreements), agreementGroup.satellite)
+					return
+				}
+
 				zap.S().Infof("Sending %v agreements to satellite %s\n", len(agreementGroup.agreements), agreementGroup.satellite)
 
-				// Get satellite ip from overlay by Lookup agreementGroup.satellite
-				satellite, err := as.overlay.Lookup(ctx, agreementGroup.satellite)
-				if err != nil {
-					zap.S().Error(err)
-					return
+				// Use the address of the trusted satellite, or get satellite
+				// ip from overlay by Lookup agreementGroup.satellite
+				address := as.trusted.Address(agreementGroup.satellite)
+				if address == "" {
+					satellite, err := as.overlay.Lookup(ctx, agreementGroup.satellite)
+					if err != nil {
+						zap.S().Error(err)
+						return
+					}
+					address = satellite.GetAddress().Address
 				}
 
 				// Create client from satellite ip
@@ -96,7 +109,7 @@ func (as *AgreementSender) Run(ctx context.Context) error {
 					return
 				}
 
-				conn, err := grpc.Dial(satellite.GetAddress().Address, identOpt)
+				conn, err := grpc.Dial(address, identOpt)
 				if err != nil {
 					zap.S().Error(err)
 					return
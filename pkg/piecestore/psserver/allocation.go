@@ -0,0 +1,83 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"strconv"
+	"strings"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Allocation is the disk space and the monthly bandwidth a satellite may
+// use, 0 not limiting them besides the total
+type Allocation struct {
+	DiskSpace int64
+	Bandwidth int64
+}
+
+// Allocations are the allocations of the satellites
+type Allocations struct {
+	defaults   Allocation
+	satellites map[storj.NodeID]Allocation
+}
+
+// ParseAllocations returns the allocations of the satellites listed in list
+// as <node id>:<disk space>:<bandwidth> entries separated by commas, the
+// other satellites being allocated defaults
+func ParseAllocations(defaults Allocation, list string) (*Allocations, error) {
+	allocations := &Allocations{
+		defaults:   defaults,
+		satellites: make(map[storj.NodeID]Allocation),
+	}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.Split(entry, ":")
+		if len(fields) != 3 {
+			return nil, ServerError.New("invalid satellite allocation %q", entry)
+		}
+		id, err := storj.NodeIDFromString(fields[0])
+		if err != nil {
+			return nil, ServerError.New("invalid satellite allocation %q: %v", entry, err)
+		}
+		diskSpace, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || diskSpace < 0 {
+			return nil, ServerError.New("invalid disk space of satellite allocation %q", entry)
+		}
+		bandwidth, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil || bandwidth < 0 {
+			return nil, ServerError.New("invalid bandwidth of satellite allocation %q", entry)
+		}
+		allocations.satellites[id] = Allocation{DiskSpace: diskSpace, Bandwidth: bandwidth}
+	}
+	return allocations, nil
+}
+
+// Get returns the allocation of the satellite with id, a nil Allocations
+// not limiting any satellite
+func (allocations *Allocations) Get(id storj.NodeID) Allocation {
+	if allocations == nil || id.IsZero() {
+		return Allocation{}
+	}
+	if allocation, ok := allocations.satellites[id]; ok {
+		return allocation
+	}
+	return allocations.defaults
+}
+
+// Satellites returns the satellites given a specific allocation
+func (allocations *Allocations) Satellites() storj.NodeIDList {
+	if allocations == nil {
+		return nil
+	}
+	var ids storj.NodeIDList
+	for id := range allocations.satellites {
+		ids = append(ids, id)
+	}
+	return ids
+}
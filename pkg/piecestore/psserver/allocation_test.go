@@ -0,0 +1,48 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestParseAllocations(t *testing.T) {
+	id1 := teststorj.NodeIDFromString("satellite1")
+	id2 := teststorj.NodeIDFromString("satellite2")
+	other := teststorj.NodeIDFromString("other")
+	defaults := Allocation{DiskSpace: 100, Bandwidth: 1000}
+
+	allocations, err := ParseAllocations(defaults, fmt.Sprintf("%s:10:0, %s:0:20", id1, id2))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, Allocation{DiskSpace: 10}, allocations.Get(id1))
+	assert.Equal(t, Allocation{Bandwidth: 20}, allocations.Get(id2))
+	assert.Equal(t, defaults, allocations.Get(other))
+	assert.ElementsMatch(t, storj.NodeIDList{id1, id2}, allocations.Satellites())
+
+	// the unknown satellites and a nil Allocations are not limited
+	assert.Equal(t, Allocation{}, allocations.Get(storj.NodeID{}))
+	assert.Equal(t, Allocation{}, (*Allocations)(nil).Get(id1))
+
+	allocations, err = ParseAllocations(defaults, "")
+	assert.NoError(t, err)
+	assert.Equal(t, defaults, allocations.Get(id1))
+
+	for i, list := range []string{
+		"invalid:10:20",
+		id1.String() + ":10",
+		id1.String() + ":ten:20",
+		id1.String() + ":10:-20",
+	} {
+		_, err := ParseAllocations(defaults, list)
+		assert.True(t, ServerError.Has(err), fmt.Sprintf("Test case #%d", i))
+	}
+}
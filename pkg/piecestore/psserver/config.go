@@ -17,6 +17,7 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psserver/agreementsender"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
 )
 
@@ -30,6 +31,13 @@ type Config struct {
 	AllocatedDiskSpace     int64         `help:"total allocated disk space, default(1GB)" default:"1073741824"`
 	AllocatedBandwidth     int64         `help:"total allocated bandwidth, default(100GB)" default:"107374182400"`
 	KBucketRefreshInterval time.Duration `help:"how frequently checker should audit segments" default:"3600s"`
+	ScrubRate              int64         `help:"bytes per second at which the stored pieces are re-read to detect their corruption, 0 to disable" default:"1048576"`
+	ScrubInterval          time.Duration `help:"how frequently each stored piece is re-read to detect its corruption" default:"168h"`
+
+	SatelliteAllocatedDiskSpace int64  `help:"disk space allocated to each satellite, 0 not to limit it besides the total" default:"0"`
+	SatelliteAllocatedBandwidth int64  `help:"monthly bandwidth allocated to each satellite, 0 not to limit it besides the total" default:"0"`
+	SatelliteAllocations        string `help:"comma-separated allocations of specific satellites replacing the ones of each satellite, as <node id>:<disk space>:<bandwidth>" default:""`
+	Trust                       trust.Config
 }
 
 // Run implements provider.Responsibility
@@ -43,15 +51,28 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return ServerError.Wrap(err)
 	}
 
-	s, err := NewEndpoint(zap.L(), c, db, server.Identity().Key)
+	trusted, err := c.Trust.Load(ctx)
+	if err != nil {
+		return ServerError.Wrap(err)
+	}
+	if trusted.TrustsAll() {
+		zap.L().Warn("No trusted satellites configured, storing for any satellite")
+	}
+
+	s, err := NewEndpoint(zap.L(), c, db, server.Identity().Key, trusted)
 	if err != nil {
 		return err
 	}
 
+	// Move the pieces stored before the blob stores into them
+	if err := s.MigratePieces(ctx); err != nil {
+		return ServerError.Wrap(err)
+	}
+
 	pb.RegisterPieceStoreRoutesServer(server.GRPC(), s)
 
 	// Run the agreement sender process
-	asProcess, err := agreementsender.Initialize(s.DB, server.Identity())
+	asProcess, err := agreementsender.Initialize(s.DB, server.Identity(), trusted)
 	if err != nil {
 		return err
 	}
@@ -85,6 +106,16 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		}
 	}()
 
+	// Run the scrubber re-reading the stored pieces
+	if c.ScrubRate > 0 {
+		scrubber := NewScrubber(zap.L(), s.DB, s.storage, c.ScrubRate, c.ScrubInterval)
+		go func() {
+			if err := scrubber.Run(ctx); err != nil {
+				cancel()
+			}
+		}()
+	}
+
 	defer func() {
 		log.Fatal(s.Stop(ctx))
 	}()
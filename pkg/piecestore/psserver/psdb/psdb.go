@@ -24,6 +24,7 @@ import (
 	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -36,12 +37,15 @@ var (
 
 // DB is a piece store database
 type DB struct {
-	dataPath string
-	mu       sync.Mutex
-	DB       *sql.DB // TODO: hide
-	check    *time.Ticker
+	storage *pstore.Storage
+	mu      sync.Mutex
+	DB      *sql.DB // TODO: hide
+	check   *time.Ticker
 }
 
+// Storage returns the storage of the pieces indexed in the database
+func (db *DB) Storage() *pstore.Storage { return db.storage }
+
 // Agreement is a struct that contains a bandwidth agreement and the associated signature
 type Agreement struct {
 	Agreement []byte
@@ -61,9 +65,9 @@ func Open(ctx context.Context, dataPath, DBPath string) (db *DB, err error) {
 		return nil, Error.Wrap(err)
 	}
 	db = &DB{
-		DB:       sqlite,
-		dataPath: dataPath,
-		check:    time.NewTicker(*defaultCheckInterval),
+		DB:      sqlite,
+		storage: pstore.NewStorage(dataPath),
+		check:   time.NewTicker(*defaultCheckInterval),
 	}
 	if err := db.init(); err != nil {
 		return nil, utils.CombineErrors(err, db.DB.Close())
@@ -84,9 +88,9 @@ func OpenInMemory(ctx context.Context, dataPath string) (db *DB, err error) {
 	}
 
 	db = &DB{
-		DB:       sqlite,
-		dataPath: dataPath,
-		check:    time.NewTicker(*defaultCheckInterval),
+		DB:      sqlite,
+		storage: pstore.NewStorage(dataPath),
+		check:   time.NewTicker(*defaultCheckInterval),
 	}
 	if err := db.init(); err != nil {
 		return nil, utils.CombineErrors(err, db.DB.Close())
@@ -105,7 +109,7 @@ func (db *DB) init() (err error) {
 
 	defer func() { _ = tx.Rollback() }()
 
-	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `ttl` (`id` BLOB UNIQUE, `created` INT(10), `expires` INT(10), `size` INT(10));")
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `ttl` (`id` BLOB UNIQUE, `created` INT(10), `expires` INT(10), `size` INT(10), `satellite` BLOB);")
 	if err != nil {
 		return err
 	}
@@ -120,7 +124,37 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
-	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `bwusagetbl` (`size` INT(10), `daystartdate` INT(10), `dayenddate` INT(10));")
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `pieces` (`id` BLOB UNIQUE, `namespace` BLOB, `blob` BLOB, `hashes` BLOB, `hash` BLOB, `verified` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS idx_pieces_verified ON pieces (verified);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `quarantine` (`id` BLOB, `namespace` BLOB, `blob` BLOB, `hashes` BLOB, `hash` BLOB, `reason` TEXT, `detected` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `bwusagetbl` (`size` INT(10), `daystartdate` INT(10), `dayenddate` INT(10), `satellite` BLOB);")
+	if err != nil {
+		return err
+	}
+
+	// the satellites of the pieces and of the bandwidth usage are recorded
+	// in the tables created before they were
+	for _, table := range []string{"ttl", "bwusagetbl"} {
+		if err := addColumn(tx, table, "satellite", "BLOB"); err != nil {
+			return err
+		}
+	}
+
+	// the pieces stored before their satellite was recorded were stored
+	// for the satellite of their namespace
+	_, err = tx.Exec("UPDATE ttl SET satellite = (SELECT namespace FROM pieces WHERE pieces.id = ttl.id) WHERE satellite IS NULL;")
 	if err != nil {
 		return err
 	}
@@ -136,6 +170,46 @@ func (db *DB) init() (err error) {
 	return nil
 }
 
+// addColumn adds the column with definition to the table if it has not
+// got it yet
+func addColumn(tx *sql.Tx, table, column, definition string) (err error) {
+	rows, err := tx.Query("PRAGMA table_info(`" + table + "`);")
+	if err != nil {
+		return err
+	}
+
+	exists := false
+	for rows.Next() {
+		var cid, notNull, primaryKey int
+		var name, columnType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return utils.CombineErrors(err, rows.Close())
+		}
+		if name == column {
+			exists = true
+		}
+	}
+	if err := utils.CombineErrors(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	_, err = tx.Exec("ALTER TABLE `" + table + "` ADD COLUMN `" + column + "` " + definition + ";")
+	return err
+}
+
+// satelliteValue returns the value of the satellite columns for the
+// satellite with id, NULL for the zero id of an unknown satellite
+func satelliteValue(id storj.NodeID) interface{} {
+	if id.IsZero() {
+		return nil
+	}
+	return id.Bytes()
+}
+
 // Close the database
 func (db *DB) Close() error {
 	return db.DB.Close()
@@ -150,7 +224,7 @@ func (db *DB) locked() func() {
 func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var expired []string
+	var expired []pstore.Piece
 	err = func() error {
 		defer db.locked()()
 
@@ -162,23 +236,29 @@ func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 
 		now := time.Now().Unix()
 
-		rows, err := tx.Query("SELECT id FROM ttl WHERE 0 < expires AND ? < expires", now)
+		rows, err := tx.Query("SELECT pieces.namespace, pieces.blob, pieces.hashes, pieces.hash FROM ttl JOIN pieces ON ttl.id = pieces.id WHERE 0 < expires AND expires < ?", now)
 		if err != nil {
 			return err
 		}
 
 		for rows.Next() {
-			var id string
-			if err := rows.Scan(&id); err != nil {
-				return err
+			piece, err := scanPiece(rows)
+			if err != nil {
+				return utils.CombineErrors(err, rows.Close())
 			}
-			expired = append(expired, id)
+			expired = append(expired, piece)
 		}
 		if err := rows.Close(); err != nil {
 			return err
 		}
 
-		_, err = tx.Exec(`DELETE FROM ttl WHERE 0 < expires AND ? < expires`, now)
+		// the pieces are no longer visible before their blobs are deleted
+		_, err = tx.Exec(`DELETE FROM pieces WHERE id IN (SELECT id FROM ttl WHERE 0 < expires AND expires < ?)`, now)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`DELETE FROM ttl WHERE 0 < expires AND expires < ?`, now)
 		if err != nil {
 			return err
 		}
@@ -186,9 +266,13 @@ func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 		return tx.Commit()
 	}()
 
+	if err != nil {
+		return err
+	}
+
 	var errs []error
-	for _, id := range expired {
-		err := pstore.Delete(id, db.dataPath)
+	for _, piece := range expired {
+		err := db.storage.Delete(ctx, piece)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -305,12 +389,13 @@ func (db *DB) GetBandwidthAllocations() (map[storj.NodeID][]*Agreement, error) {
 	return agreements, nil
 }
 
-// AddTTL adds TTL into database by id
-func (db *DB) AddTTL(id string, expiration, size int64) error {
+// AddTTL adds TTL into database by id, for the piece stored for the
+// satellite with satelliteID
+func (db *DB) AddTTL(id string, satelliteID storj.NodeID, expiration, size int64) error {
 	defer db.locked()()
 
 	created := time.Now().Unix()
-	_, err := db.DB.Exec("INSERT OR REPLACE INTO ttl (id, created, expires, size) VALUES (?, ?, ?, ?)", id, created, expiration, size)
+	_, err := db.DB.Exec("INSERT OR REPLACE INTO ttl (id, created, expires, size, satellite) VALUES (?, ?, ?, ?, ?)", id, created, expiration, size, satelliteValue(satelliteID))
 	return err
 }
 
@@ -341,6 +426,41 @@ func (db *DB) SumTTLSizes() (sum int64, err error) {
 	return sum, err
 }
 
+// SumTTLSizesBySatellite sums the size column on the ttl table for each
+// satellite the pieces are stored for, the pieces of unknown satellites
+// being left out
+func (db *DB) SumTTLSizesBySatellite() (sums map[storj.NodeID]int64, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT satellite, SUM(size) FROM ttl WHERE satellite IS NOT NULL GROUP BY satellite`)
+	if err != nil {
+		return nil, err
+	}
+	return scanSatelliteSums(rows)
+}
+
+// scanSatelliteSums scans the sums grouped by satellite of rows, skipping
+// the invalid satellite ids
+func scanSatelliteSums(rows *sql.Rows) (sums map[storj.NodeID]int64, err error) {
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	sums = make(map[storj.NodeID]int64)
+	for rows.Next() {
+		var satellite []byte
+		var sum int64
+		if err := rows.Scan(&satellite, &sum); err != nil {
+			return nil, err
+		}
+		satelliteID, err := storj.NodeIDFromBytes(satellite)
+		if err != nil {
+			// not stored for a satellite
+			continue
+		}
+		sums[satelliteID] = sum
+	}
+	return sums, rows.Err()
+}
+
 // DeleteTTLByID finds the TTL in the database by id and delete it
 func (db *DB) DeleteTTLByID(id string) error {
 	defer db.locked()()
@@ -352,26 +472,216 @@ func (db *DB) DeleteTTLByID(id string) error {
 	return err
 }
 
-// AddBandwidthUsed adds bandwidth usage into database by date
-func (db *DB) AddBandwidthUsed(size int64) (err error) {
+// AddPiece adds the blobs of the piece with id into database, the piece
+// counting as verified when added. The blobs of a piece already stored with
+// id are replaced and deleted.
+func (db *DB) AddPiece(id string, piece pstore.Piece) (err error) {
+	ctx := context.Background()
+
+	var replaced *pstore.Piece
+	err = func() error {
+		defer db.locked()()
+
+		tx, err := db.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		old, err := scanPiece(tx.QueryRow(`SELECT namespace, blob, hashes, hash FROM pieces WHERE id=?`, id))
+		switch {
+		case err == nil:
+			replaced = &old
+		case err != sql.ErrNoRows:
+			return err
+		}
+
+		var hashes []byte
+		if piece.Hashes != nil {
+			hashes = piece.Hashes[:]
+		}
+		_, err = tx.Exec("INSERT OR REPLACE INTO pieces (id, namespace, blob, hashes, hash, verified) VALUES (?, ?, ?, ?, ?, ?)",
+			id, piece.Namespace, piece.Blob[:], hashes, piece.Hash, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}()
+	if err != nil {
+		return err
+	}
+
+	// the replaced blobs are no longer visible before they are deleted, the
+	// piece being added whether or not they could be
+	if replaced != nil {
+		if err := db.storage.Delete(ctx, *replaced); err != nil {
+			zap.S().Errorf("failed to delete the blobs of replaced piece %s: %+v", id, err)
+		}
+	}
+	return nil
+}
+
+// GetPiece finds the blobs of the piece in the database by id
+func (db *DB) GetPiece(id string) (pstore.Piece, error) {
+	defer db.locked()()
+
+	return scanPiece(db.DB.QueryRow(`SELECT namespace, blob, hashes, hash FROM pieces WHERE id=?`, id))
+}
+
+// HasPiece returns whether the database has the blobs of the piece with id
+func (db *DB) HasPiece(id string) (bool, error) {
+	_, err := db.GetPiece(id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// DeletePiece finds the blobs of the piece in the database by id and delete them,
+// returning sql.ErrNoRows if there are none
+func (db *DB) DeletePiece(id string) error {
+	defer db.locked()()
+
+	result, err := db.DB.Exec(`DELETE FROM pieces WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UnverifiedPieces returns the ids of at most limit pieces last verified
+// before the time given, the least recently verified first
+func (db *DB) UnverifiedPieces(before time.Time, limit int) (ids []string, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id FROM pieces WHERE verified < ? ORDER BY verified LIMIT ?`, before.Unix(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SetPieceVerified records when the piece with id was verified, recording
+// its hash as well if it had none
+func (db *DB) SetPieceVerified(id string, hash []byte, verified time.Time) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`UPDATE pieces SET verified=?, hash=COALESCE(hash, ?) WHERE id=?`, verified.Unix(), hash, id)
+	return err
+}
+
+// QuarantinedPiece is a piece found corrupted
+type QuarantinedPiece struct {
+	ID       string
+	Reason   string
+	Detected time.Time
+}
+
+// QuarantinePiece moves the piece with id found corrupted for reason out of
+// the stored pieces, its blobs being kept for inspection
+func (db *DB) QuarantinePiece(id string, reason string) (err error) {
+	defer db.locked()()
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+
+	_, err = tx.Exec(`INSERT INTO quarantine (id, namespace, blob, hashes, hash, reason, detected)
+		SELECT id, namespace, blob, hashes, hash, ?, ? FROM pieces WHERE id=?`, reason, time.Now().Unix(), id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM pieces WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetQuarantinedPieces returns the pieces found corrupted, the most recently
+// detected first
+func (db *DB) GetQuarantinedPieces() (pieces []QuarantinedPiece, err error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id, reason, detected FROM quarantine ORDER BY detected DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var piece QuarantinedPiece
+		var detected int64
+		if err := rows.Scan(&piece.ID, &piece.Reason, &detected); err != nil {
+			return nil, err
+		}
+		piece.Detected = time.Unix(detected, 0)
+		pieces = append(pieces, piece)
+	}
+	return pieces, rows.Err()
+}
+
+// scanPiece scans the namespace, blob, hashes and hash columns of a piece
+func scanPiece(row interface{ Scan(...interface{}) error }) (piece pstore.Piece, err error) {
+	var blob, hashes []byte
+	if err := row.Scan(&piece.Namespace, &blob, &hashes, &piece.Hash); err != nil {
+		return pstore.Piece{}, err
+	}
+	copy(piece.Blob[:], blob)
+	if hashes != nil {
+		piece.Hashes = new(storage.BlobRef)
+		copy(piece.Hashes[:], hashes)
+	}
+	return piece, nil
+}
+
+// AddBandwidthUsed adds bandwidth usage into database by date, for the
+// satellite with satelliteID
+func (db *DB) AddBandwidthUsed(satelliteID storj.NodeID, size int64) (err error) {
 	defer db.locked()()
 
 	t := time.Now()
 	daystartunixtime := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Unix()
 	dayendunixtime := time.Date(t.Year(), t.Month(), t.Day(), 24, 0, 0, 0, t.Location()).Unix()
+	satellite := satelliteValue(satelliteID)
 
 	var getSize int64
 	if (t.Unix() >= daystartunixtime) && (t.Unix() <= dayendunixtime) {
-		err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate <= ? AND ? <= dayenddate`, t.Unix(), t.Unix()).Scan(&getSize)
+		err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate <= ? AND ? <= dayenddate AND satellite IS ?`, t.Unix(), t.Unix(), satellite).Scan(&getSize)
 		switch {
 		case err == sql.ErrNoRows:
-			_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, daystartdate, dayenddate) VALUES (?, ?, ?)", size, daystartunixtime, dayendunixtime)
+			_, err = db.DB.Exec("INSERT INTO bwusagetbl (size, daystartdate, dayenddate, satellite) VALUES (?, ?, ?, ?)", size, daystartunixtime, dayendunixtime, satellite)
 			return err
 		case err != nil:
 			return err
 		default:
 			getSize = size + getSize
-			_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ? WHERE daystartdate = ?", getSize, daystartunixtime)
+			_, err = db.DB.Exec("UPDATE bwusagetbl SET size = ? WHERE daystartdate = ? AND satellite IS ?", getSize, daystartunixtime, satellite)
 			return err
 		}
 	}
@@ -383,8 +693,14 @@ func (db *DB) GetBandwidthUsedByDay(t time.Time) (size int64, err error) {
 	defer db.locked()()
 
 	daystarttime := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Unix()
-	err = db.DB.QueryRow(`SELECT size FROM bwusagetbl WHERE daystartdate=?`, daystarttime).Scan(&size)
-	return size, err
+	var sum sql.NullInt64
+	if err := db.DB.QueryRow(`SELECT SUM(size) FROM bwusagetbl WHERE daystartdate=?`, daystarttime).Scan(&sum); err != nil {
+		return 0, err
+	}
+	if !sum.Valid {
+		return 0, sql.ErrNoRows
+	}
+	return sum.Int64, nil
 }
 
 // GetTotalBandwidthBetween each row in the bwusagetbl contains the total bw used per day
@@ -413,3 +729,22 @@ func (db *DB) GetTotalBandwidthBetween(startdate time.Time, enddate time.Time) (
 	err = db.DB.QueryRow(`SELECT SUM(size) FROM bwusagetbl WHERE daystartdate BETWEEN ? AND ?`, startTimeUnix, endTimeUnix).Scan(&totalbwusage)
 	return totalbwusage, err
 }
+
+// GetBandwidthBetweenBySatellite sums the bandwidth used between the days of
+// startdate and enddate for each satellite, the bandwidth used for unknown
+// satellites being left out
+func (db *DB) GetBandwidthBetweenBySatellite(startdate time.Time, enddate time.Time) (sums map[storj.NodeID]int64, err error) {
+	defer db.locked()()
+
+	startTimeUnix := time.Date(startdate.Year(), startdate.Month(), startdate.Day(), 0, 0, 0, 0, startdate.Location()).Unix()
+	endTimeUnix := time.Date(enddate.Year(), enddate.Month(), enddate.Day(), 0, 0, 0, 0, enddate.Location()).Unix()
+	if endTimeUnix < startTimeUnix {
+		return nil, errors.New("Invalid date range")
+	}
+
+	rows, err := db.DB.Query(`SELECT satellite, SUM(size) FROM bwusagetbl WHERE daystartdate BETWEEN ? AND ? AND satellite IS NOT NULL GROUP BY satellite`, startTimeUnix, endTimeUnix)
+	if err != nil {
+		return nil, err
+	}
+	return scanSatelliteSums(rows)
+}
@@ -6,9 +6,11 @@ package psdb
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
@@ -18,7 +20,9 @@ import (
 
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var ctx = context.Background()
@@ -32,7 +36,7 @@ func newDB(t testing.TB) (*DB, func()) {
 	}
 	dbpath := filepath.Join(tmpdir, "psdb.db")
 
-	db, err := Open(ctx, "", dbpath)
+	db, err := Open(ctx, filepath.Join(tmpdir, "pieces"), dbpath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -80,7 +84,7 @@ func TestHappyPath(t *testing.T) {
 			t.Run("#"+strconv.Itoa(P), func(t *testing.T) {
 				t.Parallel()
 				for _, ttl := range tests {
-					err := db.AddTTL(ttl.ID, ttl.Expiration, 0)
+					err := db.AddTTL(ttl.ID, storj.NodeID{}, ttl.Expiration, 0)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -244,6 +248,8 @@ func TestBandwidthUsage(t *testing.T) {
 		{size: 1000, timenow: time.Now()},
 	}
 
+	satelliteID := teststorj.NodeIDFromString("satellite")
+
 	var bwTotal int64
 	t.Run("AddBandwidthUsed", func(t *testing.T) {
 		for P := 0; P < concurrency; P++ {
@@ -251,7 +257,7 @@ func TestBandwidthUsage(t *testing.T) {
 			t.Run("#"+strconv.Itoa(P), func(t *testing.T) {
 				t.Parallel()
 				for _, bw := range bwtests {
-					err := db.AddBandwidthUsed(bw.size)
+					err := db.AddBandwidthUsed(satelliteID, bw.size)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -295,6 +301,348 @@ func TestBandwidthUsage(t *testing.T) {
 	})
 }
 
+func TestPieces(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	hashes := storage.BlobRef{2}
+	pieces := map[string]pstore.Piece{
+		"piece-without-hashes": {Blob: storage.BlobRef{1}},
+		"piece-with-hashes": {
+			Namespace: []byte("satellite"),
+			Blob:      storage.BlobRef{3},
+			Hashes:    &hashes,
+		},
+	}
+
+	for id, piece := range pieces {
+		has, err := db.HasPiece(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if has {
+			t.Fatalf("piece %s found before being added", id)
+		}
+
+		if err := db.AddPiece(id, piece); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := db.GetPiece(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(piece, got) {
+			t.Fatalf("expected %v got %v", piece, got)
+		}
+
+		if err := db.DeletePiece(id); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.GetPiece(id); err != sql.ErrNoRows {
+			t.Fatalf("expected %v got %v", sql.ErrNoRows, err)
+		}
+		if err := db.DeletePiece(id); err != sql.ErrNoRows {
+			t.Fatalf("expected %v got %v", sql.ErrNoRows, err)
+		}
+	}
+}
+
+func TestDeleteExpired(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	expires := map[string]int64{
+		"expired":   now - 100,
+		"live":      now + 100,
+		"unlimited": 0,
+	}
+
+	stored := map[string]pstore.Piece{}
+	for id, expiration := range expires {
+		piece, _, err := db.Storage().Store(ctx, nil, bytes.NewReader([]byte(id)), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddPiece(id, piece); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddTTL(id, storj.NodeID{}, expiration, int64(len(id))); err != nil {
+			t.Fatal(err)
+		}
+		stored[id] = piece
+	}
+
+	if err := db.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, piece := range stored {
+		has, err := db.HasPiece(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blob, loadErr := db.Storage().Open(ctx, piece)
+		if loadErr == nil {
+			_ = blob.Close()
+		}
+		if id == "expired" {
+			if has || loadErr == nil {
+				t.Fatalf("expired piece %s not deleted", id)
+			}
+			if _, err := db.GetTTLByID(id); err != sql.ErrNoRows {
+				t.Fatalf("expected %v got %v", sql.ErrNoRows, err)
+			}
+			continue
+		}
+		if !has || loadErr != nil {
+			t.Fatalf("piece %s deleted before expiring: %v", id, loadErr)
+		}
+	}
+}
+
+func TestAddPieceReplaces(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	const id = "restored"
+
+	var stored []pstore.Piece
+	for _, data := range []string{"first", "second"} {
+		piece, _, err := db.Storage().Store(ctx, nil, bytes.NewReader([]byte(data)), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddPiece(id, piece); err != nil {
+			t.Fatal(err)
+		}
+		stored = append(stored, piece)
+	}
+
+	piece, err := db.GetPiece(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if piece.Blob != stored[1].Blob {
+		t.Fatalf("expected blob %v got %v", stored[1].Blob, piece.Blob)
+	}
+
+	if blob, err := db.Storage().Open(ctx, stored[0]); err == nil {
+		_ = blob.Close()
+		t.Fatal("blob of the replaced piece not deleted")
+	}
+	blob, err := db.Storage().Open(ctx, stored[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = blob.Close()
+}
+
+func TestQuarantine(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	for _, id := range []string{"healthy", "corrupted"} {
+		if err := db.AddPiece(id, pstore.Piece{Blob: storage.BlobRef{1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the pieces count as verified when added
+	ids, err := db.UnverifiedPieces(time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("expected no unverified pieces got %v", ids)
+	}
+
+	ids, err = db.UnverifiedPieces(time.Now().Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 unverified pieces got %v", ids)
+	}
+
+	// the hash is recorded for the pieces without one
+	hash := []byte("hash")
+	if err := db.SetPieceVerified("healthy", hash, time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	piece, err := db.GetPiece("healthy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, piece.Hash) {
+		t.Fatalf("expected %v got %v", hash, piece.Hash)
+	}
+	if err := db.SetPieceVerified("healthy", []byte("other"), time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	piece, err = db.GetPiece("healthy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, piece.Hash) {
+		t.Fatalf("expected %v got %v", hash, piece.Hash)
+	}
+
+	ids, err = db.UnverifiedPieces(time.Now().Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"corrupted"}, ids) {
+		t.Fatalf("expected [corrupted] got %v", ids)
+	}
+
+	if err := db.QuarantinePiece("corrupted", "bit rot"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.GetPiece("corrupted"); err != sql.ErrNoRows {
+		t.Fatalf("expected %v got %v", sql.ErrNoRows, err)
+	}
+
+	quarantined, err := db.GetQuarantinedPieces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quarantined) != 1 || quarantined[0].ID != "corrupted" || quarantined[0].Reason != "bit rot" {
+		t.Fatalf("expected the corrupted piece got %v", quarantined)
+	}
+}
+
+func TestSatelliteUsage(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	satellite1 := teststorj.NodeIDFromString("satellite1")
+	satellite2 := teststorj.NodeIDFromString("satellite2")
+	for i, usage := range []struct {
+		satelliteID storj.NodeID
+		size        int64
+	}{
+		{satellite1, 10},
+		{satellite1, 20},
+		{satellite2, 5},
+		{storj.NodeID{}, 100},
+	} {
+		if err := db.AddTTL("piece"+strconv.Itoa(i), usage.satelliteID, 0, usage.size); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddBandwidthUsed(usage.satelliteID, usage.size); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the usage of unknown satellites counts in the totals only
+	expected := map[storj.NodeID]int64{satellite1: 30, satellite2: 5}
+	sums, err := db.SumTTLSizesBySatellite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, sums) {
+		t.Fatalf("expected %v got %v", expected, sums)
+	}
+
+	sums, err = db.GetBandwidthBetweenBySatellite(time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, sums) {
+		t.Fatalf("expected %v got %v", expected, sums)
+	}
+
+	total, err := db.GetTotalBandwidthBetween(time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 135 {
+		t.Fatalf("expected %d got %d", 135, total)
+	}
+	total, err = db.GetBandwidthUsedByDay(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 135 {
+		t.Fatalf("expected %d got %d", 135, total)
+	}
+}
+
+func TestMigrateSatellites(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "storj-psdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpdir) }()
+	dbpath := filepath.Join(tmpdir, "psdb.db")
+
+	// a database created before the satellites were recorded
+	satelliteID := teststorj.NodeIDFromString("satellite")
+	old, err := sql.Open("sqlite3", dbpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range []string{
+		"CREATE TABLE `ttl` (`id` BLOB UNIQUE, `created` INT(10), `expires` INT(10), `size` INT(10));",
+		"CREATE TABLE `bwusagetbl` (`size` INT(10), `daystartdate` INT(10), `dayenddate` INT(10));",
+		"CREATE TABLE `pieces` (`id` BLOB UNIQUE, `namespace` BLOB, `blob` BLOB, `hashes` BLOB, `hash` BLOB, `verified` INT(10));",
+		"INSERT INTO ttl (id, created, expires, size) VALUES ('piece', 0, 0, 10);",
+		"INSERT INTO ttl (id, created, expires, size) VALUES ('legacy', 0, 0, 20);",
+		"INSERT INTO bwusagetbl (size, daystartdate, dayenddate) VALUES (30, 0, 86400);",
+	} {
+		if _, err := old.Exec(query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := old.Exec("INSERT INTO pieces (id, namespace) VALUES ('piece', ?);", satelliteID.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := Open(ctx, filepath.Join(tmpdir, "data"), dbpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// the satellite of the pieces is the namespace of their blobs
+	sums, err := db.SumTTLSizesBySatellite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[storj.NodeID]int64{satelliteID: 10}
+	if !reflect.DeepEqual(expected, sums) {
+		t.Fatalf("expected %v got %v", expected, sums)
+	}
+	total, err := db.SumTTLSizes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 30 {
+		t.Fatalf("expected %d got %d", 30, total)
+	}
+
+	if err := db.AddBandwidthUsed(satelliteID, 5); err != nil {
+		t.Fatal(err)
+	}
+	sums, err = db.GetBandwidthBetweenBySatellite(time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = map[storj.NodeID]int64{satelliteID: 5}
+	if !reflect.DeepEqual(expected, sums) {
+		t.Fatalf("expected %v got %v", expected, sums)
+	}
+}
+
 func BenchmarkWriteBandwidthAllocation(b *testing.B) {
 	db, cleanup := newDB(b)
 	defer cleanup()
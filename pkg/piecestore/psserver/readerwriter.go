@@ -18,6 +18,11 @@ var StreamWriterError = errs.Class("stream writer error")
 type StreamWriter struct {
 	server *Server
 	stream pb.PieceStoreRoutes_RetrieveServer
+
+	// the proof of the range, sent with its first content
+	shareSize   int64
+	shareHashes [][]byte
+	proof       [][]byte
 }
 
 // NewStreamWriter returns a new StreamWriter
@@ -27,14 +32,26 @@ func NewStreamWriter(s *Server, stream pb.PieceStoreRoutes_RetrieveServer) *Stre
 
 // Write -- Write method for piece upload to stream for Server.Retrieve
 func (s *StreamWriter) Write(b []byte) (int, error) {
+	msg := &pb.PieceRetrievalStream{PieceSize: int64(len(b)), Content: b}
+	if s.shareHashes != nil {
+		msg.ShareSize, msg.ShareHashes, msg.Proof = s.shareSize, s.shareHashes, s.proof
+		s.shareHashes, s.proof = nil, nil
+	}
+
 	// Write the buffer to the stream we opened earlier
-	if err := s.stream.Send(&pb.PieceRetrievalStream{PieceSize: int64(len(b)), Content: b}); err != nil {
+	if err := s.stream.Send(msg); err != nil {
 		return 0, err
 	}
 
 	return len(b), nil
 }
 
+// setProof sets the hashes of the erasure shares of the range being written,
+// and their proof, to be sent with the first write
+func (s *StreamWriter) setProof(shareSize int64, shareHashes, proof [][]byte) {
+	s.shareSize, s.shareHashes, s.proof = shareSize, shareHashes, proof
+}
+
 // StreamReader is a struct for Retrieving data from server
 type StreamReader struct {
 	src                 *utils.ReaderSource
@@ -46,7 +63,7 @@ type StreamReader struct {
 }
 
 // NewStreamReader returns a new StreamReader for Server.Store
-func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, bandwidthRemaining, spaceRemaining int64) *StreamReader {
+func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, satellite *authorizer, bandwidthRemaining, spaceRemaining int64) *StreamReader {
 	sr := &StreamReader{
 		bandwidthRemaining: bandwidthRemaining,
 		spaceRemaining:     spaceRemaining,
@@ -72,12 +89,7 @@ func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, bandwidt
 				return nil, err
 			}
 
-			pbaData := &pb.PayerBandwidthAllocation_Data{}
-			if err = proto.Unmarshal(deserializedData.GetPayerAllocation().GetData(), pbaData); err != nil {
-				return nil, err
-			}
-
-			if err = s.verifyPayerAllocation(pbaData, pb.PayerBandwidthAllocation_PUT); err != nil {
+			if err = s.verifyPayerAllocation(deserializedData.GetPayerAllocation(), pb.PayerBandwidthAllocation_PUT, satellite); err != nil {
 				return nil, err
 			}
 
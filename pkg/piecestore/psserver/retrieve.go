@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/utils"
@@ -41,6 +42,10 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 	if err := s.verifier(authorization); err != nil {
 		return ServerError.Wrap(err)
 	}
+	satellite, err := s.verifyTrusted(authorization)
+	if err != nil {
+		return err
+	}
 
 	pd := recv.GetPieceData()
 	if pd == nil {
@@ -58,28 +63,46 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 		return err
 	}
 
-	// Get path to data being retrieved
-	path, err := pstore.PathByID(id, s.DataDir)
-	if err != nil {
+	if err := pstore.CheckID(id); err != nil {
 		return err
 	}
 
-	// Verify that the path exists
-	fileInfo, err := os.Stat(path)
+	// Get the blobs of the piece being retrieved
+	piece, err := s.getPiece(id)
+	if err != nil {
+		return RetrieveError.Wrap(err)
+	}
+
+	// the satellite may not use more than its allocated bandwidth
+	_, bwLeft, err := s.satelliteAllocationLeft(getSatelliteID(piece.Namespace))
+	if err != nil {
+		return RetrieveError.Wrap(err)
+	}
+	if bwLeft <= 0 {
+		return RetrieveError.New("out of bandwidth allocated to the satellite")
+	}
+
+	fileSize, err := s.pieceSize(ctx, piece)
 	if err != nil {
 		return RetrieveError.Wrap(err)
 	}
 
 	// Read the size specified
 	totalToRead := pd.GetPieceSize()
-	fileSize := fileInfo.Size()
 
 	// Read the entire file if specified -1 but make sure we do it from the correct offset
 	if pd.GetPieceSize() <= -1 || totalToRead+pd.GetOffset() > fileSize {
 		totalToRead = fileSize - pd.GetOffset()
 	}
 
-	retrieved, allocated, err := s.retrieveData(ctx, stream, id, pd.GetOffset(), totalToRead)
+	writer := NewStreamWriter(s, stream)
+	if pd.GetProof() {
+		if err := s.proveRange(ctx, writer, piece, pd.GetOffset(), totalToRead); err != nil {
+			return RetrieveError.Wrap(err)
+		}
+	}
+
+	retrieved, allocated, err := s.retrieveData(ctx, stream, writer, piece, satellite, pd.GetOffset(), totalToRead)
 	if err != nil {
 		return err
 	}
@@ -92,17 +115,41 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 	return nil
 }
 
-func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, id string, offset, length int64) (retrieved, allocated int64, err error) {
+// proveRange sets the hashes of the erasure shares of the range, and their
+// proof, to be sent with its first content
+func (s *Server) proveRange(ctx context.Context, writer *StreamWriter, piece pstore.Piece, offset, length int64) error {
+	shareSize, hashes, err := s.storage.RetrieveHashes(ctx, piece)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errs.New("piece stored without hashes")
+		}
+		return err
+	}
+	if shareSize <= 0 || offset%shareSize != 0 {
+		return errs.New("range offset %d not aligned to erasure shares of %d bytes", offset, shareSize)
+	}
+
+	start := offset / shareSize
+	end := (offset + length + shareSize - 1) / shareSize
+	proof, err := merkle.Prove(hashes, int(start), int(end))
+	if err != nil {
+		return err
+	}
+
+	writer.setProof(shareSize, hashes[start:end], proof)
+	return nil
+}
+
+func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_RetrieveServer, writer *StreamWriter, piece pstore.Piece, satellite *authorizer, offset, length int64) (retrieved, allocated int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	storeFile, err := pstore.RetrieveReader(ctx, id, offset, length, s.DataDir)
+	storeFile, err := s.storage.RetrieveReader(ctx, piece, offset, length)
 	if err != nil {
 		return 0, 0, err
 	}
 
 	defer utils.LogClose(storeFile)
 
-	writer := NewStreamWriter(s, stream)
 	allocationTracking := sync2.NewThrottle()
 	totalAllocated := int64(0)
 
@@ -145,13 +192,7 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 				return
 			}
 
-			pbaData := &pb.PayerBandwidthAllocation_Data{}
-			if err = proto.Unmarshal(allocData.GetPayerAllocation().GetData(), pbaData); err != nil {
-				allocationTracking.Fail(err)
-				return
-			}
-
-			if err = s.verifyPayerAllocation(pbaData, pb.PayerBandwidthAllocation_GET); err != nil {
+			if err = s.verifyPayerAllocation(allocData.GetPayerAllocation(), pb.PayerBandwidthAllocation_GET, satellite); err != nil {
 				allocationTracking.Fail(err)
 				return
 			}
@@ -202,7 +243,7 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 	}
 
 	// write to bandwidth usage table
-	if err = s.DB.AddBandwidthUsed(used); err != nil {
+	if err = s.DB.AddBandwidthUsed(getSatelliteID(piece.Namespace), used); err != nil {
 		return retrieved, allocated, StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
 
@@ -0,0 +1,162 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+var (
+	// ScrubberError is the error class of the scrubber
+	ScrubberError = errs.Class("scrubber error")
+)
+
+const (
+	// scrubBatchSize is the number of pieces the scrubber looks up at once
+	scrubBatchSize = 100
+	// scrubIdleInterval is how long the scrubber waits once all the pieces
+	// are verified
+	scrubIdleInterval = time.Hour
+)
+
+// Scrubber re-reads the stored pieces to detect their silent corruption,
+// quarantining the corrupt ones
+type Scrubber struct {
+	log      *zap.Logger
+	db       *psdb.DB
+	storage  *pstore.Storage
+	rate     int64
+	interval time.Duration
+}
+
+// NewScrubber returns a scrubber verifying each piece in storage indexed in
+// db every interval, re-reading at most rate bytes per second, or without
+// limit if rate is not positive
+func NewScrubber(log *zap.Logger, db *psdb.DB, storage *pstore.Storage, rate int64, interval time.Duration) *Scrubber {
+	return &Scrubber{
+		log:      log,
+		db:       db,
+		storage:  storage,
+		rate:     rate,
+		interval: interval,
+	}
+}
+
+// Run runs the scrubber until ctx is canceled
+func (scrubber *Scrubber) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		verified, err := scrubber.process(ctx, time.Now().Add(-scrubber.interval))
+		if err != nil {
+			scrubber.log.Error("process", zap.Error(err))
+		}
+
+		// wait for more pieces to verify if none were
+		wait := time.Duration(0)
+		if verified == 0 {
+			wait = scrubIdleInterval
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// VerifyAll verifies every stored piece not verified since it started
+//	returns the number of verified pieces and of the corrupt ones
+func (scrubber *Scrubber) VerifyAll(ctx context.Context) (verified, corrupted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	before := time.Now()
+	for {
+		ids, err := scrubber.db.UnverifiedPieces(before, scrubBatchSize)
+		if err != nil {
+			return verified, corrupted, ScrubberError.Wrap(err)
+		}
+		if len(ids) == 0 {
+			return verified, corrupted, nil
+		}
+
+		for _, id := range ids {
+			corrupt, err := scrubber.verify(ctx, id)
+			if err != nil {
+				return verified, corrupted, err
+			}
+			verified++
+			if corrupt {
+				corrupted++
+			}
+		}
+	}
+}
+
+// process verifies a batch of the pieces not verified since before
+func (scrubber *Scrubber) process(ctx context.Context, before time.Time) (verified int, err error) {
+	ids, err := scrubber.db.UnverifiedPieces(before, scrubBatchSize)
+	if err != nil {
+		return 0, ScrubberError.Wrap(err)
+	}
+
+	for _, id := range ids {
+		if _, err := scrubber.verify(ctx, id); err != nil {
+			return verified, err
+		}
+		verified++
+	}
+	return verified, nil
+}
+
+// verify verifies the piece with id, quarantining it if corrupt, then
+// waits for the time reading it takes at the rate of the scrubber
+func (scrubber *Scrubber) verify(ctx context.Context, id string) (corrupt bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	piece, err := scrubber.db.GetPiece(id)
+	if err == sql.ErrNoRows {
+		// deleted since
+		return false, nil
+	}
+	if err != nil {
+		return false, ScrubberError.Wrap(err)
+	}
+
+	hash, size, err := scrubber.storage.Verify(ctx, piece)
+	switch {
+	case pstore.ErrCorrupted.Has(err):
+		mon.Meter("corrupted_pieces").Mark(1)
+		scrubber.log.Error("quarantining corrupted piece", zap.String("Piece ID", id), zap.Error(err))
+		if err := scrubber.db.QuarantinePiece(id, err.Error()); err != nil {
+			return true, ScrubberError.Wrap(err)
+		}
+		corrupt = true
+	case err != nil:
+		return false, ScrubberError.Wrap(err)
+	default:
+		if err := scrubber.db.SetPieceVerified(id, hash, time.Now()); err != nil {
+			return false, ScrubberError.Wrap(err)
+		}
+	}
+	mon.Meter("scrubbed_bytes").Mark64(size)
+
+	if scrubber.rate > 0 {
+		select {
+		case <-time.After(time.Duration(size) * time.Second / time.Duration(scrubber.rate)):
+		case <-ctx.Done():
+			return corrupt, ctx.Err()
+		}
+	}
+	return corrupt, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestScrubber(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	for _, id := range []string{"11111111111111111111", "22222222222222222222"} {
+		if err := writeFileToServer(s, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// rot the content of the second piece, keeping its recorded hash
+	piece, err := s.DB.GetPiece("22222222222222222222")
+	if !assert.NoError(t, err) {
+		return
+	}
+	rotten, _, err := s.storage.Store(ctx, piece.Namespace, bytes.NewReader([]byte("bitts")), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	rotten.Hash = piece.Hash
+	assert.NoError(t, s.DB.DeletePiece("22222222222222222222"))
+	assert.NoError(t, s.DB.AddPiece("22222222222222222222", rotten))
+
+	scrubber := NewScrubber(zaptest.NewLogger(t), s.DB, s.storage, 0, time.Hour)
+
+	// the pieces were verified when stored
+	verified, err := scrubber.process(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, verified)
+
+	for _, id := range []string{"11111111111111111111", "22222222222222222222"} {
+		assert.NoError(t, s.DB.SetPieceVerified(id, nil, time.Now().Add(-2*time.Hour)))
+	}
+
+	verified, corrupted, err := scrubber.VerifyAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, verified)
+	assert.Equal(t, 1, corrupted)
+
+	_, err = s.DB.GetPiece("11111111111111111111")
+	assert.NoError(t, err)
+	has, err := s.DB.HasPiece("22222222222222222222")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	quarantined, err := s.DB.GetQuarantinedPieces()
+	assert.NoError(t, err)
+	if assert.Len(t, quarantined, 1) {
+		assert.Equal(t, "22222222222222222222", quarantined[0].ID)
+	}
+
+	// the piece is no longer retrievable
+	_, err = s.Piece(ctx, &pb.PieceId{Id: "22222222222222222222"})
+	assert.True(t, ServerError.Has(err), err)
+}
+
+func TestScrubberUnreadablePiece(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	for _, id := range []string{"11111111111111111111", "22222222222222222222", "33333333333333333333"} {
+		if err := writeFileToServer(s, id); err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, s.DB.SetPieceVerified(id, nil, time.Now().Add(-2*time.Hour)))
+	}
+
+	// replace the content of the second piece with a directory, which can be
+	// opened but fails to be read
+	piece, err := s.DB.GetPiece("22222222222222222222")
+	if !assert.NoError(t, err) {
+		return
+	}
+	name := hex.EncodeToString(piece.Blob[:])[2:]
+	var blobPath string
+	err = filepath.Walk(s.DataDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && info.Name() == name {
+			blobPath = path
+		}
+		return err
+	})
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, blobPath) {
+		return
+	}
+	assert.NoError(t, os.Remove(blobPath))
+	assert.NoError(t, os.Mkdir(blobPath, 0700))
+
+	scrubber := NewScrubber(zaptest.NewLogger(t), s.DB, s.storage, 0, time.Hour)
+
+	// the unreadable piece is quarantined and the other pieces still verified
+	verified, err := scrubber.process(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, verified)
+
+	has, err := s.DB.HasPiece("22222222222222222222")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	quarantined, err := s.DB.GetQuarantinedPieces()
+	assert.NoError(t, err)
+	if assert.Len(t, quarantined, 1) {
+		assert.Equal(t, "22222222222222222222", quarantined[0].ID)
+	}
+
+	verified, err = scrubber.process(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, verified)
+}
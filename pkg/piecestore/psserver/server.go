@@ -8,13 +8,17 @@ import (
 	"crypto/ecdsa"
 	"crypto/hmac"
 	"crypto/sha512"
+	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
 	"github.com/mr-tron/base58/base58"
 	"github.com/shirou/gopsutil/disk"
@@ -27,7 +31,10 @@ import (
 	"storj.io/storj/pkg/peertls"
 	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -58,14 +65,18 @@ type Server struct {
 	log              *zap.Logger
 	DataDir          string
 	DB               *psdb.DB
+	storage          *pstore.Storage
 	pkey             crypto.PrivateKey
 	totalAllocated   int64
 	totalBwAllocated int64
 	verifier         auth.SignedMessageVerifier
+	trusted          *trust.List  // nil trusts any satellite
+	allocations      *Allocations // nil limits no satellite
 }
 
-// NewEndpoint -- initializes a new endpoint for a piecestore server
-func NewEndpoint(log *zap.Logger, config Config, db *psdb.DB, pkey crypto.PrivateKey) (*Server, error) {
+// NewEndpoint -- initializes a new endpoint for a piecestore server storing
+// for the trusted satellites
+func NewEndpoint(log *zap.Logger, config Config, db *psdb.DB, pkey crypto.PrivateKey, trusted *trust.List) (*Server, error) {
 
 	// read the allocated disk space from the config file
 	allocatedDiskSpace := config.AllocatedDiskSpace
@@ -98,6 +109,14 @@ func NewEndpoint(log *zap.Logger, config Config, db *psdb.DB, pkey crypto.Privat
 		log.Info("Remaining Bandwidth", zap.Int64("bytes", allocatedBandwidth-usedBandwidth))
 	}
 
+	allocations, err := ParseAllocations(Allocation{
+		DiskSpace: config.SatelliteAllocatedDiskSpace,
+		Bandwidth: config.SatelliteAllocatedBandwidth,
+	}, config.SatelliteAllocations)
+	if err != nil {
+		return nil, err
+	}
+
 	// check your hard drive is big enough
 	// first time setup as a piece node server
 	if (totalUsed == 0x00) && (freeDiskSpace < allocatedDiskSpace) {
@@ -119,14 +138,18 @@ func NewEndpoint(log *zap.Logger, config Config, db *psdb.DB, pkey crypto.Privat
 		log.Warn("Disk space is less than requested. Allocating space", zap.Int64("bytes", allocatedDiskSpace))
 	}
 
+	dataDir := filepath.Join(config.Path, "piece-store-data")
 	return &Server{
 		log:              log,
-		DataDir:          filepath.Join(config.Path, "piece-store-data"),
+		DataDir:          dataDir,
 		DB:               db,
+		storage:          db.Storage(),
 		pkey:             pkey,
 		totalAllocated:   allocatedDiskSpace,
 		totalBwAllocated: allocatedBandwidth,
 		verifier:         auth.NewSignedMessageVerifier(),
+		trusted:          trusted,
+		allocations:      allocations,
 	}, nil
 }
 
@@ -136,6 +159,7 @@ func New(log *zap.Logger, dataDir string, db *psdb.DB, config Config, pkey crypt
 		log:              log,
 		DataDir:          dataDir,
 		DB:               db,
+		storage:          db.Storage(),
 		pkey:             pkey,
 		totalAllocated:   config.AllocatedDiskSpace,
 		totalBwAllocated: config.AllocatedBandwidth,
@@ -143,6 +167,17 @@ func New(log *zap.Logger, dataDir string, db *psdb.DB, config Config, pkey crypt
 	}
 }
 
+// MigratePieces moves the pieces stored before they were kept in blob
+// stores into them, and deletes the blobs whose deletion was interrupted
+func (s *Server) MigratePieces(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := s.storage.Migrate(ctx, s.DataDir, s.DB); err != nil {
+		return ServerError.Wrap(err)
+	}
+	return ServerError.Wrap(s.storage.GarbageCollect(ctx))
+}
+
 // Stop the piececstore node
 func (s *Server) Stop(ctx context.Context) (err error) {
 	return s.DB.Close()
@@ -162,8 +197,7 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, err
 	}
 
-	path, err := pstore.PathByID(id, s.DataDir)
-	if err != nil {
+	if err := pstore.CheckID(id); err != nil {
 		return nil, err
 	}
 
@@ -176,7 +210,12 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 		return nil, ServerError.New("invalid ID")
 	}
 
-	fileInfo, err := os.Stat(path)
+	piece, err := s.getPiece(id)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := s.pieceSize(ctx, piece)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +227,7 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 	}
 
 	s.log.Debug("Successfully retrieved meta", zap.String("Piece ID", in.GetId()))
-	return &pb.PieceSummary{Id: in.GetId(), PieceSize: fileInfo.Size(), ExpirationUnixSec: ttl}, nil
+	return &pb.PieceSummary{Id: in.GetId(), PieceSize: size, ExpirationUnixSec: ttl}, nil
 }
 
 // Stats will return statistics about the Server
@@ -205,7 +244,92 @@ func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, e
 		return nil, err
 	}
 
-	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}, nil
+	satellites, err := s.satelliteStats()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth), Satellites: satellites}, nil
+}
+
+// satelliteStats returns the statistics of the trusted satellites, then of
+// the other ones using the node or given a specific allocation
+func (s *Server) satelliteStats() ([]*pb.SatelliteStats, error) {
+	usedSpace, err := s.DB.SumTTLSizesBySatellite()
+	if err != nil {
+		return nil, err
+	}
+	usedBandwidth, err := s.DB.GetBandwidthBetweenBySatellite(getBeginningOfMonth(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	stat := func(id storj.NodeID, address string) *pb.SatelliteStats {
+		allocation := s.allocations.Get(id)
+		return &pb.SatelliteStats{
+			SatelliteId:        id,
+			Address:            address,
+			Trusted:            s.trusted.IsTrusted(id),
+			UsedSpace:          usedSpace[id],
+			UsedBandwidth:      usedBandwidth[id],
+			AllocatedSpace:     allocation.DiskSpace,
+			AllocatedBandwidth: allocation.Bandwidth,
+		}
+	}
+
+	var stats []*pb.SatelliteStats
+	listed := make(map[storj.NodeID]bool)
+	for _, satellite := range s.trusted.Satellites() {
+		stats = append(stats, stat(satellite.ID, satellite.Address))
+		listed[satellite.ID] = true
+	}
+
+	var others storj.NodeIDList
+	addOthers := func(ids storj.NodeIDList) {
+		for _, id := range ids {
+			if !listed[id] {
+				others = append(others, id)
+				listed[id] = true
+			}
+		}
+	}
+	for id := range usedSpace {
+		addOthers(storj.NodeIDList{id})
+	}
+	for id := range usedBandwidth {
+		addOthers(storj.NodeIDList{id})
+	}
+	addOthers(s.allocations.Satellites())
+
+	sort.Slice(others, func(i, k int) bool { return others[i].Less(others[k]) })
+	for _, id := range others {
+		stats = append(stats, stat(id, ""))
+	}
+	return stats, nil
+}
+
+// satelliteAllocationLeft returns the disk space and the bandwidth left to
+// the satellite with satelliteID from its allocation, math.MaxInt64 when not
+// limited
+func (s *Server) satelliteAllocationLeft(satelliteID storj.NodeID) (spaceLeft, bwLeft int64, err error) {
+	spaceLeft, bwLeft = math.MaxInt64, math.MaxInt64
+
+	allocation := s.allocations.Get(satelliteID)
+	if allocation.DiskSpace > 0 {
+		usedSpace, err := s.DB.SumTTLSizesBySatellite()
+		if err != nil {
+			return 0, 0, err
+		}
+		spaceLeft = allocation.DiskSpace - usedSpace[satelliteID]
+	}
+	if allocation.Bandwidth > 0 {
+		usedBandwidth, err := s.DB.GetBandwidthBetweenBySatellite(getBeginningOfMonth(), time.Now())
+		if err != nil {
+			return 0, 0, err
+		}
+		bwLeft = allocation.Bandwidth - usedBandwidth[satelliteID]
+	}
+	return spaceLeft, bwLeft, nil
 }
 
 // Delete -- Delete data by Id from piecestore
@@ -221,18 +345,70 @@ func (s *Server) Delete(ctx context.Context, in *pb.PieceDelete) (*pb.PieceDelet
 	if err != nil {
 		return nil, err
 	}
-	if err := s.deleteByID(id); err != nil {
+	if err := s.deleteByID(ctx, id); err != nil {
 		return nil, err
 	}
 
 	return &pb.PieceDeleteSummary{Message: OK}, nil
 }
 
-func (s *Server) deleteByID(id string) error {
-	if err := pstore.Delete(id, s.DataDir); err != nil {
+// pieceSize returns the size of the content of piece
+func (s *Server) pieceSize(ctx context.Context, piece pstore.Piece) (int64, error) {
+	blob, err := s.storage.Open(ctx, piece)
+	if err != nil {
+		return 0, err
+	}
+	size := blob.Size()
+	return size, blob.Close()
+}
+
+// getPiece finds the blobs of the piece with id
+func (s *Server) getPiece(id string) (pstore.Piece, error) {
+	piece, err := s.DB.GetPiece(id)
+	if err == sql.ErrNoRows {
+		return pstore.Piece{}, ServerError.New("piece %s not found", id)
+	}
+	return piece, err
+}
+
+// OpenPiece opens the content of the piece with id stored for the satellite
+// of namespace, as the pieces are read to transfer them to other nodes
+func (s *Server) OpenPiece(ctx context.Context, namespace []byte, id string) (storage.ReadSeekCloser, error) {
+	namespacedID, err := getNamespacedPieceID([]byte(id), namespace)
+	if err != nil {
+		return nil, err
+	}
+	piece, err := s.getPiece(namespacedID)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.Open(ctx, piece)
+}
+
+func (s *Server) deleteByID(ctx context.Context, id string) error {
+	if err := pstore.CheckID(id); err != nil {
 		return err
 	}
 
+	piece, err := s.DB.GetPiece(id)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil {
+		// the piece is no longer visible before its blobs are deleted, so
+		// an interrupted delete leaves no piece without its content. A
+		// concurrent delete of the piece deletes its blobs.
+		err := s.DB.DeletePiece(id)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil {
+			if err := s.storage.Delete(ctx, piece); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := s.DB.DeleteTTLByID(id); err != nil {
 		return err
 	}
@@ -260,16 +436,67 @@ func (s *Server) verifySignature(ctx context.Context, ba *pb.RenterBandwidthAllo
 	return nil
 }
 
-func (s *Server) verifyPayerAllocation(pba *pb.PayerBandwidthAllocation_Data, action pb.PayerBandwidthAllocation_Action) (err error) {
+// verifyPayerAllocation checks the payer bandwidth allocation of a request
+// authorized by satellite, which has to have signed it when only some
+// satellites are trusted
+func (s *Server) verifyPayerAllocation(pba *pb.PayerBandwidthAllocation, action pb.PayerBandwidthAllocation_Action, satellite *authorizer) (err error) {
+	data := &pb.PayerBandwidthAllocation_Data{}
+	if err := proto.Unmarshal(pba.GetData(), data); err != nil {
+		return err
+	}
+
 	switch {
-	case pba.SatelliteId.IsZero():
+	case data.SatelliteId.IsZero():
 		return StoreError.New("payer bandwidth allocation: missing satellite id")
-	case pba.UplinkId.IsZero():
+	case data.UplinkId.IsZero():
 		return StoreError.New("payer bandwidth allocation: missing uplink id")
-	case pba.Action != action:
-		return StoreError.New("payer bandwidth allocation: invalid action %v", pba.Action.String())
+	case data.Action != action:
+		return StoreError.New("payer bandwidth allocation: invalid action %v", data.Action.String())
 	}
-	return nil
+	if s.trusted.TrustsAll() {
+		return nil
+	}
+
+	// the satellite id is only trusted once the satellite proved it signed
+	// the allocation
+	if satellite == nil || data.SatelliteId != satellite.id {
+		return trust.ErrUntrusted.New("%s: payer bandwidth allocation not from the authorizing satellite", data.SatelliteId)
+	}
+	if !cryptopasta.Verify(pba.GetData(), pba.GetSignature(), satellite.key) {
+		return trust.ErrUntrusted.New("%s: failed to verify payer bandwidth allocation signature", data.SatelliteId)
+	}
+	return s.trusted.Verify(data.SatelliteId)
+}
+
+// authorizer is the satellite which authorized a request
+type authorizer struct {
+	id  storj.NodeID
+	key *ecdsa.PublicKey
+}
+
+// verifyTrusted checks that the satellite authorizing a request, whose
+// signature was verified, is trusted, returning it unless any satellite is
+// trusted. The satellite has to prove its id with its certificate chain, as
+// any node can claim it.
+func (s *Server) verifyTrusted(authorization *pb.SignedMessage) (*authorizer, error) {
+	if s.trusted.TrustsAll() {
+		return nil, nil
+	}
+	if err := auth.VerifySigner(authorization); err != nil {
+		return nil, trust.ErrUntrusted.Wrap(err)
+	}
+	satelliteID, err := storj.NodeIDFromBytes(getNamespace(authorization))
+	if err != nil {
+		return nil, trust.ErrUntrusted.Wrap(err)
+	}
+	if err := s.trusted.Verify(satelliteID); err != nil {
+		return nil, err
+	}
+	key, err := cryptopasta.DecodePublicKey(authorization.GetPublicKey())
+	if err != nil {
+		return nil, trust.ErrUntrusted.Wrap(err)
+	}
+	return &authorizer{id: satelliteID, key: key}, nil
 }
 
 func getBeginningOfMonth() time.Time {
@@ -295,3 +522,13 @@ func getNamespacedPieceID(pieceID, namespace []byte) (string, error) {
 func getNamespace(signedMessage *pb.SignedMessage) []byte {
 	return signedMessage.GetData()
 }
+
+// getSatelliteID returns the id of the satellite of namespace, or the zero id
+// if the namespace is not a satellite's
+func getSatelliteID(namespace []byte) storj.NodeID {
+	satelliteID, err := storj.NodeIDFromBytes(namespace)
+	if err != nil {
+		return storj.NodeID{}
+	}
+	return satelliteID
+}
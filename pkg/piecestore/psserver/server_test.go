@@ -14,11 +14,11 @@ import (
 	"math"
 	"net"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
@@ -30,39 +30,36 @@ import (
 
 	"storj.io/storj/internal/testidentity"
 	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/pb"
-	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/piecestore/psserver/trust"
+	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
 )
 
 var ctx = context.Background()
 
-func writeFileToDir(name, dir string) error {
-	file, err := pstore.StoreWriter(name, dir)
+func writeFileToServer(s *Server, name string) error {
+	piece, _, err := s.storage.Store(ctx, nil, bytes.NewReader([]byte("butts")), 0)
 	if err != nil {
 		return err
 	}
-
-	// Close when finished
-	_, err = io.Copy(file, bytes.NewReader([]byte("butts")))
-	if err != nil {
-		_ = file.Close()
-		return err
-	}
-	return file.Close()
+	return s.DB.AddPiece(name, piece)
 }
 
 func TestPiece(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
 
-	if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+	if err := writeFileToServer(TS.s, "11111111111111111111"); err != nil {
 		t.Errorf("Error: %v\nCould not create test piece", err)
 		return
 	}
 
-	defer func() { _ = pstore.Delete("11111111111111111111", TS.s.DataDir) }()
+	defer func() { _ = TS.s.deleteByID(ctx, "11111111111111111111") }()
 
 	// set up test cases
 	tests := []struct {
@@ -87,7 +84,7 @@ func TestPiece(t *testing.T) {
 			id:         "22222222222222222222",
 			size:       5,
 			expiration: 9999999999,
-			err:        fmt.Sprintf("rpc error: code = Unknown desc = stat %s: no such file or directory", path.Join(TS.s.DataDir, "/22/22/2222222222222222")),
+			err:        "rpc error: code = Unknown desc = PSServer error: piece 22222222222222222222 not found",
 		},
 		{ // server should err with invalid TTL
 			id:         "22222222222222222222;DELETE*FROM TTL;;;;",
@@ -139,12 +136,12 @@ func TestRetrieve(t *testing.T) {
 	defer TS.Stop()
 
 	// simulate piece stored with storagenode
-	if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+	if err := writeFileToServer(TS.s, "11111111111111111111"); err != nil {
 		t.Errorf("Error: %v\nCould not create test piece", err)
 		return
 	}
 
-	defer func() { _ = pstore.Delete("11111111111111111111", TS.s.DataDir) }()
+	defer func() { _ = TS.s.deleteByID(ctx, "11111111111111111111") }()
 
 	// set up test cases
 	tests := []struct {
@@ -208,7 +205,7 @@ func TestRetrieve(t *testing.T) {
 			allocSize: 5,
 			offset:    0,
 			content:   []byte("butts"),
-			err:       fmt.Sprintf("rpc error: code = Unknown desc = retrieve error: stat %s: no such file or directory", path.Join(TS.s.DataDir, "/22/22/2222222222222222")),
+			err:       "rpc error: code = Unknown desc = retrieve error: PSServer error: piece 22222222222222222222 not found",
 		},
 		{ // server should return expected content and respSize with offset and excess reqSize
 			id:        "11111111111111111111",
@@ -496,6 +493,198 @@ func TestPbaValidation(t *testing.T) {
 	}
 }
 
+// newSatellite returns the identity of a new satellite
+func newSatellite(t *testing.T) *provider.FullIdentity {
+	ca, err := testidentity.NewTestCA(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := ca.NewIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+// authorize returns the authorization of satellite, claiming id
+func authorize(t *testing.T, satellite *provider.FullIdentity, id storj.NodeID) *pb.SignedMessage {
+	signature, err := auth.GenerateSignature(id.Bytes(), satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorization, err := auth.NewSignedMessage(signature, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorization.Data = id.Bytes()
+	return authorization
+}
+
+// payerAllocation returns a put allocation signed by satellite, claiming id
+func payerAllocation(t *testing.T, satellite *provider.FullIdentity, id storj.NodeID) *pb.PayerBandwidthAllocation {
+	data, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		SatelliteId: id,
+		UplinkId:    teststorj.NodeIDFromString("uplinkid"),
+		Action:      pb.PayerBandwidthAllocation_PUT,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := auth.GenerateSignature(data, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.PayerBandwidthAllocation{Data: data, Signature: signature}
+}
+
+// storeForSatellite stores content under id with the authorization and the
+// payer allocation of a satellite
+func storeForSatellite(TS *TestServer, id string, authorization *pb.SignedMessage, pba *pb.PayerBandwidthAllocation, content []byte) error {
+	stream, err := TS.c.Store(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&pb.PieceStore{
+		PieceData:     &pb.PieceStore_PieceData{Id: id, ExpirationUnixSec: 9999999999},
+		Authorization: authorization,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := &pb.PieceStore{
+		PieceData: &pb.PieceStore_PieceData{Content: content},
+		BandwidthAllocation: &pb.RenterBandwidthAllocation{
+			Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+				PayerAllocation: pba,
+				Total:           int64(len(content)),
+			}),
+		},
+	}
+	msg.BandwidthAllocation.Signature, err = cryptopasta.Sign(msg.BandwidthAllocation.Data, TS.k.(*ecdsa.PrivateKey))
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(msg); err != nil && err != io.EOF {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func TestTrustedSatellites(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	trusted := newSatellite(t)
+	untrusted := newSatellite(t)
+	TS.s.trusted = trust.NewList(trust.Satellite{ID: trusted.ID, Address: "127.0.0.1:7777"})
+
+	tests := []struct {
+		authorization *pb.SignedMessage
+		pba           *pb.PayerBandwidthAllocation
+		err           string
+	}{
+		{ // should store for a trusted satellite
+			authorization: authorize(t, trusted, trusted.ID),
+			pba:           payerAllocation(t, trusted, trusted.ID),
+			err:           "",
+		},
+		{ // should err with an untrusted satellite authorizing the piece
+			authorization: authorize(t, untrusted, untrusted.ID),
+			pba:           payerAllocation(t, untrusted, untrusted.ID),
+			err:           "rpc error: code = Unknown desc = untrusted satellite: " + untrusted.ID.String(),
+		},
+		{ // should err with an untrusted satellite paying for the piece
+			authorization: authorize(t, trusted, trusted.ID),
+			pba:           payerAllocation(t, untrusted, untrusted.ID),
+			err:           "rpc error: code = Unknown desc = untrusted satellite: " + untrusted.ID.String() + ": payer bandwidth allocation not from the authorizing satellite",
+		},
+		{ // should err with an authorization forged with the id of a trusted satellite
+			authorization: authorize(t, untrusted, trusted.ID),
+			pba:           payerAllocation(t, untrusted, trusted.ID),
+			err:           fmt.Sprintf("rpc error: code = Unknown desc = untrusted satellite: auth error: signer is not node %x", trusted.ID.Bytes()),
+		},
+		{ // should err with a payer allocation forged with the id of a trusted satellite
+			authorization: authorize(t, trusted, trusted.ID),
+			pba:           payerAllocation(t, untrusted, trusted.ID),
+			err:           "rpc error: code = Unknown desc = untrusted satellite: " + trusted.ID.String() + ": failed to verify payer bandwidth allocation signature",
+		},
+	}
+
+	for i, tt := range tests {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		err := storeForSatellite(TS, fmt.Sprintf("9999999999999999999%d", i), tt.authorization, tt.pba, []byte("butts"))
+		if tt.err != "" {
+			if assert.Error(t, err, errTag) {
+				assert.Equal(t, tt.err, err.Error(), errTag)
+			}
+			continue
+		}
+		assert.NoError(t, err, errTag)
+	}
+
+	// only the piece of the trusted satellite is stored and reported
+	stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*pb.SatelliteStats{{
+			SatelliteId:   trusted.ID,
+			Address:       "127.0.0.1:7777",
+			Trusted:       true,
+			UsedSpace:     5,
+			UsedBandwidth: 5,
+		}}, stats.Satellites)
+	}
+}
+
+func TestSatelliteAllocations(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	limited := newSatellite(t)
+	other := newSatellite(t)
+	allocations, err := ParseAllocations(Allocation{DiskSpace: 8, Bandwidth: 100}, limited.ID.String()+":3:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	TS.s.allocations = allocations
+
+	store := func(id string, satellite *provider.FullIdentity) error {
+		return storeForSatellite(TS, id, authorize(t, satellite, satellite.ID), payerAllocation(t, satellite, satellite.ID), []byte("butts"))
+	}
+
+	// the satellites may not use more than their allocated disk space
+	err = store("11111111111111111111", limited)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "out of space")
+	}
+	assert.NoError(t, store("22222222222222222222", other))
+	err = store("33333333333333333333", other)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "out of space")
+	}
+
+	stats, err := TS.c.Stats(ctx, &pb.StatsReq{})
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, []*pb.SatelliteStats{{
+			SatelliteId:        limited.ID,
+			Trusted:            true,
+			AllocatedSpace:     3,
+			AllocatedBandwidth: 0,
+		}, {
+			SatelliteId:        other.ID,
+			Trusted:            true,
+			UsedSpace:          5,
+			UsedBandwidth:      5,
+			AllocatedSpace:     8,
+			AllocatedBandwidth: 100,
+		}}, stats.Satellites)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	TS := NewTestServer(t)
 	defer TS.Stop()
@@ -530,7 +719,7 @@ func TestDelete(t *testing.T) {
 			assert := assert.New(t)
 
 			// simulate piece stored with storagenode
-			if err := writeFileToDir("11111111111111111111", TS.s.DataDir); err != nil {
+			if err := writeFileToServer(TS.s, "11111111111111111111"); err != nil {
 				t.Errorf("Error: %v\nCould not create test piece", err)
 				return
 			}
@@ -545,7 +734,7 @@ func TestDelete(t *testing.T) {
 			}()
 
 			defer func() {
-				assert.NoError(pstore.Delete("11111111111111111111", TS.s.DataDir))
+				assert.NoError(TS.s.deleteByID(ctx, "11111111111111111111"))
 			}()
 
 			req := &pb.PieceDelete{Id: tt.id}
@@ -559,11 +748,9 @@ func TestDelete(t *testing.T) {
 			assert.NoError(err)
 			assert.Equal(tt.message, resp.GetMessage())
 
-			// if test passes, check if file was indeed deleted
-			filePath, err := pstore.PathByID(tt.id, TS.s.DataDir)
-			assert.NoError(err)
-			if _, err = os.Stat(filePath); os.IsExist(err) {
-				t.Errorf("File not deleted")
+			// if test passes, check if piece was indeed deleted
+			if has, err := TS.s.DB.HasPiece(tt.id); assert.NoError(err) && has {
+				t.Errorf("Piece not deleted")
 				return
 			}
 		})
@@ -591,6 +778,7 @@ func newTestServerStruct(t *testing.T) (*Server, func()) {
 		log:              zaptest.NewLogger(t),
 		DataDir:          tempDir,
 		DB:               psDB,
+		storage:          psDB.Storage(),
 		verifier:         verifier,
 		totalAllocated:   math.MaxInt64,
 		totalBwAllocated: math.MaxInt64,
@@ -687,3 +875,82 @@ func serializeData(ba *pb.RenterBandwidthAllocation_Data) []byte {
 	data, _ := proto.Marshal(ba)
 	return data
 }
+
+func TestRetrieveVerified(t *testing.T) {
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	newPBA := func(action pb.PayerBandwidthAllocation_Action) *pb.PayerBandwidthAllocation {
+		data, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+			SatelliteId: teststorj.NodeIDFromString("satelliteid"),
+			UplinkId:    teststorj.NodeIDFromString("uplinkid"),
+			Action:      action,
+		})
+		assert.NoError(t, err)
+		return &pb.PayerBandwidthAllocation{Data: data}
+	}
+
+	target := &pb.Node{Id: teststorj.NodeIDFromString("storagenode"), Type: pb.NodeType_STORAGE}
+	ps, err := psclient.NewCustomRoute(TS.c, target, 0, TS.k)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id := psclient.PieceID("22222222222222222222")
+	shareSize := int64(64)
+	data := bytes.Repeat([]byte("butts"), 100)
+
+	err = ps.Put(ctx, id, bytes.NewReader(data), time.Now().Add(time.Hour), newPBA(pb.PayerBandwidthAllocation_PUT), nil, shareSize)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	hasher := merkle.NewShareHasher(int(shareSize))
+	_, _ = hasher.Write(data)
+	hash := &psclient.PieceHash{Root: hasher.Root(), ShareSize: shareSize}
+
+	download := func(offset, length int64) ([]byte, error) {
+		rr, err := ps.Get(ctx, id, int64(len(data)), newPBA(pb.PayerBandwidthAllocation_GET), nil, hash)
+		if err != nil {
+			return nil, err
+		}
+		r, err := rr.Range(ctx, offset, length)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { assert.NoError(t, r.Close()) }()
+		return ioutil.ReadAll(r)
+	}
+
+	// the whole piece, and a range ending with the last partial share
+	for _, offset := range []int64{0, 3 * shareSize} {
+		downloaded, err := download(offset, int64(len(data))-offset)
+		assert.NoError(t, err)
+		assert.Equal(t, data[offset:], downloaded)
+	}
+
+	// corrupt the fifth erasure share of the stored piece, keeping the
+	// hashes of the original
+	piece, err := TS.s.DB.GetPiece(id.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	corrupted := append([]byte{}, data...)
+	corrupted[4*shareSize] ^= 0xff
+	corruptedPiece, _, err := TS.s.storage.Store(ctx, piece.Namespace, bytes.NewReader(corrupted), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	corruptedPiece.Hashes = piece.Hashes
+	assert.NoError(t, TS.s.DB.DeletePiece(id.String()))
+	assert.NoError(t, TS.s.DB.AddPiece(id.String(), corruptedPiece))
+
+	// the shares before the corrupted one are still returned
+	downloaded, err := download(0, 4*shareSize)
+	assert.NoError(t, err)
+	assert.Equal(t, data[:4*shareSize], downloaded)
+
+	downloaded, err = download(0, int64(len(data)))
+	assert.True(t, psclient.ErrVerification.Has(err), err)
+	assert.Equal(t, data[:4*shareSize], downloaded)
+}
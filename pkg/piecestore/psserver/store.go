@@ -6,7 +6,6 @@ package psserver
 import (
 	"context"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -40,6 +39,10 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	if err := s.verifier(authorization); err != nil {
 		return ServerError.Wrap(err)
 	}
+	satellite, err := s.verifyTrusted(authorization)
+	if err != nil {
+		return err
+	}
 
 	pd := recv.GetPieceData()
 	if pd == nil {
@@ -56,17 +59,18 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	if err != nil {
 		return err
 	}
-	total, err := s.storeData(ctx, reqStream, id)
+	total, err := s.storeData(ctx, reqStream, id, getNamespace(authorization), satellite, pd.GetShareSize())
 	if err != nil {
 		return err
 	}
 
-	if err = s.DB.AddTTL(id, pd.GetExpirationUnixSec(), total); err != nil {
-		deleteErr := s.deleteByID(id)
+	satelliteID := getSatelliteID(getNamespace(authorization))
+	if err = s.DB.AddTTL(id, satelliteID, pd.GetExpirationUnixSec(), total); err != nil {
+		deleteErr := s.deleteByID(ctx, id)
 		return StoreError.New("failed to write piece meta data to database: %v", utils.CombineErrors(err, deleteErr))
 	}
 
-	if err = s.DB.AddBandwidthUsed(total); err != nil {
+	if err = s.DB.AddBandwidthUsed(satelliteID, total); err != nil {
 		return StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
 	s.log.Debug("Successfully stored", zap.String("Piece ID", fmt.Sprint(pd.GetId())))
@@ -74,26 +78,13 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total})
 }
 
-func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, id string) (total int64, err error) {
+func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, id string, namespace []byte, satellite *authorizer, shareSize int64) (total int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// Delete data if we error
-	defer func() {
-		if err != nil && err != io.EOF {
-			if deleteErr := s.deleteByID(id); deleteErr != nil {
-				s.log.Error("Failed on deleteByID in Store", zap.Error(deleteErr))
-			}
-		}
-	}()
-
-	// Initialize file for storing data
-	storeFile, err := pstore.StoreWriter(id, s.DataDir)
-	if err != nil {
+	if err := pstore.CheckID(id); err != nil {
 		return 0, err
 	}
 
-	defer utils.LogClose(storeFile)
-
 	bwUsed, err := s.DB.GetTotalBandwidthBetween(getBeginningOfMonth(), time.Now())
 	if err != nil {
 		return 0, err
@@ -104,15 +95,35 @@ func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_Store
 	}
 	bwLeft := s.totalBwAllocated - bwUsed
 	spaceLeft := s.totalAllocated - spaceUsed
-	reader := NewStreamReader(s, stream, bwLeft, spaceLeft)
 
-	total, err = io.Copy(storeFile, reader)
+	// the satellite is limited to its own allocation as well
+	satelliteSpaceLeft, satelliteBwLeft, err := s.satelliteAllocationLeft(getSatelliteID(namespace))
+	if err != nil {
+		return 0, err
+	}
+	if satelliteBwLeft < bwLeft {
+		bwLeft = satelliteBwLeft
+	}
+	if satelliteSpaceLeft < spaceLeft {
+		spaceLeft = satelliteSpaceLeft
+	}
+	reader := NewStreamReader(s, stream, satellite, bwLeft, spaceLeft)
 
-	if err != nil && err != io.EOF {
+	// the piece is stored as a blob visible only once completely written,
+	// along with the hashes of its erasure shares to prove the integrity of
+	// the retrieved ranges
+	piece, total, err := s.storage.Store(ctx, namespace, reader, shareSize)
+	if err != nil {
 		return 0, err
 	}
 
-	err = s.DB.WriteBandwidthAllocToDB(reader.bandwidthAllocation)
+	if err = s.DB.AddPiece(id, piece); err != nil {
+		return 0, utils.CombineErrors(err, s.storage.Delete(ctx, piece))
+	}
+
+	if err = s.DB.WriteBandwidthAllocToDB(reader.bandwidthAllocation); err != nil {
+		return 0, utils.CombineErrors(err, s.deleteByID(ctx, id))
+	}
 
-	return total, err
+	return total, nil
 }
@@ -0,0 +1,192 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	// Error is the error class of the trusted satellites
+	Error = errs.Class("trust error")
+	// ErrUntrusted is the error class of the requests of untrusted satellites
+	ErrUntrusted = errs.Class("untrusted satellite")
+)
+
+// Config holds the satellites the storage node stores for
+type Config struct {
+	Satellites string `help:"comma-separated list of the satellites to store for, as <node id>@<address>, any satellite being trusted when none are listed" default:""`
+	Source     string `help:"path or http(s) URL of a file listing more satellites to store for, one <node id>@<address> per line" default:""`
+}
+
+// Load returns the list of the satellites of the config and of its source
+func (c Config) Load(ctx context.Context) (*List, error) {
+	satellites, err := ParseSatellites(c.Satellites)
+	if err != nil {
+		return nil, err
+	}
+	if c.Source != "" {
+		sourced, err := LoadSatellites(ctx, c.Source)
+		if err != nil {
+			return nil, err
+		}
+		// an unreachable or emptied source must not end up trusting any
+		// satellite
+		if len(sourced) == 0 {
+			return nil, Error.New("no satellites listed in %s", c.Source)
+		}
+		satellites = append(satellites, sourced...)
+	}
+	return NewList(satellites...), nil
+}
+
+// Satellite is a satellite trusted by the storage node
+type Satellite struct {
+	ID storj.NodeID
+	// Address is the address of the satellite, or empty if it has to be
+	// looked up
+	Address string
+}
+
+// ParseSatellites parses the satellites listed in list, as <node id> or
+// <node id>@<address> entries separated by commas or white space. The lines
+// starting with # are ignored.
+func ParseSatellites(list string) (satellites []Satellite, err error) {
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, entry := range entries {
+			satellite, err := parseSatellite(entry)
+			if err != nil {
+				return nil, err
+			}
+			satellites = append(satellites, satellite)
+		}
+	}
+	return satellites, nil
+}
+
+// parseSatellite parses a <node id>@<address> entry
+func parseSatellite(entry string) (Satellite, error) {
+	id, address := entry, ""
+	if i := strings.Index(entry, "@"); i >= 0 {
+		id, address = entry[:i], entry[i+1:]
+	}
+	nodeID, err := storj.NodeIDFromString(id)
+	if err != nil {
+		return Satellite{}, Error.New("invalid satellite %q: %v", entry, err)
+	}
+	return Satellite{ID: nodeID, Address: address}, nil
+}
+
+// LoadSatellites parses the satellites listed in the file at source, either
+// a local path or an http(s) URL
+func LoadSatellites(ctx context.Context, source string) ([]Satellite, error) {
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		req, err := http.NewRequest("GET", source, nil)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return nil, Error.New("unexpected status code fetching %s: %d", source, resp.StatusCode)
+		}
+		data, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	} else {
+		var err error
+		data, err = ioutil.ReadFile(source)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return ParseSatellites(string(data))
+}
+
+// List is the list of the satellites trusted by the storage node. A nil or
+// empty list trusts any satellite.
+type List struct {
+	ids       storj.NodeIDList
+	addresses map[storj.NodeID]string
+}
+
+// NewList returns the list of satellites, keeping the last address listed
+// for each satellite
+func NewList(satellites ...Satellite) *List {
+	list := &List{addresses: make(map[storj.NodeID]string)}
+	for _, satellite := range satellites {
+		address, listed := list.addresses[satellite.ID]
+		if !listed {
+			list.ids = append(list.ids, satellite.ID)
+		}
+		if satellite.Address != "" {
+			address = satellite.Address
+		}
+		list.addresses[satellite.ID] = address
+	}
+	return list
+}
+
+// TrustsAll returns whether any satellite is trusted
+func (list *List) TrustsAll() bool {
+	return list == nil || len(list.ids) == 0
+}
+
+// IsTrusted returns whether the satellite with id is trusted
+func (list *List) IsTrusted(id storj.NodeID) bool {
+	if list.TrustsAll() {
+		return true
+	}
+	_, ok := list.addresses[id]
+	return ok
+}
+
+// Verify returns an ErrUntrusted error if the satellite with id is not
+// trusted
+func (list *List) Verify(id storj.NodeID) error {
+	if !list.IsTrusted(id) {
+		return ErrUntrusted.New("%s", id)
+	}
+	return nil
+}
+
+// Address returns the address of the satellite with id, or empty if it is
+// unknown
+func (list *List) Address(id storj.NodeID) string {
+	if list == nil {
+		return ""
+	}
+	return list.addresses[id]
+}
+
+// Satellites returns the listed satellites
+func (list *List) Satellites() []Satellite {
+	if list == nil {
+		return nil
+	}
+	satellites := make([]Satellite, 0, len(list.ids))
+	for _, id := range list.ids {
+		satellites = append(satellites, Satellite{ID: id, Address: list.addresses[id]})
+	}
+	return satellites
+}
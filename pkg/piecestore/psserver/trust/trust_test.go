@@ -0,0 +1,121 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package trust
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/teststorj"
+)
+
+func TestParseSatellites(t *testing.T) {
+	id1 := teststorj.NodeIDFromString("satellite1")
+	id2 := teststorj.NodeIDFromString("satellite2")
+
+	satellites, err := ParseSatellites(fmt.Sprintf("%s@127.0.0.1:7777, %s", id1, id2))
+	assert.NoError(t, err)
+	assert.Equal(t, []Satellite{{ID: id1, Address: "127.0.0.1:7777"}, {ID: id2}}, satellites)
+
+	satellites, err = ParseSatellites(fmt.Sprintf("# trusted satellites\n%s@127.0.0.1:7777\n\n%s@example.com:7777\n", id1, id2))
+	assert.NoError(t, err)
+	assert.Equal(t, []Satellite{{ID: id1, Address: "127.0.0.1:7777"}, {ID: id2, Address: "example.com:7777"}}, satellites)
+
+	satellites, err = ParseSatellites("")
+	assert.NoError(t, err)
+	assert.Empty(t, satellites)
+
+	_, err = ParseSatellites("invalid@127.0.0.1:7777")
+	assert.True(t, Error.Has(err))
+}
+
+func TestList(t *testing.T) {
+	id1 := teststorj.NodeIDFromString("satellite1")
+	id2 := teststorj.NodeIDFromString("satellite2")
+	other := teststorj.NodeIDFromString("other")
+
+	// nil and empty lists trust any satellite
+	for _, list := range []*List{nil, NewList()} {
+		assert.True(t, list.TrustsAll())
+		assert.True(t, list.IsTrusted(other))
+		assert.NoError(t, list.Verify(other))
+		assert.Empty(t, list.Satellites())
+	}
+
+	list := NewList(Satellite{ID: id1}, Satellite{ID: id2, Address: "127.0.0.1:7777"}, Satellite{ID: id1, Address: "127.0.0.1:7778"}, Satellite{ID: id2})
+	assert.False(t, list.TrustsAll())
+	assert.True(t, list.IsTrusted(id1))
+	assert.True(t, list.IsTrusted(id2))
+	assert.False(t, list.IsTrusted(other))
+	assert.NoError(t, list.Verify(id1))
+	assert.True(t, ErrUntrusted.Has(list.Verify(other)))
+	assert.Equal(t, "127.0.0.1:7778", list.Address(id1))
+	assert.Equal(t, "", list.Address(other))
+	assert.Equal(t, []Satellite{
+		{ID: id1, Address: "127.0.0.1:7778"},
+		{ID: id2, Address: "127.0.0.1:7777"},
+	}, list.Satellites())
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	id1 := teststorj.NodeIDFromString("satellite1")
+	id2 := teststorj.NodeIDFromString("satellite2")
+	content := fmt.Sprintf("%s@127.0.0.1:7778\n", id2)
+
+	dir, err := ioutil.TempDir("", "trust")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "satellites.txt")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(dir, "empty.txt")
+	if err := ioutil.WriteFile(emptyPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/satellites.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	for i, source := range []string{path, server.URL + "/satellites.txt"} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		list, err := Config{Satellites: id1.String() + "@127.0.0.1:7777", Source: source}.Load(ctx)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		assert.Equal(t, []Satellite{
+			{ID: id1, Address: "127.0.0.1:7777"},
+			{ID: id2, Address: "127.0.0.1:7778"},
+		}, list.Satellites(), errTag)
+	}
+
+	// a source which lists no satellite or fails to load errs rather than
+	// trusting any satellite
+	for i, source := range []string{emptyPath, filepath.Join(dir, "missing.txt"), server.URL + "/missing.txt"} {
+		_, err := Config{Source: source}.Load(ctx)
+		assert.True(t, Error.Has(err), fmt.Sprintf("Test case #%d", i))
+	}
+
+	list, err := Config{}.Load(ctx)
+	assert.NoError(t, err)
+	assert.True(t, list.TrustsAll())
+}
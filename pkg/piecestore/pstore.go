@@ -4,15 +4,23 @@
 package pstore
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/zeebo/errs"
 
-	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/merkle"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
 )
 
 // IDLength -- Minimum ID length
@@ -22,12 +30,31 @@ const IDLength = 20
 var (
 	ArgError = errs.Class("argError")
 	FSError  = errs.Class("fsError")
+	// ErrCorrupted is the error class of the pieces whose content no longer
+	// matches what was stored
+	ErrCorrupted = errs.Class("corrupted piece")
 )
 
-// PathByID creates datapath from id and dir
-func PathByID(id, dir string) (string, error) {
+const (
+	// blobsDir is the directory of the blob stores in the data directory
+	blobsDir = "blobs"
+	// defaultNamespace is the directory of the blobs stored without a namespace
+	defaultNamespace = "default"
+)
+
+// CheckID checks whether id is long enough to be a piece id
+func CheckID(id string) error {
 	if len(id) < IDLength {
-		return "", ArgError.New("invalid id length")
+		return ArgError.New("invalid id length")
+	}
+	return nil
+}
+
+// PathByID creates datapath from id and dir, in the layout of the pieces
+// stored before they were kept in blob stores
+func PathByID(id, dir string) (string, error) {
+	if err := CheckID(id); err != nil {
+		return "", err
 	}
 	if dir == "" {
 		return "", ArgError.New("no path provided")
@@ -40,83 +67,351 @@ func PathByID(id, dir string) (string, error) {
 	return path.Join(dir, folder1, folder2, fileName), nil
 }
 
-// StoreWriter stores data into piece store in multiple writes
-// 	id is the id of the data to be stored
-// 	dir is the pstore directory containing all other data stored
-// 	returns error if failed and nil if successful
-func StoreWriter(id string, dir string) (io.WriteCloser, error) {
-	dataPath, err := PathByID(id, dir)
+// Piece locates the blobs of a stored piece
+type Piece struct {
+	// Namespace is the namespace of the blob store of the piece, which is
+	// the id of the satellite the piece is stored for
+	Namespace []byte
+	// Blob is the blob of the content of the piece
+	Blob storage.BlobRef
+	// Hashes is the blob of the hashes of the erasure shares of the piece,
+	// or nil if the piece was stored without them
+	Hashes *storage.BlobRef
+	// Hash is the SHA-256 hash of the content of the piece, or nil if it
+	// was not recorded yet
+	Hash []byte
+}
+
+// Storage stores the pieces as blobs in content-addressed blob stores, one
+// per namespace. A blob is visible only once completely written, so
+// partially written pieces never are.
+type Storage struct {
+	dir string
+
+	mu    sync.Mutex
+	blobs map[string]*filestore.Store
+}
+
+// NewStorage returns the storage of the pieces in the data directory dir
+func NewStorage(dir string) *Storage {
+	if dir != "" {
+		dir = filepath.Join(dir, blobsDir)
+	}
+	return &Storage{
+		dir:   dir,
+		blobs: make(map[string]*filestore.Store),
+	}
+}
+
+// namespaceDir returns the name of the directory of the blobs of namespace
+func namespaceDir(namespace []byte) string {
+	if len(namespace) == 0 {
+		return defaultNamespace
+	}
+	return hex.EncodeToString(namespace)
+}
+
+// Blobs returns the blob store of namespace
+func (s *Storage) Blobs(namespace []byte) (*filestore.Store, error) {
+	name := namespaceDir(namespace)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if blobs, ok := s.blobs[name]; ok {
+		return blobs, nil
+	}
+	if s.dir == "" {
+		return nil, ArgError.New("no path provided")
+	}
+
+	blobs, err := filestore.NewAt(filepath.Join(s.dir, name))
 	if err != nil {
-		return nil, err
+		return nil, FSError.Wrap(err)
 	}
+	s.blobs[name] = blobs
+	return blobs, nil
+}
 
-	// Create directory path on file system
-	if err = os.MkdirAll(filepath.Dir(dataPath), 0700); err != nil {
-		return nil, err
+// Store stores the piece read from r for namespace
+//	shareSize, if positive, is the size of the erasure shares of the piece,
+//	whose hashes are stored to prove the integrity of the retrieved ranges
+//	returns the stored piece and its size
+func (s *Storage) Store(ctx context.Context, namespace []byte, r io.Reader, shareSize int64) (piece Piece, size int64, err error) {
+	blobs, err := s.Blobs(namespace)
+	if err != nil {
+		return Piece{}, 0, err
+	}
+
+	counter := &countingWriter{}
+	contentHasher := sha256.New()
+	writer := io.MultiWriter(counter, contentHasher)
+	var hasher *merkle.ShareHasher
+	if shareSize > 0 {
+		hasher = merkle.NewShareHasher(int(shareSize))
+		writer = io.MultiWriter(counter, contentHasher, hasher)
+	}
+
+	reader := &errReader{r: io.TeeReader(r, writer)}
+	ref, err := blobs.Store(ctx, reader, -1)
+	if reader.err != nil {
+		// the error reading the piece is returned as is
+		return Piece{}, 0, reader.err
+	}
+	if err != nil {
+		return Piece{}, 0, err
+	}
+	piece = Piece{Namespace: namespace, Blob: ref, Hash: contentHasher.Sum(nil)}
+
+	if hasher != nil {
+		data := encodeHashes(shareSize, hasher.Leaves())
+		hashesRef, err := blobs.Store(ctx, bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return Piece{}, 0, errs.Combine(err, blobs.Delete(ctx, ref))
+		}
+		piece.Hashes = &hashesRef
 	}
 
-	// Create File on file system
-	return os.OpenFile(dataPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	return piece, counter.n, nil
+}
+
+// Open opens the content of piece
+func (s *Storage) Open(ctx context.Context, piece Piece) (storage.ReadSeekCloser, error) {
+	blobs, err := s.Blobs(piece.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return blobs.Load(ctx, piece.Blob)
 }
 
-// RetrieveReader retrieves data from pstore directory
-//	id is the id of the stored data
+// RetrieveReader retrieves data of piece
 //	offset	is the offset of the data that you are reading. Useful for multiple connections to split the data transfer
 //	length is the amount of data to read. Read all data if -1
-//	dir is the pstore directory containing all other data stored
 // 	returns error if failed and nil if successful
-func RetrieveReader(ctx context.Context, id string, offset int64, length int64, dir string) (io.ReadCloser, error) {
-	dataPath, err := PathByID(id, dir)
+func (s *Storage) RetrieveReader(ctx context.Context, piece Piece, offset int64, length int64) (io.ReadCloser, error) {
+	blob, err := s.Open(ctx, piece)
 	if err != nil {
 		return nil, err
 	}
 
-	fileInfo, err := os.Stat(dataPath)
+	// If offset is greater than blob size return
+	if offset >= blob.Size() || offset < 0 {
+		return nil, errs.Combine(ArgError.New("invalid offset: %v", offset), blob.Close())
+	}
+
+	// If length less than 0 read the entire blob
+	if length <= -1 {
+		length = blob.Size()
+	}
+
+	// If trying to read past the end of the blob, just read to the end
+	if blob.Size() < offset+length {
+		length = blob.Size() - offset
+	}
+
+	// Created a section reader so that we can concurrently retrieve the same blob.
+	return &sectionReadCloser{io.NewSectionReader(blob, offset, length), blob}, nil
+}
+
+// RetrieveHashes retrieves the hashes of the erasure shares of piece
+//	returns the size of the erasure shares and their leaf hashes, or an error
+//	satisfying os.IsNotExist if the piece was stored without them
+func (s *Storage) RetrieveHashes(ctx context.Context, piece Piece) (shareSize int64, hashes [][]byte, err error) {
+	if piece.Hashes == nil {
+		return 0, nil, os.ErrNotExist
+	}
+
+	blobs, err := s.Blobs(piece.Namespace)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
+	blob, err := blobs.Load(ctx, *piece.Hashes)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { err = errs.Combine(err, blob.Close()) }()
 
-	// If offset is greater than file size return
-	if offset >= fileInfo.Size() || offset < 0 {
-		return nil, ArgError.New("invalid offset: %v", offset)
+	buf := make([]byte, blob.Size())
+	if _, err := io.ReadFull(blob, buf); err != nil {
+		return 0, nil, FSError.Wrap(err)
 	}
+	return decodeHashes(buf)
+}
 
-	// If length less than 0 read the entire file
-	if length <= -1 {
-		length = fileInfo.Size()
+// Verify re-reads the content of piece, checking it against its hash and
+// the hashes of its erasure shares
+//	returns the hash and the size of the content, or an ErrCorrupted error if
+//	the content or its hashes do not match or cannot be read
+func (s *Storage) Verify(ctx context.Context, piece Piece) (hash []byte, size int64, err error) {
+	contentHasher := sha256.New()
+	writer := io.Writer(contentHasher)
+
+	var hasher *merkle.ShareHasher
+	var hashes [][]byte
+	if piece.Hashes != nil {
+		var shareSize int64
+		shareSize, hashes, err = s.RetrieveHashes(ctx, piece)
+		if os.IsNotExist(err) {
+			return nil, 0, ErrCorrupted.New("missing hashes")
+		}
+		if FSError.Has(err) {
+			return nil, 0, ErrCorrupted.Wrap(err)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if shareSize <= 0 {
+			return nil, 0, ErrCorrupted.New("invalid share size: %d", shareSize)
+		}
+		hasher = merkle.NewShareHasher(int(shareSize))
+		writer = io.MultiWriter(contentHasher, hasher)
 	}
 
-	// If trying to read past the end of the file, just read to the end
-	if fileInfo.Size() < offset+length {
-		length = fileInfo.Size() - offset
+	blob, err := s.Open(ctx, piece)
+	if os.IsNotExist(err) {
+		return nil, 0, ErrCorrupted.New("missing content")
+	}
+	if err != nil {
+		return nil, 0, err
 	}
+	defer func() { err = errs.Combine(err, blob.Close()) }()
 
-	// Created a section reader so that we can concurrently retrieve the same file.
-	rr, err := ranger.FileRanger(dataPath)
+	size, err = io.Copy(writer, blob)
 	if err != nil {
-		return nil, err
+		// content that cannot be read back is as lost as rotten content
+		return nil, 0, ErrCorrupted.Wrap(err)
+	}
+
+	hash = contentHasher.Sum(nil)
+	if piece.Hash != nil && !bytes.Equal(piece.Hash, hash) {
+		return nil, 0, ErrCorrupted.New("content hash mismatch")
+	}
+
+	if hasher != nil {
+		leaves := hasher.Leaves()
+		if len(leaves) != len(hashes) {
+			return nil, 0, ErrCorrupted.New("%d erasure shares instead of %d", len(leaves), len(hashes))
+		}
+		for i := range leaves {
+			if !bytes.Equal(leaves[i], hashes[i]) {
+				return nil, 0, ErrCorrupted.New("erasure share %d hash mismatch", i)
+			}
+		}
 	}
 
-	return rr.Range(ctx, offset, length)
+	return hash, size, nil
 }
 
-// Delete deletes data from storagenode
-//	id is the id of the data to be stored
-//	dir is the pstore directory containing all other data stored
-//	returns error if failed and nil if successful
-func Delete(id string, dir string) error {
-	dataPath, err := PathByID(id, dir)
+// Delete deletes the blobs of piece
+func (s *Storage) Delete(ctx context.Context, piece Piece) error {
+	blobs, err := s.Blobs(piece.Namespace)
 	if err != nil {
 		return err
 	}
 
-	if _, err = os.Stat(dataPath); os.IsNotExist(err) {
-		return nil
+	err = blobs.Delete(ctx, piece.Blob)
+	if piece.Hashes != nil {
+		err = errs.Combine(err, blobs.Delete(ctx, *piece.Hashes))
 	}
+	return err
+}
 
-	if err = os.Remove(dataPath); err != nil {
+// GarbageCollect deletes the blobs whose deletion was interrupted, in the
+// blob stores of all the namespaces
+func (s *Storage) GarbageCollect(ctx context.Context) error {
+	dirs, err := readDirNames(s.dir)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	var group errs.Group
+	for _, name := range dirs {
+		var namespace []byte
+		if name != defaultNamespace {
+			namespace, err = hex.DecodeString(name)
+			if err != nil {
+				continue
+			}
+		}
+		blobs, err := s.Blobs(namespace)
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		group.Add(blobs.GarbageCollect(ctx))
+	}
+	return group.Err()
+}
+
+// readDirNames returns the names of the directories in dir, which may not
+// exist yet
+func readDirNames(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, FSError.Wrap(err)
+	}
+
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() {
+			names = append(names, info.Name())
+		}
+	}
+	return names, nil
+}
+
+// encodeHashes encodes the size of the erasure shares and their hashes
+func encodeHashes(shareSize int64, hashes [][]byte) []byte {
+	buf := make([]byte, 8, 8+len(hashes)*merkle.HashSize)
+	binary.BigEndian.PutUint64(buf, uint64(shareSize))
+	for _, hash := range hashes {
+		buf = append(buf, hash...)
+	}
+	return buf
+}
+
+// decodeHashes decodes the size of the erasure shares and their hashes
+func decodeHashes(buf []byte) (shareSize int64, hashes [][]byte, err error) {
+	if len(buf) < 8 || (len(buf)-8)%merkle.HashSize != 0 {
+		return 0, nil, FSError.New("invalid hashes size: %d", len(buf))
+	}
+
+	shareSize = int64(binary.BigEndian.Uint64(buf))
+	for buf = buf[8:]; len(buf) > 0; buf = buf[merkle.HashSize:] {
+		hashes = append(hashes, buf[:merkle.HashSize])
+	}
+	return shareSize, hashes, nil
+}
+
+// countingWriter counts the bytes written to it
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// errReader keeps the error reading from r other than io.EOF
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// sectionReadCloser reads a section of a blob, closing the blob with it
+type sectionReadCloser struct {
+	*io.SectionReader
+	io.Closer
 }
@@ -5,9 +5,11 @@ package pointerdb
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
@@ -30,10 +32,11 @@ const (
 // Config is a configuration struct that is everything you need to start a
 // PointerDB responsibility
 type Config struct {
-	DatabaseURL          string `help:"the database connection string to use" default:"bolt://$CONFDIR/pointerdb.db"`
-	MinRemoteSegmentSize int    `default:"1240" help:"minimum remote segment size"`
-	MaxInlineSegmentSize int    `default:"8000" help:"maximum inline segment size"`
-	Overlay              bool   `default:"true" help:"toggle flag if overlay is enabled"`
+	DatabaseURL          string        `help:"the database connection string to use" default:"bolt://$CONFDIR/pointerdb.db"`
+	MinRemoteSegmentSize int           `default:"1240" help:"minimum remote segment size"`
+	MaxInlineSegmentSize int           `default:"8000" help:"maximum inline segment size"`
+	Overlay              bool          `default:"true" help:"toggle flag if overlay is enabled"`
+	UsageCacheTime       time.Duration `default:"10m" help:"how long the stored usage computed from all the pointers is reused"`
 }
 
 func newKeyValueStore(dbURLString string) (db storage.KeyValueStore, err error) {
@@ -62,6 +65,11 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) error {
 	cache := overlay.LoadFromContext(ctx)
 	dblogged := storelogger.New(zap.L().Named("pdb"), db)
 	s := NewServer(dblogged, cache, zap.L(), c, server.Identity())
+	if masterdb, ok := ctx.Value("masterdb").(interface {
+		BandwidthAgreement() bwagreement.DB
+	}); ok {
+		s.agreements = masterdb.BandwidthAgreement()
+	}
 	pb.RegisterPointerDBServer(server.GRPC(), s)
 	// add the server to the context
 	ctx = context.WithValue(ctx, ctxKey, s)
@@ -52,6 +52,7 @@ type Client interface {
 	Get(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, *pb.PayerBandwidthAllocation, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 	Delete(ctx context.Context, path storj.Path) error
+	Usage(ctx context.Context, bucket string) (*pb.UsageResponse, error)
 
 	SignedMessage() *pb.SignedMessage
 	PayerBandwidthAllocation(context.Context, pb.PayerBandwidthAllocation_Action) (*pb.PayerBandwidthAllocation, error)
@@ -144,6 +145,13 @@ func (pdb *PointerDB) Delete(ctx context.Context, path storj.Path) (err error) {
 	return err
 }
 
+// Usage gets the storage and bandwidth usage of all buckets or of bucket if not empty
+func (pdb *PointerDB) Usage(ctx context.Context, bucket string) (resp *pb.UsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return pdb.client.Usage(ctx, &pb.UsageRequest{Bucket: bucket})
+}
+
 // PayerBandwidthAllocation gets payer bandwidth allocation message
 func (pdb *PointerDB) PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action) (resp *pb.PayerBandwidthAllocation, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -114,3 +114,16 @@ func (m *MockClient) SignedMessage() *pb.SignedMessage {
 func (mr *MockClientMockRecorder) SignedMessage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedMessage", reflect.TypeOf((*MockClient)(nil).SignedMessage))
 }
+
+// Usage mocks base method
+func (m *MockClient) Usage(arg0 context.Context, arg1 string) (*pb.UsageResponse, error) {
+	ret := m.ctrl.Call(m, "Usage", arg0, arg1)
+	ret0, _ := ret[0].(*pb.UsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage
+func (mr *MockClientMockRecorder) Usage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockClient)(nil).Usage), arg0, arg1)
+}
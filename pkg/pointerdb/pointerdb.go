@@ -7,6 +7,9 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -18,12 +21,14 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
 	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
 
@@ -34,11 +39,21 @@ var (
 
 // Server implements the network state RPC service
 type Server struct {
-	DB       storage.KeyValueStore
-	logger   *zap.Logger
-	config   Config
-	cache    *overlay.Cache
-	identity *provider.FullIdentity
+	DB         storage.KeyValueStore
+	logger     *zap.Logger
+	config     Config
+	cache      *overlay.Cache
+	identity   *provider.FullIdentity
+	agreements bwagreement.DB // nil when the bandwidth agreements are not available
+
+	usageMu sync.Mutex
+	usage   map[string]storedUsage // by bucket, "" for all buckets
+}
+
+// storedUsage is the stored usage computed from the pointers at a time
+type storedUsage struct {
+	computed time.Time
+	usage    pb.UsageResponse
 }
 
 // NewServer creates instance of Server
@@ -297,6 +312,160 @@ func (s *Server) Iterate(ctx context.Context, req *pb.IterateRequest, f func(it
 	return s.DB.Iterate(opts, f)
 }
 
+// Usage returns the size, the number of objects and the number of segments
+// stored in all buckets or in the requested bucket, with the bandwidth used
+// by the requesting uplink for downloads
+func (s *Server) Usage(ctx context.Context, req *pb.UsageRequest) (resp *pb.UsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err = s.storedUsage(req.GetBucket())
+	if err != nil {
+		s.logger.Error("err computing stored usage", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if s.agreements != nil {
+		resp.EgressBytes, err = s.egress(ctx)
+		if err != nil {
+			s.logger.Error("err summing bandwidth agreements", zap.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return resp, nil
+}
+
+// storedUsage returns the size, the number of objects and the number of
+// segments stored in all buckets or in bucket. As they are computed from all
+// the pointers, they are reused for the configured time.
+func (s *Server) storedUsage(bucket string) (*pb.UsageResponse, error) {
+	// concurrent requests wait for the usage computed by the first one
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	now := time.Now()
+	if stored, ok := s.usage[bucket]; ok && now.Sub(stored.computed) < s.config.UsageCacheTime {
+		usage := stored.usage
+		return &usage, nil
+	}
+
+	usage := pb.UsageResponse{}
+
+	// segments are stored in <segment>/<bucket>/<path>, where segment is
+	// "l" for the last segment and "s<index>" otherwise
+	segments, err := s.segmentPrefixes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, segment := range segments {
+		prefix := segment
+		if bucket != "" {
+			prefix = storj.JoinPaths(segment, bucket)
+		}
+
+		err = s.DB.Iterate(storage.IterateOptions{Prefix: storage.Key(prefix + "/"), Recurse: true},
+			func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) {
+					// the buckets themselves are stored at l/<bucket>
+					if len(storj.SplitPath(item.Key.String())) < 3 {
+						continue
+					}
+
+					pointer := &pb.Pointer{}
+					err := proto.Unmarshal(item.Value, pointer)
+					if err != nil {
+						return err
+					}
+
+					usage.StoredBytes += pointer.GetSegmentSize()
+					usage.Segments++
+					if segment == "l" {
+						usage.Objects++
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.config.UsageCacheTime > 0 {
+		for cached, stored := range s.usage {
+			if now.Sub(stored.computed) >= s.config.UsageCacheTime {
+				delete(s.usage, cached)
+			}
+		}
+		if s.usage == nil {
+			s.usage = make(map[string]storedUsage)
+		}
+		s.usage[bucket] = storedUsage{computed: now, usage: usage}
+	}
+
+	return &usage, nil
+}
+
+// segmentPrefixes returns the segments having pointers stored
+func (s *Server) segmentPrefixes() (segments []string, err error) {
+	err = s.DB.Iterate(storage.IterateOptions{},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				if !item.IsPrefix {
+					continue
+				}
+				segment := strings.TrimSuffix(item.Key.String(), "/")
+				if isSegment(segment) {
+					segments = append(segments, segment)
+				}
+			}
+			return nil
+		})
+	return segments, err
+}
+
+// isSegment returns whether prefix is "l" or "s<index>"
+func isSegment(prefix string) bool {
+	if prefix == "l" {
+		return true
+	}
+	if !strings.HasPrefix(prefix, "s") {
+		return false
+	}
+	_, err := strconv.ParseUint(prefix[1:], 10, 64)
+	return err == nil
+}
+
+// egress returns the total of the bandwidth agreements for downloads by the
+// requesting uplink
+func (s *Server) egress(ctx context.Context) (total int64, err error) {
+	peerIdentity, err := provider.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	agreements, err := s.agreements.GetUplinkAgreements(ctx, peerIdentity.ID, pb.PayerBandwidthAllocation_GET)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, agreement := range agreements {
+		rbad := &pb.RenterBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(agreement.Agreement, rbad); err != nil {
+			return 0, err
+		}
+		total += rbad.GetTotal()
+	}
+
+	return total, nil
+}
+
 // PayerBandwidthAllocation returns PayerBandwidthAllocation struct, signed and with given action type
 func (s *Server) PayerBandwidthAllocation(ctx context.Context, req *pb.PayerBandwidthAllocationRequest) (*pb.PayerBandwidthAllocationResponse, error) {
 	payer := s.identity.ID
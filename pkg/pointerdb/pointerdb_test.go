@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -23,8 +24,10 @@ import (
 
 	"storj.io/storj/internal/testidentity"
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/teststore"
 )
@@ -159,6 +162,124 @@ func TestServiceDelete(t *testing.T) {
 	}
 }
 
+type agreementsStub []bwagreement.Agreement
+
+func (agreements agreementsStub) CreateAgreement(context.Context, bwagreement.Agreement) error {
+	return nil
+}
+func (agreements agreementsStub) GetAgreements(context.Context) ([]bwagreement.Agreement, error) {
+	return agreements, nil
+}
+func (agreements agreementsStub) GetAgreementsSince(context.Context, time.Time) ([]bwagreement.Agreement, error) {
+	return agreements, nil
+}
+func (agreements agreementsStub) GetUplinkAgreements(ctx context.Context, uplinkID storj.NodeID, action pb.PayerBandwidthAllocation_Action) (result []bwagreement.Agreement, err error) {
+	for _, agreement := range agreements {
+		if agreement.UplinkID == uplinkID && agreement.Action == action {
+			result = append(result, agreement)
+		}
+	}
+	return result, nil
+}
+
+func TestServiceUsage(t *testing.T) {
+	ctx := context.Background()
+	ca, err := testidentity.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+	otherCA, err := testidentity.NewTestCA(ctx)
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA}}}
+	ctx = auth.WithAPIKey(ctx, nil)
+	ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+
+	db := teststore.New()
+	for path, size := range map[string]int64{
+		"bucket1":          0,
+		"l/bucket1":        0, // bucket
+		"l/bucket1/a":      10,
+		"s0/bucket1/b":     100,
+		"s1/bucket1/b":     100,
+		"l/bucket1/b":      20,
+		"p/bucket1/c":      0, // pending object
+		"s0/bucket1/c":     100,
+		"l/bucket2/a/b/c":  30,
+		"s0/bucket2/a/b/c": 100,
+		"sx/bucket1/d":     1000, // not a segment
+	} {
+		pointer, err := proto.Marshal(&pb.Pointer{SegmentSize: size})
+		assert.NoError(t, err)
+		assert.NoError(t, db.Put(storage.Key(path), storage.Value(pointer)))
+	}
+
+	var agreements agreementsStub
+	for _, tt := range []struct {
+		action pb.PayerBandwidthAllocation_Action
+		uplink storj.NodeID
+		total  int64
+	}{
+		{pb.PayerBandwidthAllocation_GET, identity.ID, 1000},
+		{pb.PayerBandwidthAllocation_GET, identity.ID, 2000},
+		{pb.PayerBandwidthAllocation_PUT, identity.ID, 4000},
+		{pb.PayerBandwidthAllocation_GET, otherCA.ID, 8000},
+	} {
+		pbad, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{Action: tt.action, UplinkId: tt.uplink})
+		assert.NoError(t, err)
+		rbad, err := proto.Marshal(&pb.RenterBandwidthAllocation_Data{
+			PayerAllocation: &pb.PayerBandwidthAllocation{Data: pbad},
+			Total:           tt.total,
+		})
+		assert.NoError(t, err)
+		agreements = append(agreements, bwagreement.Agreement{Agreement: rbad, UplinkID: tt.uplink, Action: tt.action})
+	}
+
+	s := Server{DB: db, logger: zap.NewNop(), agreements: agreements}
+
+	for i, tt := range []struct {
+		bucket   string
+		expected pb.UsageResponse
+	}{
+		{"", pb.UsageResponse{StoredBytes: 460, Objects: 3, Segments: 7, EgressBytes: 3000}},
+		{"bucket1", pb.UsageResponse{StoredBytes: 330, Objects: 2, Segments: 5, EgressBytes: 3000}},
+		{"bucket2", pb.UsageResponse{StoredBytes: 130, Objects: 1, Segments: 2, EgressBytes: 3000}},
+		{"bucket3", pb.UsageResponse{EgressBytes: 3000}},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		resp, err := s.Usage(ctx, &pb.UsageRequest{Bucket: tt.bucket})
+		if assert.NoError(t, err, errTag) {
+			assert.True(t, proto.Equal(&tt.expected, resp), errTag)
+		}
+	}
+
+	_, err = s.Usage(auth.WithAPIKey(ctx, []byte("wrong key")), &pb.UsageRequest{})
+	assert.EqualError(t, err, status.Errorf(codes.Unauthenticated, "Invalid API credential").Error())
+
+	// the stored usage is reused for the configured time
+	s.config.UsageCacheTime = time.Hour
+
+	resp, err := s.Usage(ctx, &pb.UsageRequest{})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 460, resp.StoredBytes)
+	}
+
+	pointer, err := proto.Marshal(&pb.Pointer{SegmentSize: 40})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put(storage.Key("l/bucket2/d"), storage.Value(pointer)))
+
+	resp, err = s.Usage(ctx, &pb.UsageRequest{})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 460, resp.StoredBytes)
+	}
+
+	resp, err = s.Usage(ctx, &pb.UsageRequest{Bucket: "bucket2"})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 170, resp.StoredBytes)
+	}
+}
+
 func TestServiceList(t *testing.T) {
 	db := teststore.New()
 	server := Server{DB: db, logger: zap.NewNop()}
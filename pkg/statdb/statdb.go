@@ -27,6 +27,12 @@ type DB interface {
 	UpdateBatch(ctx context.Context, requests []*UpdateRequest) (statslist []*NodeStats, failed []*UpdateRequest, err error)
 	// CreateEntryIfNotExists creates a node stats entry if it didn't already exist.
 	CreateEntryIfNotExists(ctx context.Context, nodeID storj.NodeID) (stats *NodeStats, err error)
+	// ReportCorruption records that reporterID found a corrupted piece on nodeID and returns
+	// the number of distinct reporters of nodeID. It does not change the audit stats.
+	ReportCorruption(ctx context.Context, nodeID, reporterID storj.NodeID) (reports int64, err error)
+	// ReportSlow records that nodeID was too slow to serve or store a piece and returns
+	// the number of times it was. It does not change the uptime stats.
+	ReportSlow(ctx context.Context, nodeID storj.NodeID) (slowCount int64, err error)
 }
 
 // UpdateRequest is used to update a node status.
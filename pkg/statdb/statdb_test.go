@@ -297,4 +297,50 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, newAuditRatio2, stats2.AuditSuccessRatio)
 		assert.EqualValues(t, newUptimeRatio2, stats2.UptimeRatio)
 	}
+
+	{ // TestReportCorruption
+		stats, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+
+		reporterID1 := storj.NodeID{7, 1}
+		reporterID2 := storj.NodeID{7, 2}
+
+		reports, err := sdb.ReportCorruption(ctx, nodeID, reporterID1)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, reports)
+
+		// a reporter is counted once
+		reports, err = sdb.ReportCorruption(ctx, nodeID, reporterID1)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, reports)
+
+		reports, err = sdb.ReportCorruption(ctx, nodeID, reporterID2)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, reports)
+
+		// the audit stats are not changed
+		after, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, stats.AuditCount, after.AuditCount)
+		assert.EqualValues(t, stats.AuditSuccessCount, after.AuditSuccessCount)
+	}
+
+	{ // TestReportSlow
+		stats, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+
+		slowCount, err := sdb.ReportSlow(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, slowCount)
+
+		slowCount, err = sdb.ReportSlow(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, slowCount)
+
+		// the uptime stats are not changed
+		after, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, stats.UptimeCount, after.UptimeCount)
+		assert.EqualValues(t, stats.UptimeSuccessCount, after.UptimeSuccessCount)
+	}
 }
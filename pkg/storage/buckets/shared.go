@@ -0,0 +1,76 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package buckets
+
+import (
+	"context"
+
+	"storj.io/storj/pkg/storage/objects"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+// sharedStore is the store of the single bucket of a key shared for a
+// prefix in it. The bucket metadata is not readable with such a key, so
+// the path cipher of the bucket comes with the key.
+type sharedStore struct {
+	stream     streams.Store
+	bucket     string
+	pathCipher storj.Cipher
+}
+
+// NewSharedStore instantiates a Store for the bucket of a key shared for a
+// prefix in it, whose paths are encrypted with pathCipher
+func NewSharedStore(stream streams.Store, bucket string, pathCipher storj.Cipher) Store {
+	return &sharedStore{stream: stream, bucket: bucket, pathCipher: pathCipher}
+}
+
+// GetObjectStore returns an implementation of objects.Store
+func (b *sharedStore) GetObjectStore(ctx context.Context, bucket string) (objects.Store, error) {
+	if _, err := b.Get(ctx, bucket); err != nil {
+		return nil, err
+	}
+	prefixed := prefixedObjStore{
+		store:  objects.NewStore(b.stream, b.pathCipher),
+		prefix: bucket,
+	}
+	return &prefixed, nil
+}
+
+// Get returns the metadata of the shared bucket
+func (b *sharedStore) Get(ctx context.Context, bucket string) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if bucket == "" {
+		return Meta{}, storj.ErrNoBucket.New("")
+	}
+	if bucket != b.bucket {
+		return Meta{}, storj.ErrBucketNotFound.New("%s", bucket)
+	}
+	return Meta{PathEncryptionType: b.pathCipher}, nil
+}
+
+// Put fails, as buckets are not created with a shared key
+func (b *sharedStore) Put(ctx context.Context, bucket string, pathCipher storj.Cipher) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Meta{}, streams.ErrPathNotShared.New("%s", bucket)
+}
+
+// Delete fails, as buckets are not deleted with a shared key
+func (b *sharedStore) Delete(ctx context.Context, bucket string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return streams.ErrPathNotShared.New("%s", bucket)
+}
+
+// List lists the shared bucket
+func (b *sharedStore) List(ctx context.Context, startAfter, endBefore string, limit int) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if b.bucket <= startAfter || (endBefore != "" && b.bucket >= endBefore) {
+		return nil, false, nil
+	}
+	return []ListItem{{Bucket: b.bucket, Meta: Meta{PathEncryptionType: b.pathCipher}}}, false, nil
+}
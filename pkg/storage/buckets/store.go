@@ -103,7 +103,7 @@ func (b *BucketStore) Put(ctx context.Context, bucket string, pathCipher storj.C
 		return Meta{}, storj.ErrNoBucket.New("")
 	}
 
-	if pathCipher < storj.Unencrypted || pathCipher > storj.SecretBox {
+	if pathCipher < storj.Unencrypted || pathCipher > storj.XChaCha20Poly1305 {
 		return Meta{}, encryption.ErrInvalidConfig.New("encryption type %d is not supported", pathCipher)
 	}
 
@@ -7,13 +7,16 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
@@ -28,27 +31,75 @@ var mon = monkit.Package()
 // Client defines an interface for storing erasure coded data to piece store nodes
 type Client interface {
 	Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error)
+		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage,
+		replace NodeSelector) (successfulNodes []*pb.Node, report PutReport, err error)
 	Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
+		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage,
+		initial int, hashes [][]byte) (ranger.Ranger, error)
 	Delete(ctx context.Context, nodes []*pb.Node, pieceID psclient.PieceID, authorization *pb.SignedMessage) error
 }
 
+// NodeSelector selects a node, other than the excluded ones, to replace a
+// node that failed to store a piece
+type NodeSelector func(ctx context.Context, excluded storj.NodeIDList) (*pb.Node, error)
+
+// PutReport lists the nodes that did not store their piece during a Put, and
+// the hashes of the stored pieces
+type PutReport struct {
+	// Slow are the nodes whose upload was cancelled for being too slow, or
+	// still in progress when the optimal threshold was reached
+	Slow []*pb.Node
+	// Failed are the nodes whose upload failed, including the ones replaced
+	Failed []*pb.Node
+	// Hashes are the root hashes of the erasure shares of the stored pieces,
+	// indexed like the successful nodes
+	Hashes [][]byte
+}
+
+// CorruptionObserver is an observer notified of the nodes serving corrupted
+// erasure shares, so they can be penalized
+type CorruptionObserver interface {
+	PieceCorrupted(ctx context.Context, node *pb.Node, err error)
+}
+
+// SlowObserver is an observer notified of the nodes too slow to serve or
+// store their pieces. The transport observers of the client implementing it
+// are notified.
+type SlowObserver interface {
+	PieceSlow(ctx context.Context, node *pb.Node)
+}
+
+// maxReplacements is the maximum number of replacement nodes tried for a piece
+const maxReplacements = 2
+
+// slowPieceTimeout is the time waited for the erasure shares of a stripe
+// before downloading a spare piece
+const slowPieceTimeout = 5 * time.Second
+
 type psClientFunc func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error)
 type psClientHelper func(context.Context, *pb.Node) (psclient.Client, error)
 
 type ecClient struct {
 	transport       transport.Client
+	observers       []transport.Observer
+	corruption      CorruptionObserver
 	memoryLimit     int
+	slowTimeout     time.Duration
 	newPSClientFunc psClientFunc
 }
 
-// NewClient from the given identity and max buffer memory
-func NewClient(identity *provider.FullIdentity, memoryLimit int) Client {
-	tc := transport.NewClient(identity)
+// NewClient from the given identity and max buffer memory. The corruption
+// observer, if not nil, is notified of the nodes serving corrupted pieces,
+// and the observers of the nodes failing, or too slow, to serve or store
+// their pieces.
+func NewClient(identity *provider.FullIdentity, memoryLimit int, corruption CorruptionObserver, obs ...transport.Observer) Client {
+	tc := transport.NewClient(identity, obs...)
 	return &ecClient{
 		transport:       tc,
+		observers:       obs,
+		corruption:      corruption,
 		memoryLimit:     memoryLimit,
+		slowTimeout:     slowPieceTimeout,
 		newPSClientFunc: psclient.NewPSClient,
 	}
 }
@@ -59,29 +110,40 @@ func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (psclient.Clien
 }
 
 func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error) {
+	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage,
+	replace NodeSelector) (successfulNodes []*pb.Node, report PutReport, err error) {
 	defer mon.Task()(&ctx)(&err)
 	if len(nodes) != rs.TotalCount() {
-		return nil, Error.New("size of nodes slice (%d) does not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
+		return nil, report, Error.New("size of nodes slice (%d) does not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
 	}
 
 	if nonNilCount(nodes) < rs.RepairThreshold() {
-		return nil, Error.New("number of non-nil nodes (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(nodes), rs.RepairThreshold())
+		return nil, report, Error.New("number of non-nil nodes (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(nodes), rs.RepairThreshold())
 	}
 
 	if !unique(nodes) {
-		return nil, Error.New("duplicated nodes are not allowed")
+		return nil, report, Error.New("duplicated nodes are not allowed")
 	}
 
 	padded := eestream.PadReader(ioutil.NopCloser(data), rs.StripeSize())
 	readers, err := eestream.EncodeReader(ctx, padded, rs, ec.memoryLimit)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
+	// the uploads still in progress are cancelled once the optimal threshold
+	// is reached
+	putCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	used := newNodeList(nodes)
+
 	type info struct {
-		i   int
-		err error
+		i        int
+		node     *pb.Node
+		replaced []*pb.Node
+		hash     []byte
+		err      error
 	}
 	infos := make(chan info, len(nodes))
 
@@ -97,45 +159,60 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				infos <- info{i: i, err: err}
 				return
 			}
-			derivedPieceID, err := pieceID.Derive(n.Id.Bytes())
 
-			if err != nil {
-				zap.S().Errorf("Failed deriving piece id for %s: %v", pieceID, err)
-				infos <- info{i: i, err: err}
-				return
-			}
-			ps, err := ec.newPSClient(ctx, n)
-			if err != nil {
-				zap.S().Errorf("Failed dialing for putting piece %s -> %s to node %s: %v",
-					pieceID, derivedPieceID, n.Id, err)
-				infos <- info{i: i, err: err}
-				return
-			}
-			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, pba, authorization)
-			// normally the bellow call should be deferred, but doing so fails
-			// randomly the unit tests
-			utils.LogClose(ps)
-			// io.ErrUnexpectedEOF means the piece upload was interrupted due to slow connection.
-			// No error logging for this case.
-			if err != nil && err != io.ErrUnexpectedEOF {
-				nodeAddress := "nil"
-				if n.Address != nil {
-					nodeAddress = n.Address.Address
+			// a piece is uploaded to a replacement node only if none of its
+			// data was read yet, as the encoded data cannot be read again
+			hasher := merkle.NewShareHasher(rs.ErasureShareSize())
+			reader := &countingReader{reader: io.TeeReader(readers[i], hasher)}
+			var replaced []*pb.Node
+			for {
+				err := ec.putPiece(putCtx, n, pieceID, reader, expiration, pba, authorization, rs.ErasureShareSize())
+				if err == nil {
+					infos <- info{i: i, node: n, replaced: replaced, hash: hasher.Root()}
+					return
+				}
+				if err == io.ErrUnexpectedEOF || putCtx.Err() != nil ||
+					replace == nil || reader.count > 0 || len(replaced) >= maxReplacements {
+					infos <- info{i: i, node: n, replaced: replaced, err: err}
+					return
 				}
-				zap.S().Errorf("Failed putting piece %s -> %s to node %s (%+v): %v",
-					pieceID, derivedPieceID, n.Id, nodeAddress, err)
+
+				replacement, replaceErr := replace(putCtx, used.ids())
+				if replaceErr != nil {
+					zap.S().Errorf("Failed selecting replacement for node %s: %v", n.Id, replaceErr)
+					infos <- info{i: i, node: n, replaced: replaced, err: err}
+					return
+				}
+				replacement.Type.DPanicOnInvalid("ec client Put replacement")
+				used.add(replacement)
+
+				replaced = append(replaced, n)
+				n = replacement
 			}
-			infos <- info{i: i, err: err}
 		}(i, n)
 	}
 
 	successfulNodes = make([]*pb.Node, len(nodes))
+	report.Hashes = make([][]byte, len(nodes))
 	var successfulCount int
 	for range nodes {
 		info := <-infos
-		if info.err == nil {
-			successfulNodes[info.i] = nodes[info.i]
+		report.Failed = append(report.Failed, info.replaced...)
+		switch {
+		case info.node == nil:
+			// no piece was to be stored for a nil node
+		case info.err == nil:
+			successfulNodes[info.i] = info.node
+			report.Hashes[info.i] = info.hash
 			successfulCount++
+			if successfulCount == rs.OptimalThreshold() {
+				cancel()
+			}
+		case info.err == io.ErrUnexpectedEOF || putCtx.Err() != nil:
+			report.Slow = append(report.Slow, info.node)
+			ec.reportSlow(ctx, info.node)
+		default:
+			report.Failed = append(report.Failed, info.node)
 		}
 	}
 
@@ -145,21 +222,57 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 		case <-ctx.Done():
 			err = utils.CombineErrors(
 				Error.New("upload cancelled by user"),
-				ec.Delete(context.Background(), nodes, pieceID, authorization),
+				ec.Delete(context.Background(), used.nodes, pieceID, authorization),
 			)
 		default:
 		}
 	}()
 
 	if successfulCount < rs.RepairThreshold() {
-		return nil, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
+		return nil, report, Error.New("successful puts (%d) less than repair threshold (%d)", successfulCount, rs.RepairThreshold())
 	}
 
-	return successfulNodes, nil
+	return successfulNodes, report, nil
+}
+
+// putPiece uploads the piece read from data to node n
+func (ec *ecClient) putPiece(ctx context.Context, n *pb.Node, pieceID psclient.PieceID, data io.Reader,
+	expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int) error {
+	derivedPieceID, err := pieceID.Derive(n.Id.Bytes())
+	if err != nil {
+		zap.S().Errorf("Failed deriving piece id for %s: %v", pieceID, err)
+		return err
+	}
+	ps, err := ec.newPSClient(ctx, n)
+	if err != nil {
+		zap.S().Errorf("Failed dialing for putting piece %s -> %s to node %s: %v",
+			pieceID, derivedPieceID, n.Id, err)
+		return err
+	}
+	err = ps.Put(ctx, derivedPieceID, data, expiration, pba, authorization, int64(shareSize))
+	// normally the bellow call should be deferred, but doing so fails
+	// randomly the unit tests
+	utils.LogClose(ps)
+	// io.ErrUnexpectedEOF means the piece upload was interrupted due to slow connection.
+	// No error logging for this case, the slow node is reported once the upload is done.
+	if err != nil && err != io.ErrUnexpectedEOF {
+		nodeAddress := "nil"
+		if n.Address != nil {
+			nodeAddress = n.Address.Address
+		}
+		zap.S().Errorf("Failed putting piece %s -> %s to node %s (%+v): %v",
+			pieceID, derivedPieceID, n.Id, nodeAddress, err)
+		// the nodes failing to be dialed are reported by the transport
+		if ctx.Err() == nil {
+			ec.reportFailure(ctx, n, err)
+		}
+	}
+	return err
 }
 
 func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
-	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
+	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage,
+	initial int, hashes [][]byte) (rr ranger.Ranger, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(nodes) != es.TotalCount() {
@@ -170,62 +283,103 @@ func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.Erasu
 		return nil, Error.New("number of non-nil nodes (%d) is less than required count (%d) of erasure scheme", nonNilCount(nodes), es.RequiredCount())
 	}
 
+	if initial < es.RequiredCount() {
+		initial = es.RequiredCount()
+	}
+
 	paddedSize := calcPadded(size, es.StripeSize())
 	pieceSize := paddedSize / int64(es.RequiredCount())
 	rrs := map[int]ranger.Ranger{}
+	spares := map[int]ranger.Ranger{}
 
-	type rangerInfo struct {
-		i   int
-		rr  ranger.Ranger
-		err error
-	}
-	ch := make(chan rangerInfo, len(nodes))
+	// the pieces downloaded from the start are chosen at random, the others
+	// are downloaded only if some of them fail or are too slow
+	for _, i := range rand.Perm(len(nodes)) {
+		n := nodes[i]
+		if n == nil {
+			continue
+		}
+		n.Type.DPanicOnInvalid("ec client Get")
 
-	for i, n := range nodes {
+		derivedPieceID, err := pieceID.Derive(n.Id.Bytes())
+		if err != nil {
+			zap.S().Errorf("Failed deriving piece id for %s: %v", pieceID, err)
+			continue
+		}
 
-		if n != nil {
-			n.Type.DPanicOnInvalid("ec client Get")
+		rr := &lazyPieceRanger{
+			newPSClientHelper: ec.newPSClient,
+			node:              n,
+			id:                derivedPieceID,
+			size:              pieceSize,
+			pba:               pba,
+			authorization:     authorization,
+			failure:           ec.reportFailure,
+		}
+		// the pieces stored without hashes are not verified
+		if i < len(hashes) && hashes[i] != nil {
+			rr.hash = &psclient.PieceHash{Root: hashes[i], ShareSize: int64(es.ErasureShareSize())}
 		}
 
-		if n == nil {
-			ch <- rangerInfo{i: i, rr: nil, err: nil}
-			continue
+		if len(rrs) < initial {
+			rrs[i] = rr
+		} else {
+			spares[i] = rr
 		}
+	}
 
-		go func(i int, n *pb.Node) {
-			derivedPieceID, err := pieceID.Derive(n.Id.Bytes())
-			if err != nil {
-				zap.S().Errorf("Failed deriving piece id for %s: %v", pieceID, err)
-				ch <- rangerInfo{i: i, rr: nil, err: err}
-				return
+	rr, err = eestream.DecodeWithOptions(rrs, es, ec.memoryLimit, eestream.DecodeOptions{
+		Spares:      spares,
+		SlowTimeout: ec.slowTimeout,
+		OnSlow: func(ctx context.Context, pieces []int) {
+			for _, i := range pieces {
+				ec.reportSlow(ctx, nodes[i])
 			}
-
-			rr := &lazyPieceRanger{
-				newPSClientHelper: ec.newPSClient,
-				node:              n,
-				id:                derivedPieceID,
-				size:              pieceSize,
-				pba:               pba,
-				authorization:     authorization,
+		},
+		OnCorrupted: func(ctx context.Context, pieces []int) {
+			for _, i := range pieces {
+				ec.reportCorrupted(ctx, nodes[i], ErrCorruptedPiece.New("%s", nodes[i].Id))
 			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			ch <- rangerInfo{i: i, rr: rr, err: nil}
-		}(i, n)
+	return eestream.Unpad(rr, int(paddedSize-size))
+}
+
+// reportFailure reports to the observers a node failing to serve a piece
+func (ec *ecClient) reportFailure(ctx context.Context, n *pb.Node, err error) {
+	if psclient.ErrVerification.Has(err) {
+		ec.reportCorrupted(ctx, n, err)
+		return
 	}
+	for _, o := range ec.observers {
+		o.ConnFailure(ctx, n, err)
+	}
+}
 
-	for range nodes {
-		rri := <-ch
-		if rri.err == nil && rri.rr != nil {
-			rrs[rri.i] = rri.rr
+// reportSlow reports to the observers a node too slow to serve or store a
+// piece. A slow node is still online, so it is not reported as a failure.
+func (ec *ecClient) reportSlow(ctx context.Context, n *pb.Node) {
+	mon.Meter("slow_pieces").Mark(1)
+	zap.S().Debugf("Node %s was too slow to serve or store a piece", n.Id)
+	for _, o := range ec.observers {
+		if slow, ok := o.(SlowObserver); ok {
+			slow.PieceSlow(ctx, n)
 		}
 	}
+}
 
-	rr, err = eestream.Decode(rrs, es, ec.memoryLimit)
-	if err != nil {
-		return nil, err
+// reportCorrupted reports to the corruption observer a node serving a
+// corrupted piece
+func (ec *ecClient) reportCorrupted(ctx context.Context, n *pb.Node, err error) {
+	mon.Meter("corrupted_pieces").Mark(1)
+	zap.S().Warnf("Node %s served a corrupted piece: %v", n.Id, err)
+	if ec.corruption != nil {
+		ec.corruption.PieceCorrupted(ctx, n, err)
 	}
-
-	return eestream.Unpad(rr, int(paddedSize-size))
 }
 
 func (ec *ecClient) Delete(ctx context.Context, nodes []*pb.Node, pieceID psclient.PieceID, authorization *pb.SignedMessage) (err error) {
@@ -334,6 +488,8 @@ type lazyPieceRanger struct {
 	size              int64
 	pba               *pb.PayerBandwidthAllocation
 	authorization     *pb.SignedMessage
+	hash              *psclient.PieceHash
+	failure           func(ctx context.Context, n *pb.Node, err error)
 }
 
 // Size implements Ranger.Size
@@ -349,13 +505,103 @@ func (lr *lazyPieceRanger) Range(ctx context.Context, offset, length int64) (io.
 		if err != nil {
 			return nil, err
 		}
-		ranger, err := ps.Get(ctx, lr.id, lr.size, lr.pba, lr.authorization)
+		ranger, err := ps.Get(ctx, lr.id, lr.size, lr.pba, lr.authorization, lr.hash)
 		if err != nil {
+			lr.reportFailure(ctx, err)
 			return nil, err
 		}
 		lr.ranger = ranger
 	}
-	return lr.ranger.Range(ctx, offset, length)
+	r, err := lr.ranger.Range(ctx, offset, length)
+	if err != nil {
+		lr.reportFailure(ctx, err)
+		return nil, err
+	}
+	return &observedReader{ReadCloser: r, failure: func(err error) { lr.reportFailure(ctx, err) }}, nil
+}
+
+func (lr *lazyPieceRanger) reportFailure(ctx context.Context, err error) {
+	if lr.failure != nil && ctx.Err() == nil {
+		lr.failure(ctx, lr.node, err)
+	}
+}
+
+// observedReader reports the first error reading a piece, unless the reader
+// was closed before
+type observedReader struct {
+	io.ReadCloser
+	failure func(err error)
+
+	mu       sync.Mutex
+	closed   bool
+	reported bool
+}
+
+// Read implements io.Reader
+func (r *observedReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		r.mu.Lock()
+		report := !r.closed && !r.reported
+		r.reported = true
+		r.mu.Unlock()
+		if report {
+			r.failure(err)
+		}
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (r *observedReader) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	return r.ReadCloser.Close()
+}
+
+// nodeList is a list of nodes safe for concurrent use
+type nodeList struct {
+	mu    sync.Mutex
+	nodes []*pb.Node
+}
+
+func newNodeList(nodes []*pb.Node) *nodeList {
+	list := &nodeList{}
+	for _, n := range nodes {
+		if n != nil {
+			list.nodes = append(list.nodes, n)
+		}
+	}
+	return list
+}
+
+func (list *nodeList) add(n *pb.Node) {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	list.nodes = append(list.nodes, n)
+}
+
+func (list *nodeList) ids() storj.NodeIDList {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	ids := make(storj.NodeIDList, 0, len(list.nodes))
+	for _, n := range list.nodes {
+		ids = append(ids, n.Id)
+	}
+	return ids
+}
+
+// countingReader counts the bytes read from reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
 }
 
 func nonNilCount(nodes []*pb.Node) int {
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 )
 
@@ -43,6 +45,7 @@ var (
 	node1 = teststorj.MockNode("node-1")
 	node2 = teststorj.MockNode("node-2")
 	node3 = teststorj.MockNode("node-3")
+	node4 = teststorj.MockNode("node-4")
 )
 
 func TestNewECClient(t *testing.T) {
@@ -53,7 +56,7 @@ func TestNewECClient(t *testing.T) {
 
 	privKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	identity := &provider.FullIdentity{Key: privKey}
-	ec := NewClient(identity, mbm)
+	ec := NewClient(identity, mbm, nil)
 	assert.NotNil(t, ec)
 
 	ecc, ok := ec.(*ecClient)
@@ -111,6 +114,8 @@ TestLoop:
 			"ecclient error: successful puts (1) less than repair threshold (2)"},
 		{[]*pb.Node{nil, nil, node2, node3}, 2, 0, false,
 			[]error{nil, nil, nil, nil}, ""},
+		{[]*pb.Node{node0, node1, node2, node3}, 2, 0, false,
+			[]error{nil, io.ErrUnexpectedEOF, ErrOpFailed, nil}, ""},
 	} {
 		errTag := fmt.Sprintf("Test case #%d", i)
 
@@ -134,8 +139,8 @@ TestLoop:
 			}
 			ps := NewMockPSClient(ctrl)
 			gomock.InOrder(
-				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(errs[n]).
-					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any(), int64(es.ErasureShareSize())).Return(errs[n]).
+					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int64) {
 						// simulate that the mocked piece store client is reading the data
 						_, err := io.Copy(ioutil.Discard, data)
 						assert.NoError(t, err, errTag)
@@ -149,9 +154,25 @@ TestLoop:
 			continue
 		}
 		r := io.LimitReader(rand.Reader, int64(size))
-		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
-
-		successfulNodes, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil)
+		observer := &slowRecorder{}
+		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm, observers: []transport.Observer{observer}}
+
+		successfulNodes, _, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil, nil)
+
+		// the nodes failing to store their piece, or too slow to, are
+		// reported to the observers
+		var failed, slow []*pb.Node
+		for i, err := range tt.errs {
+			switch {
+			case tt.badInput:
+			case err == ErrOpFailed || err == ErrDialFailed:
+				failed = append(failed, tt.nodes[i])
+			case err == io.ErrUnexpectedEOF:
+				slow = append(slow, tt.nodes[i])
+			}
+		}
+		assert.ElementsMatch(t, failed, observer.failed, errTag)
+		assert.ElementsMatch(t, slow, observer.slow, errTag)
 
 		if tt.errString != "" {
 			assert.EqualError(t, err, tt.errString, errTag)
@@ -169,6 +190,81 @@ TestLoop:
 	}
 }
 
+func TestPutReplacement(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	size := 32 * 1024
+	k := 2
+	n := 4
+	fc, err := infectious.NewFEC(k, n)
+	if !assert.NoError(t, err) {
+		return
+	}
+	es := eestream.NewRSScheme(fc, size/n)
+	rs, err := eestream.NewRedundancyStrategy(es, 3, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i, tt := range []struct {
+		replacement *pb.Node
+		replaceErr  error
+		successful  []*pb.Node
+	}{
+		{node4, nil, []*pb.Node{node0, node4, node2, node3}},
+		{nil, ErrOpFailed, []*pb.Node{node0, nil, node2, node3}},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		id := psclient.NewPieceID()
+		ttl := time.Now()
+
+		// node1 fails dialing, so its piece goes to the replacement
+		clients := make(map[*pb.Node]psclient.Client)
+		for _, n := range []*pb.Node{node0, node2, node3, node4} {
+			if n == node4 && tt.replacement == nil {
+				continue
+			}
+			derivedID, err := id.Derive(n.Id.Bytes())
+			if !assert.NoError(t, err, errTag) {
+				return
+			}
+			ps := NewMockPSClient(ctrl)
+			gomock.InOrder(
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any(), int64(es.ErasureShareSize())).Return(nil).
+					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage, shareSize int64) {
+						_, err := io.Copy(ioutil.Discard, data)
+						assert.NoError(t, err, errTag)
+					}),
+				ps.EXPECT().Close().Return(nil),
+			)
+			clients[n] = ps
+		}
+
+		var excluded storj.NodeIDList
+		replace := func(ctx context.Context, ids storj.NodeIDList) (*pb.Node, error) {
+			excluded = ids
+			return tt.replacement, tt.replaceErr
+		}
+
+		r := io.LimitReader(rand.Reader, int64(size))
+		ec := ecClient{newPSClientFunc: mockNewPSClient(clients)}
+
+		nodes := []*pb.Node{node0, node1, node2, node3}
+		successfulNodes, report, err := ec.Put(ctx, nodes, rs, id, r, ttl, nil, nil, replace)
+
+		assert.ElementsMatch(t, storj.NodeIDList{node0.Id, node1.Id, node2.Id, node3.Id}, excluded, errTag)
+		assert.Equal(t, []*pb.Node{node1}, report.Failed, errTag)
+		assert.Empty(t, report.Slow, errTag)
+
+		if assert.NoError(t, err, errTag) {
+			assert.Equal(t, tt.successful, successfulNodes, errTag)
+		}
+	}
+}
+
 func mockNewPSClient(clients map[*pb.Node]psclient.Client) psClientFunc {
 	return func(_ context.Context, _ transport.Client, n *pb.Node, _ int) (psclient.Client, error) {
 		n.Type.DPanicOnInvalid("mock new ps client")
@@ -237,12 +333,12 @@ TestLoop:
 					continue TestLoop
 				}
 				ps := NewMockPSClient(ctrl)
-				ps.EXPECT().Get(gomock.Any(), derivedID, int64(size/k), gomock.Any(), gomock.Any()).Return(ranger.ByteRanger(nil), errs[n])
+				ps.EXPECT().Get(gomock.Any(), derivedID, int64(size/k), gomock.Any(), gomock.Any(), gomock.Any()).Return(ranger.ByteRanger(nil), errs[n])
 				clients[n] = ps
 			}
 		}
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
-		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), nil, nil)
+		rr, err := ec.Get(ctx, tt.nodes, es, id, int64(size), nil, nil, n, nil)
 		if err == nil {
 			_, err := rr.Range(ctx, 0, 0)
 			assert.NoError(t, err, errTag)
@@ -337,3 +433,45 @@ func TestUnique(t *testing.T) {
 		assert.Equal(t, tt.unique, unique(tt.nodes), errTag)
 	}
 }
+
+// observerRecorder records the nodes reported to a transport observer
+type observerRecorder struct {
+	mu     sync.Mutex
+	failed []*pb.Node
+}
+
+func (recorder *observerRecorder) ConnSuccess(ctx context.Context, node *pb.Node) {}
+
+func (recorder *observerRecorder) ConnFailure(ctx context.Context, node *pb.Node, err error) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.failed = append(recorder.failed, node)
+}
+
+// slowRecorder also records the slow nodes reported to it
+type slowRecorder struct {
+	observerRecorder
+	slow []*pb.Node
+}
+
+func (recorder *slowRecorder) PieceSlow(ctx context.Context, node *pb.Node) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.slow = append(recorder.slow, node)
+}
+
+func TestReportSlow(t *testing.T) {
+	ctx := context.Background()
+
+	transportOnly := &observerRecorder{}
+	slow := &slowRecorder{}
+	ec := ecClient{observers: []transport.Observer{transportOnly, slow}}
+
+	ec.reportSlow(ctx, node0)
+	ec.reportSlow(ctx, node1)
+
+	// a slow node is not reported as failing
+	assert.Empty(t, transportOnly.failed)
+	assert.Empty(t, slow.failed)
+	assert.Equal(t, []*pb.Node{node0, node1}, slow.slow)
+}
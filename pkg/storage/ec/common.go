@@ -9,3 +9,7 @@ import (
 
 // Error is the errs class of standard Ranger errors
 var Error = errs.Class("ecclient error")
+
+// ErrCorruptedPiece is the errs class reported for nodes serving corrupted
+// erasure shares
+var ErrCorruptedPiece = errs.Class("corrupted piece")
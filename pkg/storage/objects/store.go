@@ -24,10 +24,12 @@ var mon = monkit.Package()
 // Meta is the full object metadata
 type Meta struct {
 	pb.SerializableMeta
-	Modified   time.Time
-	Expiration time.Time
-	Size       int64
-	Checksum   string
+	Modified      time.Time
+	Expiration    time.Time
+	Size          int64
+	SegmentCount  int64
+	Checksum      string
+	ChecksumParts int64
 }
 
 // ListItem is a single item in a listing
@@ -155,6 +157,9 @@ func convertMeta(m streams.Meta) Meta {
 		Modified:         m.Modified,
 		Expiration:       m.Expiration,
 		Size:             m.Size,
+		SegmentCount:     m.SegmentCount,
+		Checksum:         string(m.Checksum),
+		ChecksumParts:    m.ChecksumParts,
 		SerializableMeta: ser,
 	}
 }
@@ -12,6 +12,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	pb "storj.io/storj/pkg/pb"
 	ranger "storj.io/storj/pkg/ranger"
 	storj "storj.io/storj/pkg/storj"
 )
@@ -103,6 +104,18 @@ func (mr *MockStoreMockRecorder) Delete(ctx, path interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), ctx, path)
 }
 
+// DeletePieces mocks base method
+func (m *MockStore) DeletePieces(ctx context.Context, path storj.Path, pointer *pb.Pointer) error {
+	ret := m.ctrl.Call(m, "DeletePieces", ctx, path, pointer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePieces indicates an expected call of DeletePieces
+func (mr *MockStoreMockRecorder) DeletePieces(ctx, path, pointer interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePieces", reflect.TypeOf((*MockStore)(nil).DeletePieces), ctx, path, pointer)
+}
+
 // List mocks base method
 func (m *MockStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) ([]ListItem, bool, error) {
 	ret := m.ctrl.Call(m, "List", ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags)
@@ -6,6 +6,7 @@ package segments
 import (
 	"context"
 
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
@@ -119,8 +120,26 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 
 	signedMessage := s.pdb.SignedMessage()
 
-	// Download the segment using just the healthyNodes
-	rr, err := s.ec.Get(ctx, healthyNodes, rs, pid, pr.GetSegmentSize(), pba, signedMessage)
+	hashes, err := pieceHashes(pr.GetRemote())
+	if err != nil {
+		return err
+	}
+
+	// Download the segment using just the healthyNodes. The erasure schemes
+	// able to rebuild the lost pieces from fewer pieces download only those
+	// when the erasure shares are verified with hashes, as no extra pieces
+	// are needed to detect corrupted erasure shares.
+	needed := int(calcNeededNodes(pr.GetRemote().GetRedundancy()))
+	if local, ok := rs.ErasureScheme.(eestream.LocalRepairer); ok && hashes != nil {
+		var missing []int
+		for i, v := range healthyNodes {
+			if v == nil {
+				missing = append(missing, i)
+			}
+		}
+		needed = local.RepairCount(missing)
+	}
+	rr, err := s.ec.Get(ctx, healthyNodes, rs, pid, pr.GetSegmentSize(), pba, signedMessage, needed, hashes)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -131,26 +150,44 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	}
 	defer utils.LogClose(r)
 
+	// the replacements of failed repair nodes exclude the healthy nodes too
+	replace := func(ctx context.Context, excluded storj.NodeIDList) (*pb.Node, error) {
+		excluded = append(excluded, excludeNodeIDs...)
+		return chooseNode(ctx, s.oc, overlay.Options{Amount: 1, Space: 0, Excluded: excluded})
+	}
+
 	// Upload the repaired pieces to the repairNodes
-	successfulNodes, err := s.ec.Put(ctx, repairNodes, rs, pid, r, convertTime(pr.GetExpirationDate()), pba, signedMessage)
+	successfulNodes, report, err := s.ec.Put(ctx, repairNodes, rs, pid, r, convertTime(pr.GetExpirationDate()), pba, signedMessage, replace)
+	observePutReport(report)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
 	// Merge the successful nodes list into the healthy nodes list
+	if hashes == nil {
+		hashes = make([][]byte, len(healthyNodes))
+	}
 	for i, v := range healthyNodes {
 		if v == nil {
 			// copy the successfuNode info
 			healthyNodes[i] = successfulNodes[i]
+			if i < len(report.Hashes) {
+				hashes[i] = report.Hashes[i]
+			}
 		}
 	}
 
 	metadata := pr.GetMetadata()
-	pointer, err := makeRemotePointer(healthyNodes, rs, pid, rr.Size(), pr.GetExpirationDate(), metadata)
+	pointer, err := makeRemotePointer(healthyNodes, hashes, rs, pid, rr.Size(), pr.GetExpirationDate(), metadata)
 	if err != nil {
 		return err
 	}
+	pointer.Remote.SharedPaths = seg.GetSharedPaths()
 
-	// update the segment info in the pointerDB
-	return s.pdb.Put(ctx, path, pointer)
+	// update the segment info in the pointerDB, and in the pointers sharing
+	// its pieces
+	if err := s.pdb.Put(ctx, path, pointer); err != nil {
+		return err
+	}
+	return updateSharedPieces(ctx, s.pdb, pointer)
 }
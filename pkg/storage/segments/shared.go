@@ -0,0 +1,153 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package segments
+
+import (
+	"context"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// The pointers referencing the same remote pieces, like an object and its
+// server-side copies, list the paths of each other in their shared paths.
+// The pieces are only deleted from the storage nodes with the last of them,
+// and a repair of one of them updates all of them.
+
+// SharePieces records that the pointer about to be put at path references the
+// pieces of the pointer src at srcPath, in src and in the pointers already
+// sharing them. It returns the shared paths of the new pointer.
+func SharePieces(ctx context.Context, pdb pdbclient.Client, srcPath storj.Path, src *pb.Pointer, path storj.Path) (shared []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if src.GetType() != pb.Pointer_REMOTE {
+		return nil, nil
+	}
+
+	// the new pointer is listed before it is put, so an interrupted copy
+	// leaks its pieces rather than losing them
+	remote := src.GetRemote()
+	for _, sharedPath := range remote.SharedPaths {
+		_, err := updateSharedPaths(ctx, pdb, sharedPath, remote.PieceId, func(paths []string) []string {
+			return appendPath(paths, path)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shared = appendPath(append([]string(nil), remote.SharedPaths...), srcPath)
+	remote.SharedPaths = appendPath(remote.SharedPaths, path)
+	if err := pdb.Put(ctx, srcPath, src); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return shared, nil
+}
+
+// MoveSharedPieces records that the pointer about to be put at path
+// references the pieces of the pointer src, in the pointers sharing them.
+// The caller unshares src when deleting it.
+func MoveSharedPieces(ctx context.Context, pdb pdbclient.Client, src *pb.Pointer, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	remote := src.GetRemote()
+	for _, sharedPath := range remote.GetSharedPaths() {
+		_, err := updateSharedPaths(ctx, pdb, sharedPath, remote.PieceId, func(paths []string) []string {
+			return appendPath(paths, path)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnsharePieces removes the pointer at path from the pointers sharing its
+// pieces, returning whether any of them still references the pieces
+func UnsharePieces(ctx context.Context, pdb pdbclient.Client, path storj.Path, pointer *pb.Pointer) (shared bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	remote := pointer.GetRemote()
+	for _, sharedPath := range remote.GetSharedPaths() {
+		sharing, err := updateSharedPaths(ctx, pdb, sharedPath, remote.PieceId, func(paths []string) []string {
+			return removePath(paths, path)
+		})
+		if err != nil {
+			return false, err
+		}
+		shared = shared || sharing
+	}
+	return shared, nil
+}
+
+// updateSharedPieces updates the remote pieces of the pointers sharing them
+// with the repaired pointer
+func updateSharedPieces(ctx context.Context, pdb pdbclient.Client, pointer *pb.Pointer) error {
+	remote := pointer.GetRemote()
+	for _, sharedPath := range remote.GetSharedPaths() {
+		shared, _, _, err := pdb.Get(ctx, sharedPath)
+		if err != nil {
+			if storage.ErrKeyNotFound.Has(err) {
+				continue
+			}
+			return Error.Wrap(err)
+		}
+		if shared.GetType() != pb.Pointer_REMOTE || shared.GetRemote().GetPieceId() != remote.PieceId {
+			continue
+		}
+
+		shared.Remote.RemotePieces = remote.RemotePieces
+		shared.Remote.MerkleRoot = remote.MerkleRoot
+		shared.Remote.PieceHashes = remote.PieceHashes
+		if err := pdb.Put(ctx, sharedPath, shared); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// updateSharedPaths updates the shared paths of the pointer at path, if it
+// still references the pieces with pieceID, returning whether it does
+func updateSharedPaths(ctx context.Context, pdb pdbclient.Client, path storj.Path, pieceID string, update func([]string) []string) (bool, error) {
+	pointer, _, _, err := pdb.Get(ctx, path)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return false, nil
+		}
+		return false, Error.Wrap(err)
+	}
+	// the path may have been reused since for other pieces
+	if pointer.GetType() != pb.Pointer_REMOTE || pointer.GetRemote().GetPieceId() != pieceID {
+		return false, nil
+	}
+
+	pointer.Remote.SharedPaths = update(pointer.Remote.SharedPaths)
+	if err := pdb.Put(ctx, path, pointer); err != nil {
+		return false, Error.Wrap(err)
+	}
+	return true, nil
+}
+
+// appendPath appends path to paths unless it is listed already
+func appendPath(paths []string, path storj.Path) []string {
+	for _, p := range paths {
+		if p == path {
+			return paths
+		}
+	}
+	return append(paths, path)
+}
+
+// removePath removes path from paths
+func removePath(paths []string, path storj.Path) []string {
+	var result []string
+	for _, p := range paths {
+		if p != path {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -4,18 +4,18 @@
 package segments
 
 import (
+	"bytes"
 	"context"
 	"io"
-	"math/rand"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
-	"github.com/vivint/infectious"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/merkle"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
@@ -50,6 +50,7 @@ type Store interface {
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
 	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	DeletePieces(ctx context.Context, path storj.Path, pointer *pb.Pointer) (err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -135,7 +136,18 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 			return Meta{}, Error.Wrap(err)
 		}
 
-		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
+		// pieces failing to upload are retried on other nodes of the same kind
+		replace := func(ctx context.Context, excluded storj.NodeIDList) (*pb.Node, error) {
+			return chooseNode(ctx, s.oc, overlay.Options{
+				Amount:    1,
+				Bandwidth: sizedReader.Size() / int64(s.rs.TotalCount()),
+				Space:     sizedReader.Size() / int64(s.rs.TotalCount()),
+				Excluded:  excluded,
+			})
+		}
+
+		successfulNodes, report, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization, replace)
+		observePutReport(report)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
@@ -146,7 +158,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		}
 		path = p
 
-		pointer, err = makeRemotePointer(successfulNodes, s.rs, pieceID, sizedReader.Size(), exp, metadata)
+		pointer, err = makeRemotePointer(successfulNodes, report.Hashes, s.rs, pieceID, sizedReader.Size(), exp, metadata)
 		if err != nil {
 			return Meta{}, err
 		}
@@ -166,6 +178,28 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 	return m, nil
 }
 
+// chooseNode asks the overlay for a single node matching op
+func chooseNode(ctx context.Context, oc overlay.Client, op overlay.Options) (*pb.Node, error) {
+	nodes, err := oc.Choose(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, Error.New("no replacement node available")
+	}
+	return nodes[0], nil
+}
+
+// observePutReport records the nodes that were slow or failed during an
+// upload, the ec client having reported them to its observers
+func observePutReport(report ecclient.PutReport) {
+	mon.IntVal("put_slow_nodes").Observe(int64(len(report.Slow)))
+	mon.IntVal("put_failed_nodes").Observe(int64(len(report.Failed)))
+	for _, n := range report.Failed {
+		zap.S().Debugf("Node %s failed to store a piece", n.Id)
+	}
+}
+
 // Get retrieves a segment using erasure code, overlay, and pointerdb clients
 func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -192,26 +226,17 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 			return nil, Meta{}, err
 		}
 
-		needed := calcNeededNodes(pr.GetRemote().GetRedundancy())
-		selected := make([]*pb.Node, rs.TotalCount())
-
-		for _, i := range rand.Perm(len(nodes)) {
-			node := nodes[i]
-			if node == nil {
-				continue
-			}
-
-			selected[i] = node
-
-			needed--
-			if needed <= 0 {
-				break
-			}
-			node.Type.DPanicOnInvalid("ss get")
+		hashes, err := pieceHashes(seg)
+		if err != nil {
+			return nil, Meta{}, err
 		}
 
+		// only the needed nodes are downloaded from at first, the others
+		// only if some of them fail or are too slow
+		needed := calcNeededNodes(pr.GetRemote().GetRedundancy())
+
 		authorization := s.pdb.SignedMessage()
-		rr, err = s.ec.Get(ctx, selected, rs, pid, pr.GetSegmentSize(), pba, authorization)
+		rr, err = s.ec.Get(ctx, nodes, rs, pid, pr.GetSegmentSize(), pba, authorization, int(needed), hashes)
 		if err != nil {
 			return nil, Meta{}, Error.Wrap(err)
 		}
@@ -222,33 +247,61 @@ func (s *segmentStore) Get(ctx context.Context, path storj.Path) (rr ranger.Rang
 	return rr, convertMeta(pr), nil
 }
 
-// makeRemotePointer creates a pointer of type remote
-func makeRemotePointer(nodes []*pb.Node, rs eestream.RedundancyStrategy, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
+// makeRemotePointer creates a pointer of type remote. The hashes of the
+// pieces, indexed like nodes, are stored with their root hash if all of the
+// pieces have one.
+func makeRemotePointer(nodes []*pb.Node, hashes [][]byte, rs eestream.RedundancyStrategy, pieceID psclient.PieceID, readerSize int64, exp *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
+	redundancy := &pb.RedundancyScheme{
+		Type:             eestream.SchemeType(rs),
+		MinReq:           int32(rs.RequiredCount()),
+		Total:            int32(rs.TotalCount()),
+		RepairThreshold:  int32(rs.RepairThreshold()),
+		SuccessThreshold: int32(rs.OptimalThreshold()),
+		ErasureShareSize: int32(rs.ErasureShareSize()),
+	}
+
 	var remotePieces []*pb.RemotePiece
+	// the root hash is over the hashes of all of the piece numbers, the
+	// pieces never stored having none, so that it stays valid when pieces
+	// are dropped or moved to other nodes
+	pieceHashes := make([][]byte, redundancy.Total)
+	missingHash := false
 	for i := range nodes {
 		if nodes[i] == nil {
 			continue
 		}
 		nodes[i].Type.DPanicOnInvalid("makeremotepointer")
+		if i >= len(pieceHashes) {
+			return nil, Error.New("invalid piece number %d", i)
+		}
+		var hash []byte
+		if i < len(hashes) {
+			hash = hashes[i]
+		}
 		remotePieces = append(remotePieces, &pb.RemotePiece{
 			PieceNum: int32(i),
 			NodeId:   nodes[i].Id,
+			Hash:     hash,
 		})
+		pieceHashes[i] = hash
+		missingHash = missingHash || hash == nil
+	}
+
+	var merkleRoot []byte
+	if len(remotePieces) > 0 && !missingHash {
+		merkleRoot = merkle.Root(pieceHashes)
+	} else {
+		pieceHashes = nil
 	}
 
 	pointer = &pb.Pointer{
 		Type: pb.Pointer_REMOTE,
 		Remote: &pb.RemoteSegment{
-			Redundancy: &pb.RedundancyScheme{
-				Type:             pb.RedundancyScheme_RS,
-				MinReq:           int32(rs.RequiredCount()),
-				Total:            int32(rs.TotalCount()),
-				RepairThreshold:  int32(rs.RepairThreshold()),
-				SuccessThreshold: int32(rs.OptimalThreshold()),
-				ErasureShareSize: int32(rs.ErasureShareSize()),
-			},
+			Redundancy:   redundancy,
 			PieceId:      string(pieceID),
 			RemotePieces: remotePieces,
+			MerkleRoot:   merkleRoot,
+			PieceHashes:  pieceHashes,
 		},
 		SegmentSize:    readerSize,
 		ExpirationDate: exp,
@@ -266,30 +319,53 @@ func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error)
 		return Error.Wrap(err)
 	}
 
-	if pr.GetType() == pb.Pointer_REMOTE {
-		seg := pr.GetRemote()
-		pid := psclient.PieceID(seg.PieceId)
+	err = s.deletePieces(ctx, path, pr, nodes)
+	if err != nil {
+		return err
+	}
 
-		nodes, err = lookupAndAlignNodes(ctx, s.oc, nodes, seg)
-		if err != nil {
-			return Error.Wrap(err)
-		}
-		for _, v := range nodes {
-			if v != nil {
-				v.Type.DPanicOnInvalid("ss delete")
-			}
-		}
+	// deletes pointer from pointerdb
+	return s.pdb.Delete(ctx, path)
+}
 
-		authorization := s.pdb.SignedMessage()
-		// ecclient sends delete request
-		err = s.ec.Delete(ctx, nodes, pid, authorization)
-		if err != nil {
-			return Error.Wrap(err)
+// DeletePieces deletes the pieces of pointer, which was stored at path
+// before being replaced there, from the storage nodes
+func (s *segmentStore) DeletePieces(ctx context.Context, path storj.Path, pointer *pb.Pointer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.deletePieces(ctx, path, pointer, nil)
+}
+
+// deletePieces deletes the pieces of the pointer at path from the nodes,
+// looking the nodes up if nil
+func (s *segmentStore) deletePieces(ctx context.Context, path storj.Path, pr *pb.Pointer, nodes []*pb.Node) (err error) {
+	if pr.GetType() != pb.Pointer_REMOTE {
+		return nil
+	}
+
+	// pieces still referenced by another pointer stay on the storage nodes
+	shared, err := UnsharePieces(ctx, s.pdb, path, pr)
+	if err != nil || shared {
+		return err
+	}
+
+	seg := pr.GetRemote()
+	pid := psclient.PieceID(seg.PieceId)
+
+	nodes, err = lookupAndAlignNodes(ctx, s.oc, nodes, seg)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	for _, v := range nodes {
+		if v != nil {
+			v.Type.DPanicOnInvalid("ss delete")
 		}
 	}
 
-	// deletes pointer from pointerdb
-	return s.pdb.Delete(ctx, path)
+	authorization := s.pdb.SignedMessage()
+	// ecclient sends delete request
+	err = s.ec.Delete(ctx, nodes, pid, authorization)
+	return Error.Wrap(err)
 }
 
 // List retrieves paths to segments and their metadata stored in the pointerdb
@@ -314,11 +390,10 @@ func (s *segmentStore) List(ctx context.Context, prefix, startAfter, endBefore s
 }
 
 func makeRedundancyStrategy(scheme *pb.RedundancyScheme) (eestream.RedundancyStrategy, error) {
-	fc, err := infectious.NewFEC(int(scheme.GetMinReq()), int(scheme.GetTotal()))
+	es, err := eestream.NewScheme(scheme)
 	if err != nil {
 		return eestream.RedundancyStrategy{}, Error.Wrap(err)
 	}
-	es := eestream.NewRSScheme(fc, int(scheme.GetErasureShareSize()))
 	return eestream.NewRedundancyStrategy(es, int(scheme.GetRepairThreshold()), int(scheme.GetSuccessThreshold()))
 }
 
@@ -375,6 +450,43 @@ func lookupAndAlignNodes(ctx context.Context, oc overlay.Client, nodes []*pb.Nod
 	return result, nil
 }
 
+// pieceHashes returns the hashes of the pieces of a segment, indexed by piece
+// number, after checking them against the root hash of the segment. The
+// pieces stored without hashes have a nil one.
+func pieceHashes(seg *pb.RemoteSegment) ([][]byte, error) {
+	if seg.GetMerkleRoot() == nil {
+		// the pieces of a segment stored before the hashes existed are not
+		// verified
+		return nil, nil
+	}
+
+	hashes := seg.GetPieceHashes()
+	if len(hashes) != int(seg.GetRedundancy().GetTotal()) {
+		return nil, Error.New("%d piece hashes for %d pieces", len(hashes), seg.GetRedundancy().GetTotal())
+	}
+	if !bytes.Equal(merkle.Root(hashes), seg.GetMerkleRoot()) {
+		return nil, Error.New("piece hashes do not match the segment root hash")
+	}
+
+	for _, p := range seg.GetRemotePieces() {
+		if p.PieceNum < 0 || int(p.PieceNum) >= len(hashes) {
+			return nil, Error.New("invalid piece number %d", p.PieceNum)
+		}
+		if p.Hash != nil && !bytes.Equal(p.Hash, hashes[p.PieceNum]) {
+			return nil, Error.New("hash of piece %d does not match the segment piece hashes", p.PieceNum)
+		}
+	}
+
+	result := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		// the pieces never stored have an empty hash
+		if len(hash) > 0 {
+			result[i] = hash
+		}
+	}
+	return result, nil
+}
+
 // contains checks if n exists in list
 func contains(list []int32, n int) bool {
 	for i := range list {
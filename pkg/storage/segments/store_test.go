@@ -25,6 +25,7 @@ import (
 	pdb "storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -121,7 +122,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 			mockPDB.EXPECT().SignedMessage(),
 			mockPDB.EXPECT().PayerBandwidthAllocation(gomock.Any(), gomock.Any()),
 			mockEC.EXPECT().Put(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
 			mockES.EXPECT().RequiredCount().Return(1),
 			mockES.EXPECT().TotalCount().Return(1),
@@ -284,7 +285,7 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
 			mockPDB.EXPECT().SignedMessage(),
 			mockEC.EXPECT().Get(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
 		}
 		gomock.InOrder(calls...)
@@ -294,6 +295,59 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 	}
 }
 
+func TestSegmentStoreGetDroppedPiece(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	es, err := eestream.NewReplicationScheme(3, 8)
+	if !assert.NoError(t, err) {
+		return
+	}
+	rs, err := eestream.NewRedundancyStrategy(es, 0, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the second piece was never stored
+	nodes := []*pb.Node{teststorj.MockNode("1"), nil, teststorj.MockNode("3")}
+	hashes := [][]byte{[]byte("hash1"), nil, []byte("hash3")}
+	pointer, err := makeRemotePointer(nodes, hashes, rs, "here's my piece id", 3, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	hashes, err = pieceHashes(pointer.GetRemote())
+	if assert.NoError(t, err) {
+		assert.Equal(t, [][]byte{[]byte("hash1"), nil, []byte("hash3")}, hashes)
+	}
+
+	// the first piece is dropped, as after a failed transfer
+	remote := pointer.GetRemote()
+	remote.RemotePieces = remote.RemotePieces[1:]
+
+	mockOC := mock_overlay.NewMockClient(ctrl)
+	mockEC := mock_ecclient.NewMockClient(ctrl)
+	mockPDB := mock_pointerdb.NewMockClient(ctrl)
+	ss := segmentStore{mockOC, mockEC, mockPDB, rs, 10}
+
+	gomock.InOrder(
+		mockPDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(pointer, nil, nil, nil),
+		mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()).Return([]*pb.Node{teststorj.MockNode("3")}, nil),
+		mockPDB.EXPECT().SignedMessage(),
+		mockEC.EXPECT().Get(
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			[][]byte{[]byte("hash1"), nil, []byte("hash3")},
+		),
+	)
+
+	_, _, err = ss.Get(ctx, "path/1/2/3")
+	assert.NoError(t, err)
+
+	// the segment is rejected with altered piece hashes
+	remote.PieceHashes[2] = []byte("altered")
+	_, err = pieceHashes(remote)
+	assert.Error(t, err)
+}
+
 func TestSegmentStoreDeleteInline(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -410,6 +464,65 @@ func TestSegmentStoreDeleteRemote(t *testing.T) {
 	}
 }
 
+func TestSegmentStoreDeleteShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remote := func(pieceID string, shared ...string) *pb.Pointer {
+		return &pb.Pointer{
+			Type: pb.Pointer_REMOTE,
+			Remote: &pb.RemoteSegment{
+				Redundancy:  &pb.RedundancyScheme{Type: pb.RedundancyScheme_RS, MinReq: 1, Total: 2},
+				PieceId:     pieceID,
+				SharedPaths: shared,
+			},
+		}
+	}
+
+	for i, tt := range []struct {
+		copy          *pb.Pointer
+		deletesPieces bool
+	}{
+		{ // the pieces are kept for the copy still referencing them
+			copy:          remote("piece", "path/1", "path/3"),
+			deletesPieces: false,
+		},
+		{ // the pieces are deleted when the copy references other pieces
+			copy:          remote("other piece", "path/1"),
+			deletesPieces: true,
+		},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		mockOC := mock_overlay.NewMockClient(ctrl)
+		mockEC := mock_ecclient.NewMockClient(ctrl)
+		mockPDB := mock_pointerdb.NewMockClient(ctrl)
+		ss := segmentStore{mockOC, mockEC, mockPDB, eestream.RedundancyStrategy{}, 10}
+
+		calls := []*gomock.Call{
+			mockPDB.EXPECT().Get(gomock.Any(), "path/1").Return(remote("piece", "path/2", "path/3"), nil, nil, nil),
+			mockPDB.EXPECT().Get(gomock.Any(), "path/2").Return(tt.copy, nil, nil, nil),
+		}
+		if !tt.deletesPieces {
+			calls = append(calls, mockPDB.EXPECT().Put(gomock.Any(), "path/2", remote("piece", "path/3")))
+		}
+		// the deleted pointer at path/3 does not reference the pieces
+		calls = append(calls, mockPDB.EXPECT().Get(gomock.Any(), "path/3").Return(nil, nil, nil, storage.ErrKeyNotFound.New("path/3")))
+		if tt.deletesPieces {
+			calls = append(calls,
+				mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
+				mockPDB.EXPECT().SignedMessage(),
+				mockEC.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+			)
+		}
+		calls = append(calls, mockPDB.EXPECT().Delete(gomock.Any(), "path/1"))
+		gomock.InOrder(calls...)
+
+		err := ss.Delete(ctx, "path/1")
+		assert.NoError(t, err, errTag)
+	}
+}
+
 func TestSegmentStoreList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,115 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding"
+	"hash"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+// ErrChecksum is the error class for content not matching its checksum
+var ErrChecksum = errs.Class("checksum mismatch")
+
+// PartsChecksum returns the checksum of a stream concatenated from parts with
+// the given checksums, the way S3 computes the ETags of multipart uploads: the
+// MD5 checksum of the concatenated part checksums.
+func PartsChecksum(partChecksums [][]byte) []byte {
+	hash := md5.New()
+	for _, checksum := range partChecksums {
+		_, _ = hash.Write(checksum)
+	}
+	return hash.Sum(nil)
+}
+
+// encryptChecksumState returns the state of the checksums at the end of a
+// segment, encrypted with its content key and the zero nonce, which is not
+// used for the content of a segment before the last one
+func encryptChecksumState(segmentHash, streamHash hash.Hash, cipher storj.Cipher, contentKey *storj.Key) ([]byte, error) {
+	streamState, err := streamHash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := proto.Marshal(&pb.ChecksumState{
+		SegmentChecksum: segmentHash.Sum(nil),
+		StreamHash:      streamState,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.Encrypt(state, cipher, contentKey, &storj.Nonce{})
+}
+
+// decryptChecksumState returns the state of the checksums at the end of the
+// segment with meta, or nil if it was stored without it
+func decryptChecksumState(meta *pb.SegmentMeta, cipher storj.Cipher, derivedKey *storj.Key) (*pb.ChecksumState, error) {
+	if len(meta.EncryptedChecksumState) == 0 {
+		return nil, nil
+	}
+
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(meta)
+	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encryption.Decrypt(meta.EncryptedChecksumState, cipher, contentKey, &storj.Nonce{})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &pb.ChecksumState{}
+	err = proto.Unmarshal(data, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// checksumRanger verifies the MD5 checksum of a segment when it is read
+// completely. Partial reads are not verified.
+type checksumRanger struct {
+	ranger.Ranger
+	checksum []byte
+}
+
+// Range implements Ranger.Range
+func (rr *checksumRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	reader, err := rr.Ranger.Range(ctx, offset, length)
+	if err != nil || offset != 0 || length != rr.Size() {
+		return reader, err
+	}
+	return &checksumReader{ReadCloser: reader, hash: md5.New(), checksum: rr.checksum}, nil
+}
+
+// checksumReader returns an error at the end of the data if it does not
+// match the checksum
+type checksumReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	checksum []byte
+}
+
+// Read implements io.Reader
+func (r *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	_, _ = r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.checksum) {
+		return n, ErrChecksum.New("expected %x, got %x", r.checksum, r.hash.Sum(nil))
+	}
+	return n, err
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// PutPending stores an empty segment at p/<path>, which marks the stream
+// as being uploaded until the upload is committed or aborted. The given
+// metadata is kept in its stream info, the same way as in l/<path>, with
+// the redundancy and encryption schemes of the upload.
+func (s *streamStore) PutPending(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte, expiration time.Time, redundancy *pb.RedundancyScheme, es storj.EncryptionScheme) (m Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	derivedKey, err := s.key.DeriveContentKey(path)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	var contentKey storj.Key
+	_, err = rand.Read(contentKey[:])
+	if err != nil {
+		return Meta{}, err
+	}
+
+	var keyNonce storj.Nonce
+	_, err = rand.Read(keyNonce[:])
+	if err != nil {
+		return Meta{}, err
+	}
+
+	encryptedKey, err := encryption.EncryptKey(&contentKey, es.Cipher, derivedKey, &keyNonce)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	streamInfo, err := proto.Marshal(&pb.StreamInfo{
+		Metadata:   metadata,
+		Redundancy: redundancy,
+	})
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// encrypt metadata with the content encryption key and zero nonce
+	encryptedStreamInfo, err := encryption.Encrypt(streamInfo, es.Cipher, &contentKey, &storj.Nonce{})
+	if err != nil {
+		return Meta{}, err
+	}
+
+	streamMeta := pb.StreamMeta{
+		EncryptedStreamInfo: encryptedStreamInfo,
+		EncryptionType:      int32(es.Cipher),
+		EncryptionBlockSize: es.BlockSize,
+	}
+
+	if es.Cipher != storj.Unencrypted {
+		streamMeta.LastSegmentMeta = &pb.SegmentMeta{
+			EncryptedKey: encryptedKey,
+			KeyNonce:     keyNonce[:],
+		}
+	}
+
+	pendingMeta, err := proto.Marshal(&streamMeta)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	putMeta, err := s.segments.Put(ctx, bytes.NewReader(nil), expiration, func() (storj.Path, []byte, error) {
+		encPath, err := s.key.EncryptPath(path, pathCipher)
+		if err != nil {
+			return "", nil, err
+		}
+		return storj.JoinPaths("p", encPath), pendingMeta, nil
+	})
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{
+		Modified:   putMeta.Modified,
+		Expiration: expiration,
+		Data:       metadata,
+	}, nil
+}
+
+// DeletePending deletes the p/<path> marker of a stream
+func (s *streamStore) DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := s.key.EncryptPath(path, pathCipher)
+	if err != nil {
+		return err
+	}
+
+	return s.segments.Delete(ctx, storj.JoinPaths("p", encPath))
+}
+
+// pending returns whether the stream is marked as pending by p/<path>
+func (s *streamStore) pending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (bool, error) {
+	encPath, err := s.key.EncryptPath(path, pathCipher)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = s.segments.Meta(ctx, storj.JoinPaths("p", encPath))
+	if storage.ErrKeyNotFound.Has(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListPending lists the streams that are being uploaded, stripping off the p/ prefix
+func (s *streamStore) ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.list(ctx, "p", prefix, startAfter, endBefore, pathCipher, recursive, limit, metaFlags)
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"storj.io/storj/pkg/ranger"
+)
+
+// uploadPipeline runs the uploads of segments in the background, with at
+// most limit segments being read or uploaded at once. The first failed
+// upload cancels the others.
+type uploadPipeline struct {
+	ctx    context.Context
+	cancel func()
+	slots  chan struct{}
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+func newUploadPipeline(ctx context.Context, limit int) *uploadPipeline {
+	ctx, cancel := context.WithCancel(ctx)
+	return &uploadPipeline{
+		ctx:    ctx,
+		cancel: cancel,
+		slots:  make(chan struct{}, limit),
+	}
+}
+
+// acquire waits until a slot is free for reading the next segment. It returns
+// the error of a failed upload, if any.
+func (p *uploadPipeline) acquire() error {
+	select {
+	case p.slots <- struct{}{}:
+		return p.error()
+	case <-p.ctx.Done():
+		if err := p.error(); err != nil {
+			return err
+		}
+		return p.ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire, when the segment is not uploaded
+// in the background
+func (p *uploadPipeline) release() {
+	<-p.slots
+}
+
+// start runs upload in the background in the slot taken by acquire
+func (p *uploadPipeline) start(upload func(ctx context.Context) error) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.release()
+
+		err := upload(p.ctx)
+		if err != nil {
+			p.mu.Lock()
+			if p.err == nil {
+				p.err = err
+			}
+			p.mu.Unlock()
+			p.cancel()
+		}
+	}()
+}
+
+// wait waits for the started uploads and returns the first error
+func (p *uploadPipeline) wait() error {
+	p.wg.Wait()
+	return p.error()
+}
+
+// close cancels the uploads still in progress and waits for them
+func (p *uploadPipeline) close() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *uploadPipeline) error() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// concatRangers concatenates the rangers of the segments of a stream. With a
+// concurrency larger than 1, the readers of the segments following the one
+// being read are opened ahead, so the segments are downloaded concurrently.
+func concatRangers(rangers []ranger.Ranger, concurrency int) ranger.Ranger {
+	if concurrency <= 1 {
+		return ranger.Concat(rangers...)
+	}
+	return &prefetchRanger{rangers: rangers, concurrency: concurrency}
+}
+
+type prefetchRanger struct {
+	rangers     []ranger.Ranger
+	concurrency int
+}
+
+// Size implements Ranger.Size
+func (rr *prefetchRanger) Size() (size int64) {
+	for _, segment := range rr.rangers {
+		size += segment.Size()
+	}
+	return size
+}
+
+// Range implements Ranger.Range
+func (rr *prefetchRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, ranger.Error.New("negative offset")
+	}
+	if length < 0 {
+		return nil, ranger.Error.New("negative length")
+	}
+	if offset+length > rr.Size() {
+		return nil, ranger.Error.New("range beyond end")
+	}
+	if length == 0 {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	var parts []func(context.Context) (io.ReadCloser, error)
+	for _, segment := range rr.rangers {
+		size := segment.Size()
+		if offset >= size {
+			offset -= size
+			continue
+		}
+
+		segment, partOffset, partLength := segment, offset, size-offset
+		if partLength > length {
+			partLength = length
+		}
+		parts = append(parts, func(ctx context.Context) (io.ReadCloser, error) {
+			return segment.Range(ctx, partOffset, partLength)
+		})
+
+		offset = 0
+		length -= partLength
+		if length == 0 {
+			break
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &prefetchReader{
+		ctx:         ctx,
+		cancel:      cancel,
+		parts:       parts,
+		concurrency: rr.concurrency,
+	}, nil
+}
+
+type openResult struct {
+	reader io.ReadCloser
+	err    error
+}
+
+// prefetchReader reads the parts one after another, while up to concurrency
+// parts, including the one being read, are opened in the background
+type prefetchReader struct {
+	ctx         context.Context
+	cancel      func()
+	parts       []func(context.Context) (io.ReadCloser, error)
+	concurrency int
+
+	opening []chan openResult
+	current io.ReadCloser
+	err     error
+}
+
+// prefetch starts opening the next parts in the background
+func (r *prefetchReader) prefetch() {
+	for len(r.opening) < r.concurrency && len(r.parts) > 0 {
+		open := r.parts[0]
+		r.parts = r.parts[1:]
+
+		result := make(chan openResult, 1)
+		go func() {
+			reader, err := open(r.ctx)
+			result <- openResult{reader: reader, err: err}
+		}()
+		r.opening = append(r.opening, result)
+	}
+}
+
+// Read implements io.Reader
+func (r *prefetchReader) Read(p []byte) (n int, err error) {
+	for r.err == nil {
+		if r.current == nil {
+			r.prefetch()
+			if len(r.opening) == 0 {
+				r.err = io.EOF
+				break
+			}
+
+			result := <-r.opening[0]
+			r.opening = r.opening[1:]
+			if result.err != nil {
+				r.err = result.err
+				break
+			}
+			r.current = result.reader
+		}
+
+		n, err = r.current.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+
+		err = r.current.Close()
+		r.current = nil
+		if err != nil {
+			r.err = err
+			break
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+	return 0, r.err
+}
+
+// Close implements io.Closer
+func (r *prefetchReader) Close() (err error) {
+	r.cancel()
+
+	// the parts opened ahead were not read, so their errors don't matter
+	for _, result := range r.opening {
+		opened := <-result
+		if opened.err == nil {
+			_ = opened.reader.Close()
+		}
+	}
+	r.opening = nil
+
+	if r.current != nil {
+		err = r.current.Close()
+		r.current = nil
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/storj"
+)
+
+// ErrPathNotShared is the error class for paths outside the prefix of a
+// shared key
+var ErrPathNotShared = errs.Class("path not shared")
+
+// PrefixKey is the key of the paths under a prefix, from which the keys of
+// the paths and the contents under it are derived. The prefix starts with
+// the bucket. The root key is the key of the empty prefix, giving access to
+// all the paths.
+type PrefixKey struct {
+	// Prefix is the unencrypted prefix
+	Prefix storj.Path
+	// EncryptedPrefix is the prefix encrypted after the bucket
+	EncryptedPrefix storj.Path
+	// Key is the key derived for the prefix
+	Key *storj.Key
+}
+
+// RootKey returns the key of the empty prefix
+func RootKey(key *storj.Key) *PrefixKey {
+	return &PrefixKey{Key: key}
+}
+
+// Share returns the key of the paths under prefix, which must be within the
+// prefix of k, for handing access to just these paths to another user.
+// pathCipher is the path cipher of the bucket of prefix.
+func (k *PrefixKey) Share(prefix storj.Path, pathCipher storj.Cipher) (*PrefixKey, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return nil, storj.ErrNoBucket.New("")
+	}
+
+	encPrefix, err := k.EncryptPath(prefix, pathCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := k.DerivePathKey(prefix, len(storj.SplitPath(prefix)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrefixKey{Prefix: prefix, EncryptedPrefix: encPrefix, Key: key}, nil
+}
+
+// EncryptPath encrypts path after its bucket like EncryptAfterBucket
+func (k *PrefixKey) EncryptPath(path storj.Path, pathCipher storj.Cipher) (storj.Path, error) {
+	if k.Prefix == "" {
+		return EncryptAfterBucket(path, pathCipher, k.Key)
+	}
+
+	rel, err := k.relative(path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "" {
+		return k.EncryptedPrefix, nil
+	}
+
+	encrypted, err := encryption.EncryptPath(rel, pathCipher, k.Key)
+	if err != nil {
+		return "", err
+	}
+	return storj.JoinPaths(k.EncryptedPrefix, encrypted), nil
+}
+
+// DerivePathKey derives the key of path for the given depth like
+// encryption.DerivePathKey. The depth must not be less than the depth of
+// the prefix.
+func (k *PrefixKey) DerivePathKey(path storj.Path, depth int) (*storj.Key, error) {
+	if k.Prefix == "" {
+		return encryption.DerivePathKey(path, k.Key, depth)
+	}
+
+	rel, err := k.relative(path)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixDepth := len(storj.SplitPath(k.Prefix))
+	if depth < prefixDepth {
+		return nil, ErrPathNotShared.New("%s at depth %d", path, depth)
+	}
+	return encryption.DerivePathKey(rel, k.Key, depth-prefixDepth)
+}
+
+// DeriveContentKey derives the key for the encrypted object data of path
+// like encryption.DeriveContentKey
+func (k *PrefixKey) DeriveContentKey(path storj.Path) (*storj.Key, error) {
+	if k.Prefix == "" {
+		return encryption.DeriveContentKey(path, k.Key)
+	}
+
+	pathKey, err := k.DerivePathKey(path, len(storj.SplitPath(path)))
+	if err != nil {
+		return nil, err
+	}
+	return encryption.DeriveKey(pathKey, "content")
+}
+
+// relative returns path relative to the prefix, failing for paths outside
+// of it
+func (k *PrefixKey) relative(path storj.Path) (storj.Path, error) {
+	if path == k.Prefix {
+		return "", nil
+	}
+	if !strings.HasPrefix(path, k.Prefix+"/") {
+		return "", ErrPathNotShared.New("%s", path)
+	}
+	return path[len(k.Prefix)+1:], nil
+}
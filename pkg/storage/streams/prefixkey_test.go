@@ -0,0 +1,95 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestPrefixKeyShare(t *testing.T) {
+	key := new(storj.Key)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	root := RootKey(key)
+
+	shared, err := root.Share("bucket/photos/", storj.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "bucket/photos", shared.Prefix)
+
+	// the key shared for a prefix within the shared prefix
+	reshared, err := shared.Share("bucket/photos/2019", storj.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, path := range []storj.Path{
+		"bucket/photos",
+		"bucket/photos/2019",
+		"bucket/photos/2019/cat.jpg",
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		encPath, err := root.EncryptPath(path, storj.AESGCM)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		contentKey, err := root.DeriveContentKey(path)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		depth := len(storj.SplitPath(path))
+		pathKey, err := root.DerivePathKey(path, depth)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+
+		// the shared key encrypts the paths and derives the keys under its
+		// prefix like the root key
+		sharedEncPath, err := shared.EncryptPath(path, storj.AESGCM)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, encPath, sharedEncPath, errTag)
+		sharedContentKey, err := shared.DeriveContentKey(path)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, contentKey, sharedContentKey, errTag)
+		sharedPathKey, err := shared.DerivePathKey(path, depth)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, pathKey, sharedPathKey, errTag)
+
+		if path != "bucket/photos" {
+			resharedEncPath, err := reshared.EncryptPath(path, storj.AESGCM)
+			assert.NoError(t, err, errTag)
+			assert.Equal(t, encPath, resharedEncPath, errTag)
+		}
+	}
+
+	for i, path := range []storj.Path{
+		"",
+		"bucket",
+		"bucket/photo",
+		"bucket/photosx/cat.jpg",
+		"bucket/docs/cv.pdf",
+		"other/photos/cat.jpg",
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		_, err := shared.EncryptPath(path, storj.AESGCM)
+		assert.True(t, ErrPathNotShared.Has(err), errTag)
+		_, err = shared.DeriveContentKey(path)
+		assert.True(t, ErrPathNotShared.Has(err), errTag)
+	}
+
+	_, err = shared.DerivePathKey("bucket/photos/2019", 1)
+	assert.True(t, ErrPathNotShared.Has(err))
+
+	_, err = shared.Share("bucket/docs", storj.AESGCM)
+	assert.True(t, ErrPathNotShared.Has(err))
+}
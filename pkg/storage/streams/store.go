@@ -6,7 +6,9 @@ package streams
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"encoding"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -18,6 +20,7 @@ import (
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/compression"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/pb"
@@ -32,10 +35,15 @@ var mon = monkit.Package()
 
 // Meta info about a segment
 type Meta struct {
-	Modified   time.Time
-	Expiration time.Time
-	Size       int64
-	Data       []byte
+	Modified     time.Time
+	Expiration   time.Time
+	Size         int64
+	SegmentCount int64
+	Checksum     []byte
+	// ChecksumParts is the number of parts of a concatenated stream,
+	// whose checksum is computed from their checksums
+	ChecksumParts int64
+	Data          []byte
 }
 
 // convertMeta converts segment metadata to stream metadata
@@ -47,56 +55,103 @@ func convertMeta(lastSegmentMeta segments.Meta) (Meta, error) {
 	}
 
 	return Meta{
-		Modified:   lastSegmentMeta.Modified,
-		Expiration: lastSegmentMeta.Expiration,
-		Size:       ((stream.NumberOfSegments - 1) * stream.SegmentsSize) + stream.LastSegmentSize,
-		Data:       stream.Metadata,
+		Modified:      lastSegmentMeta.Modified,
+		Expiration:    lastSegmentMeta.Expiration,
+		Size:          StreamSize(&stream),
+		SegmentCount:  stream.NumberOfSegments,
+		Checksum:      stream.Checksum,
+		ChecksumParts: stream.ChecksumParts,
+		Data:          stream.Metadata,
 	}, nil
 }
 
+// StreamSize returns the size of the content of the stream with info
+func StreamSize(info *pb.StreamInfo) int64 {
+	if len(info.SegmentSizes) == 0 {
+		return (info.NumberOfSegments-1)*info.SegmentsSize + info.LastSegmentSize
+	}
+	size := info.LastSegmentSize
+	for _, segmentSize := range info.SegmentSizes {
+		size += segmentSize
+	}
+	return size
+}
+
 // Store interface methods for streams to satisfy to be a store
 type Store interface {
 	Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
 	Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (ranger.Ranger, Meta, error)
 	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	Continue(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	Committed(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (segments int64, size int64, err error)
 	Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+
+	PutPending(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte, expiration time.Time, redundancy *pb.RedundancyScheme, es storj.EncryptionScheme) (Meta, error)
+	DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
 // streamStore is a store for streams
 type streamStore struct {
 	segments     segments.Store
 	segmentSize  int64
-	rootKey      *storj.Key
+	key          *PrefixKey
 	encBlockSize int
 	cipher       storj.Cipher
+	concurrency  int
+	memoryLimit  int
+	compression  storj.CompressionScheme
 }
 
-// NewStreamStore stuff
-func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
+// NewStreamStore stuff. The segments read ahead into memory by concurrent
+// uploads don't take more than memoryLimit bytes.
+func NewStreamStore(segments segments.Store, segmentSize int64, key *PrefixKey, encBlockSize int, cipher storj.Cipher, concurrency int, memoryLimit int, compression storj.CompressionScheme) (Store, error) {
 	if segmentSize <= 0 {
 		return nil, errs.New("segment size must be larger than 0")
 	}
-	if rootKey == nil {
+	if key == nil || key.Key == nil {
 		return nil, errs.New("encryption key must not be empty")
 	}
 	if encBlockSize <= 0 {
 		return nil, errs.New("encryption block size must be larger than 0")
 	}
+	if concurrency <= 0 {
+		return nil, errs.New("segment concurrency must be larger than 0")
+	}
+	if compression.Algorithm != storj.NoCompression && compression.BlockSize <= 0 {
+		return nil, errs.New("compression block size must be larger than 0")
+	}
 
 	return &streamStore{
 		segments:     segments,
 		segmentSize:  segmentSize,
-		rootKey:      rootKey,
+		key:          key,
 		encBlockSize: encBlockSize,
 		cipher:       cipher,
+		concurrency:  concurrency,
+		memoryLimit:  memoryLimit,
+		compression:  compression,
 	}, nil
 }
 
+// readAhead returns the number of segments an upload reads ahead into
+// memory, which is bounded by both the concurrency and the memory limit
+func (s *streamStore) readAhead() int {
+	slots := int64(s.memoryLimit) / s.segmentSize
+	if slots > int64(s.concurrency) {
+		return s.concurrency
+	}
+	return int(slots)
+}
+
 // Put breaks up data as it comes in into s.segmentSize length pieces, then
 // store the first piece at s0/<path>, second piece at s1/<path>, and the
 // *last* piece at l/<path>. Store the given metadata, along with the number
-// of segments, in a new protobuf, in the metadata of l/<path>.
+// of segments, in a new protobuf, in the metadata of l/<path>. If the upload
+// fails, the stored segments are deleted, unless the stream is marked as
+// pending by p/<path>: they are kept then, so that the upload can be
+// continued, until the pending stream is deleted.
 func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// previously file uploaded?
@@ -107,36 +162,150 @@ func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj
 		return Meta{}, err
 	}
 
-	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration)
+	m, lastSegment, err := s.upload(ctx, path, pathCipher, nil, data, metadata, expiration)
+	if err != nil || ctx.Err() != nil {
+		pending, pendingErr := s.pending(context.Background(), path, pathCipher)
+		switch {
+		case pendingErr != nil:
+			zap.S().Warnf("Failed checking whether the upload is pending %v", pendingErr)
+		case !pending:
+			s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
+		}
+	}
+
+	return m, err
+}
+
+// Continue uploads data as the remainder of a stream, whose first segments
+// were already stored by a previous interrupted Put or Continue. It keeps
+// the stored segments if the upload fails again, so that it can be
+// continued later.
+func (s *streamStore) Continue(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	committed, err := s.committed(ctx, path, pathCipher)
 	if err != nil {
-		s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
+		return Meta{}, err
 	}
 
+	m, _, err = s.upload(ctx, path, pathCipher, committed, data, metadata, expiration)
 	return m, err
 }
 
-func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+// Committed returns the number of consecutive segments s0/<path>, s1/<path>,
+// ... already stored for a stream that has no l/<path> yet, and the size of
+// the data they hold.
+func (s *streamStore) Committed(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (count int64, size int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var currentSegment int64
-	var streamSize int64
-	var putMeta segments.Meta
+	committed, err := s.committed(ctx, path, pathCipher)
+	if err != nil {
+		return 0, 0, err
+	}
 
-	defer func() {
-		select {
-		case <-ctx.Done():
-			s.cancelHandler(context.Background(), currentSegment, path, pathCipher)
-		default:
+	for _, meta := range committed {
+		size += s.contentSize(meta)
+	}
+	return int64(len(committed)), size, nil
+}
+
+// committed returns the segment metas of the consecutive segments already
+// stored for a stream that has no l/<path> yet
+func (s *streamStore) committed(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (metas []*pb.SegmentMeta, err error) {
+	encPath, err := s.key.EncryptPath(path, pathCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		segmentMeta, err := s.segments.Meta(ctx, getSegmentPath(encPath, int64(len(metas))))
+		if storage.ErrKeyNotFound.Has(err) {
+			return metas, nil
 		}
-	}()
+		if err != nil {
+			return nil, err
+		}
+
+		meta := &pb.SegmentMeta{}
+		err = proto.Unmarshal(segmentMeta.Data, meta)
+		if err != nil {
+			return nil, err
+		}
+		metas = append(metas, meta)
+	}
+}
 
-	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+// contentSize returns the size of the content of a committed segment.
+// Segments stored before their size was recorded have the fixed size.
+func (s *streamStore) contentSize(meta *pb.SegmentMeta) int64 {
+	if meta.ContentSize > 0 {
+		return meta.ContentSize
+	}
+	return s.segmentSize
+}
+
+// upload stores data as the segments of the stream, after the committed
+// segments with the given metas stored before. The checksums of the content
+// are continued from the state stored with the committed segments, and are
+// not stored when the segments were committed without it.
+func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, committed []*pb.SegmentMeta, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	firstSegment := int64(len(committed))
+	currentSegment := firstSegment
+	var segmentSizes []int64
+	streamSize := int64(0)
+	for _, meta := range committed {
+		segmentSizes = append(segmentSizes, s.contentSize(meta))
+		streamSize += s.contentSize(meta)
+	}
+	var putMeta segments.Meta
+	var segmentChecksums [][]byte
+
+	derivedKey, err := s.key.DeriveContentKey(path)
 	if err != nil {
 		return Meta{}, currentSegment, err
 	}
 
+	streamHash := md5.New()
+	knownChecksums := true
+	for _, meta := range committed {
+		state, err := decryptChecksumState(meta, s.cipher, derivedKey)
+		if err != nil {
+			return Meta{}, currentSegment, err
+		}
+		if state == nil {
+			knownChecksums = false
+			break
+		}
+		segmentChecksums = append(segmentChecksums, state.SegmentChecksum)
+		if len(segmentChecksums) == len(committed) {
+			err = streamHash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state.StreamHash)
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+		}
+	}
+
 	eofReader := NewEOFReader(data)
 
+	// when more than 1 segment fits in the read ahead, the segments are read
+	// into memory and all but the last one are uploaded in the background
+	var pipeline *uploadPipeline
+	if readAhead := s.readAhead(); readAhead > 1 {
+		pipeline = newUploadPipeline(ctx, readAhead)
+		defer pipeline.close()
+	}
+
+	// the slot acquired for a segment is released on any failure before the
+	// segment is handed over to its upload
+	acquired := false
+	defer func() {
+		if acquired {
+			pipeline.release()
+		}
+	}()
+
 	for !eofReader.isEOF() && !eofReader.hasError() {
 		// generate random key for encrypting the segment's content
 		var contentKey storj.Key
@@ -172,7 +341,53 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		}
 
 		sizeReader := NewSizeReader(eofReader)
-		segmentReader := io.LimitReader(sizeReader, s.segmentSize)
+		segmentHash := md5.New()
+		segmentReader := io.TeeReader(io.LimitReader(sizeReader, s.segmentSize), io.MultiWriter(segmentHash, streamHash))
+
+		// the state of the checksums is taken once the content of the
+		// segment is read, before the content of the next segments
+		var checksumState []byte
+		getChecksumState := func() (_ []byte, err error) {
+			if checksumState == nil {
+				checksumState, err = encryptChecksumState(segmentHash, streamHash, s.cipher, &contentKey)
+			}
+			return checksumState, err
+		}
+
+		index := currentSegment
+		isLast := eofReader.isEOF
+		if pipeline != nil {
+			err = pipeline.acquire()
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+			acquired = true
+
+			buffered, err := ioutil.ReadAll(segmentReader)
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+			segmentReader = bytes.NewReader(buffered)
+
+			// the reads ahead change the state of eofReader
+			last := eofReader.isEOF()
+			isLast = func() bool { return last }
+
+			_, err = getChecksumState()
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+		}
+
+		// the content is compressed before its encryption, as the
+		// encrypted content is not compressible
+		if s.compression.Algorithm != storj.NoCompression {
+			segmentReader, err = compression.CompressReader(segmentReader, s.compression.Algorithm, int(s.compression.BlockSize))
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+		}
+
 		peekReader := segments.NewPeekThresholdReader(segmentReader)
 		largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
 		if err != nil {
@@ -194,23 +409,30 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 			transformedReader = bytes.NewReader(cipherData)
 		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
-			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+		segmentInfo := func() (storj.Path, []byte, error) {
+			encPath, err := s.key.EncryptPath(path, pathCipher)
 			if err != nil {
 				return "", nil, err
 			}
 
-			if !eofReader.isEOF() {
-				segmentPath := getSegmentPath(encPath, currentSegment)
+			if !isLast() {
+				segmentPath := getSegmentPath(encPath, index)
 
-				if s.cipher == storj.Unencrypted {
-					return segmentPath, nil, nil
+				checksumState, err := getChecksumState()
+				if err != nil {
+					return "", nil, err
 				}
 
-				segmentMeta, err := proto.Marshal(&pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
-				})
+				meta := pb.SegmentMeta{
+					ContentSize:            sizeReader.Size(),
+					EncryptedChecksumState: checksumState,
+				}
+				if s.cipher != storj.Unencrypted {
+					meta.EncryptedKey = encryptedKey
+					meta.KeyNonce = keyNonce[:]
+				}
+
+				segmentMeta, err := proto.Marshal(&meta)
 				if err != nil {
 					return "", nil, err
 				}
@@ -220,12 +442,25 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 
 			lastSegmentPath := storj.JoinPaths("l", encPath)
 
-			streamInfo, err := proto.Marshal(&pb.StreamInfo{
-				NumberOfSegments: currentSegment + 1,
+			info := pb.StreamInfo{
+				NumberOfSegments: index + 1,
 				SegmentsSize:     s.segmentSize,
 				LastSegmentSize:  sizeReader.Size(),
 				Metadata:         metadata,
-			})
+				CompressionType:  int32(s.compression.Algorithm),
+			}
+			for _, size := range segmentSizes {
+				if size != s.segmentSize {
+					info.SegmentSizes = segmentSizes
+					break
+				}
+			}
+			if knownChecksums {
+				info.SegmentChecksums = append(segmentChecksums, segmentHash.Sum(nil))
+				info.Checksum = streamHash.Sum(nil)
+			}
+
+			streamInfo, err := proto.Marshal(&info)
 			if err != nil {
 				return "", nil, err
 			}
@@ -255,13 +490,35 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 			}
 
 			return lastSegmentPath, lastSegmentMeta, nil
-		})
+		}
+
+		switch {
+		case pipeline == nil:
+			putMeta, err = s.segments.Put(ctx, transformedReader, expiration, segmentInfo)
+		case !isLast():
+			acquired = false
+			pipeline.start(func(ctx context.Context) error {
+				_, err := s.segments.Put(ctx, transformedReader, expiration, segmentInfo)
+				return err
+			})
+		default:
+			// the last segment commits the stream, so it is stored only
+			// after all the segments before it
+			acquired = false
+			pipeline.release()
+			err = pipeline.wait()
+			if err == nil {
+				putMeta, err = s.segments.Put(ctx, transformedReader, expiration, segmentInfo)
+			}
+		}
 		if err != nil {
 			return Meta{}, currentSegment, err
 		}
 
 		currentSegment++
 		streamSize += sizeReader.Size()
+		segmentSizes = append(segmentSizes, sizeReader.Size())
+		segmentChecksums = append(segmentChecksums, segmentHash.Sum(nil))
 	}
 
 	if eofReader.hasError() {
@@ -269,10 +526,14 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 	}
 
 	resultMeta := Meta{
-		Modified:   putMeta.Modified,
-		Expiration: expiration,
-		Size:       streamSize,
-		Data:       metadata,
+		Modified:     putMeta.Modified,
+		Expiration:   expiration,
+		Size:         streamSize,
+		SegmentCount: currentSegment,
+		Data:         metadata,
+	}
+	if knownChecksums {
+		resultMeta.Checksum = streamHash.Sum(nil)
 	}
 
 	return resultMeta, currentSegment, nil
@@ -289,7 +550,7 @@ func getSegmentPath(path storj.Path, segNum int64) storj.Path {
 func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (rr ranger.Ranger, meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.key.EncryptPath(path, pathCipher)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -299,7 +560,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.key)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -316,34 +577,38 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	derivedKey, err := s.key.DeriveContentKey(path)
 	if err != nil {
 		return nil, Meta{}, err
 	}
 
+	// the checksums are verified only if known for all segments
+	verifyChecksums := int64(len(stream.SegmentChecksums)) == stream.NumberOfSegments
+
 	var rangers []ranger.Ranger
 	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
 		currentPath := getSegmentPath(encPath, i)
 		size := stream.SegmentsSize
-		var contentNonce storj.Nonce
-		_, err := encryption.Increment(&contentNonce, i+1)
-		if err != nil {
-			return nil, Meta{}, err
+		if i < int64(len(stream.SegmentSizes)) {
+			size = stream.SegmentSizes[i]
+		}
+		var rr ranger.Ranger = &lazySegmentRanger{
+			segments:     s.segments,
+			path:         currentPath,
+			index:        i,
+			size:         size,
+			derivedKey:   derivedKey,
+			encBlockSize: int(streamMeta.EncryptionBlockSize),
+			cipher:       storj.Cipher(streamMeta.EncryptionType),
+			compression:  storj.CompressionAlgorithm(stream.CompressionType),
 		}
-		rr := &lazySegmentRanger{
-			segments:      s.segments,
-			path:          currentPath,
-			size:          size,
-			derivedKey:    derivedKey,
-			startingNonce: &contentNonce,
-			encBlockSize:  int(streamMeta.EncryptionBlockSize),
-			cipher:        storj.Cipher(streamMeta.EncryptionType),
+		if verifyChecksums {
+			rr = &checksumRanger{Ranger: rr, checksum: stream.SegmentChecksums[i]}
 		}
 		rangers = append(rangers, rr)
 	}
 
-	var contentNonce storj.Nonce
-	_, err = encryption.Increment(&contentNonce, stream.NumberOfSegments)
+	contentNonce, err := ContentNonce(streamMeta.LastSegmentMeta, stream.NumberOfSegments-1)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -356,15 +621,22 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		derivedKey,
 		encryptedKey,
 		keyNonce,
-		&contentNonce,
+		contentNonce,
 		int(streamMeta.EncryptionBlockSize),
+		storj.CompressionAlgorithm(stream.CompressionType),
 	)
 	if err != nil {
 		return nil, Meta{}, err
 	}
+	if verifyChecksums {
+		decryptedLastSegmentRanger = &checksumRanger{
+			Ranger:   decryptedLastSegmentRanger,
+			checksum: stream.SegmentChecksums[stream.NumberOfSegments-1],
+		}
+	}
 	rangers = append(rangers, decryptedLastSegmentRanger)
 
-	catRangers := ranger.Concat(rangers...)
+	catRangers := concatRangers(rangers, s.concurrency)
 
 	lastSegmentMeta.Data = streamInfo
 	meta, err = convertMeta(lastSegmentMeta)
@@ -379,7 +651,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.key.EncryptPath(path, pathCipher)
 	if err != nil {
 		return Meta{}, err
 	}
@@ -389,7 +661,7 @@ func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher stor
 		return Meta{}, err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.key)
 	if err != nil {
 		return Meta{}, err
 	}
@@ -407,7 +679,7 @@ func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher stor
 func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.key.EncryptPath(path, pathCipher)
 	if err != nil {
 		return err
 	}
@@ -416,7 +688,7 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 		return err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.key)
 	if err != nil {
 		return err
 	}
@@ -428,7 +700,7 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 	}
 
 	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
-		encPath, err = EncryptAfterBucket(path, pathCipher, s.rootKey)
+		encPath, err = s.key.EncryptPath(path, pathCipher)
 		if err != nil {
 			return err
 		}
@@ -453,6 +725,11 @@ type ListItem struct {
 func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return s.list(ctx, "l", prefix, startAfter, endBefore, pathCipher, recursive, limit, metaFlags)
+}
+
+// list all the paths inside <segment>/, stripping off the <segment>/ prefix
+func (s *streamStore) list(ctx context.Context, segment string, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	if metaFlags&meta.Size != 0 {
 		// Calculating the stream's size require also the user-defined metadata,
 		// where stream store keeps info about the number of segments and their size.
@@ -461,39 +738,39 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 
 	prefix = strings.TrimSuffix(prefix, "/")
 
-	encPrefix, err := EncryptAfterBucket(prefix, pathCipher, s.rootKey)
+	encPrefix, err := s.key.EncryptPath(prefix, pathCipher)
 	if err != nil {
 		return nil, false, err
 	}
 
-	prefixKey, err := encryption.DerivePathKey(prefix, s.rootKey, len(storj.SplitPath(prefix)))
+	prefixKey, err := s.key.DerivePathKey(prefix, len(storj.SplitPath(prefix)))
 	if err != nil {
 		return nil, false, err
 	}
 
-	encStartAfter, err := s.encryptMarker(startAfter, pathCipher, prefixKey)
+	encStartAfter, err := s.encryptMarker(startAfter, pathCipher, prefix, prefixKey)
 	if err != nil {
 		return nil, false, err
 	}
 
-	encEndBefore, err := s.encryptMarker(endBefore, pathCipher, prefixKey)
+	encEndBefore, err := s.encryptMarker(endBefore, pathCipher, prefix, prefixKey)
 	if err != nil {
 		return nil, false, err
 	}
 
-	segments, more, err := s.segments.List(ctx, storj.JoinPaths("l", encPrefix), encStartAfter, encEndBefore, recursive, limit, metaFlags)
+	segments, more, err := s.segments.List(ctx, storj.JoinPaths(segment, encPrefix), encStartAfter, encEndBefore, recursive, limit, metaFlags)
 	if err != nil {
 		return nil, false, err
 	}
 
 	items = make([]ListItem, len(segments))
 	for i, item := range segments {
-		path, err := s.decryptMarker(item.Path, pathCipher, prefixKey)
+		path, err := s.decryptMarker(item.Path, pathCipher, prefix, prefixKey)
 		if err != nil {
 			return nil, false, err
 		}
 
-		streamInfo, err := DecryptStreamInfo(ctx, item.Meta, storj.JoinPaths(prefix, path), s.rootKey)
+		streamInfo, err := DecryptStreamInfo(ctx, item.Meta, storj.JoinPaths(prefix, path), s.key)
 		if err != nil {
 			return nil, false, err
 		}
@@ -511,30 +788,31 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 }
 
 // encryptMarker is a helper method for encrypting startAfter and endBefore markers
-func (s *streamStore) encryptMarker(marker storj.Path, pathCipher storj.Cipher, prefixKey *storj.Key) (storj.Path, error) {
-	if bytes.Equal(s.rootKey[:], prefixKey[:]) { // empty prefix
-		return EncryptAfterBucket(marker, pathCipher, s.rootKey)
+func (s *streamStore) encryptMarker(marker storj.Path, pathCipher storj.Cipher, prefix storj.Path, prefixKey *storj.Key) (storj.Path, error) {
+	if prefix == "" {
+		return s.key.EncryptPath(marker, pathCipher)
 	}
 	return encryption.EncryptPath(marker, pathCipher, prefixKey)
 }
 
 // decryptMarker is a helper method for decrypting listed path markers
-func (s *streamStore) decryptMarker(marker storj.Path, pathCipher storj.Cipher, prefixKey *storj.Key) (storj.Path, error) {
-	if bytes.Equal(s.rootKey[:], prefixKey[:]) { // empty prefix
-		return DecryptAfterBucket(marker, pathCipher, s.rootKey)
+func (s *streamStore) decryptMarker(marker storj.Path, pathCipher storj.Cipher, prefix storj.Path, prefixKey *storj.Key) (storj.Path, error) {
+	if prefix == "" {
+		return DecryptAfterBucket(marker, pathCipher, s.key.Key)
 	}
 	return encryption.DecryptPath(marker, pathCipher, prefixKey)
 }
 
 type lazySegmentRanger struct {
-	ranger        ranger.Ranger
-	segments      segments.Store
-	path          storj.Path
-	size          int64
-	derivedKey    *storj.Key
-	startingNonce *storj.Nonce
-	encBlockSize  int
-	cipher        storj.Cipher
+	ranger       ranger.Ranger
+	segments     segments.Store
+	path         storj.Path
+	index        int64
+	size         int64
+	derivedKey   *storj.Key
+	encBlockSize int
+	cipher       storj.Cipher
+	compression  storj.CompressionAlgorithm
 }
 
 // Size implements Ranger.Size
@@ -555,7 +833,11 @@ func (lr *lazySegmentRanger) Range(ctx context.Context, offset, length int64) (i
 			return nil, err
 		}
 		encryptedKey, keyNonce := getEncryptedKeyAndNonce(&segmentMeta)
-		lr.ranger, err = decryptRanger(ctx, rr, lr.size, lr.cipher, lr.derivedKey, encryptedKey, keyNonce, lr.startingNonce, lr.encBlockSize)
+		contentNonce, err := ContentNonce(&segmentMeta, lr.index)
+		if err != nil {
+			return nil, err
+		}
+		lr.ranger, err = decryptRanger(ctx, rr, lr.size, lr.cipher, lr.derivedKey, encryptedKey, keyNonce, contentNonce, lr.encBlockSize, lr.compression)
 		if err != nil {
 			return nil, err
 		}
@@ -563,8 +845,10 @@ func (lr *lazySegmentRanger) Range(ctx context.Context, offset, length int64) (i
 	return lr.ranger.Range(ctx, offset, length)
 }
 
-// decryptRanger returns a decrypted ranger of the given rr ranger
-func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, cipher storj.Cipher, derivedKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, encryptedKeyNonce, startingNonce *storj.Nonce, encBlockSize int) (ranger.Ranger, error) {
+// decryptRanger returns a decrypted ranger of the given rr ranger. The
+// content compressed before its encryption is decompressed too, and then
+// decryptedSize is the size of the decompressed content.
+func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, cipher storj.Cipher, derivedKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, encryptedKeyNonce, startingNonce *storj.Nonce, encBlockSize int, compressionAlgorithm storj.CompressionAlgorithm) (ranger.Ranger, error) {
 	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, encryptedKeyNonce)
 	if err != nil {
 		return nil, err
@@ -589,14 +873,33 @@ func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, c
 		if err != nil {
 			return nil, err
 		}
-		return ranger.ByteRanger(data), nil
+		return decompressRanger(ctx, ranger.ByteRanger(data), compressionAlgorithm)
 	}
 
 	rd, err = encryption.Transform(rr, decrypter)
 	if err != nil {
 		return nil, err
 	}
-	return eestream.Unpad(rd, int(rd.Size()-decryptedSize))
+	if compressionAlgorithm == storj.NoCompression {
+		return eestream.Unpad(rd, int(rd.Size()-decryptedSize))
+	}
+
+	// the size of the compressed content is not known, so the padding is
+	// read from its end
+	rd, err = eestream.UnpadSlow(ctx, rd)
+	if err != nil {
+		return nil, err
+	}
+	return decompressRanger(ctx, rd, compressionAlgorithm)
+}
+
+// decompressRanger returns a decompressed ranger of the given rr ranger,
+// unless its content is not compressed
+func decompressRanger(ctx context.Context, rr ranger.Ranger, algorithm storj.CompressionAlgorithm) (ranger.Ranger, error) {
+	if algorithm == storj.NoCompression {
+		return rr, nil
+	}
+	return compression.Decompress(ctx, rr, algorithm)
 }
 
 // EncryptAfterBucket encrypts a path without encrypting its first element
@@ -641,7 +944,7 @@ func DecryptAfterBucket(path storj.Path, cipher storj.Cipher, key *storj.Key) (d
 // CancelHandler handles clean up of segments on receiving CTRL+C
 func (s *streamStore) cancelHandler(ctx context.Context, totalSegments int64, path storj.Path, pathCipher storj.Cipher) {
 	for i := int64(0); i < totalSegments; i++ {
-		encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+		encPath, err := s.key.EncryptPath(path, pathCipher)
 		if err != nil {
 			zap.S().Warnf("Failed deleting a segment due to encryption path %v %v", i, err)
 		}
@@ -665,15 +968,58 @@ func getEncryptedKeyAndNonce(m *pb.SegmentMeta) (storj.EncryptedPrivateKey, *sto
 	return m.EncryptedKey, &nonce
 }
 
+// ContentNonce returns the nonce the content of the segment with index was
+// encrypted with, the one recorded in its segment meta m if any, or else
+// the index incremented by 1
+func ContentNonce(m *pb.SegmentMeta, index int64) (*storj.Nonce, error) {
+	var nonce storj.Nonce
+	if len(m.GetContentNonce()) > 0 {
+		copy(nonce[:], m.ContentNonce)
+		return &nonce, nil
+	}
+	_, err := encryption.Increment(&nonce, index+1)
+	return &nonce, err
+}
+
 // DecryptStreamInfo decrypts stream info
-func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path, rootKey *storj.Key) (streamInfo []byte, err error) {
+func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path, key *PrefixKey) (streamInfo []byte, err error) {
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(item.Data, &streamMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := key.DeriveContentKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher := storj.Cipher(streamMeta.EncryptionType)
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
+	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// decrypt metadata with the content encryption key and its nonce,
+	// which is the zero nonce unless the metadata was replaced
+	var nonce storj.Nonce
+	copy(nonce[:], streamMeta.StreamInfoNonce)
+
+	return encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &nonce)
+}
+
+// ReplaceStreamInfo encrypts streamInfo with the content encryption key of
+// the last segment and a new random nonce, and returns the marshaled stream
+// meta of the last segment with it.
+func ReplaceStreamInfo(ctx context.Context, item segments.Meta, streamInfo []byte, path storj.Path, key *PrefixKey) (data []byte, err error) {
 	streamMeta := pb.StreamMeta{}
 	err = proto.Unmarshal(item.Data, &streamMeta)
 	if err != nil {
 		return nil, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
+	derivedKey, err := key.DeriveContentKey(path)
 	if err != nil {
 		return nil, err
 	}
@@ -685,6 +1031,18 @@ func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path,
 		return nil, err
 	}
 
-	// decrypt metadata with the content encryption key and zero nonce
-	return encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &storj.Nonce{})
+	// never reuse a nonce with the same content encryption key
+	var nonce storj.Nonce
+	_, err = rand.Read(nonce[:])
+	if err != nil {
+		return nil, err
+	}
+
+	streamMeta.EncryptedStreamInfo, err = encryption.Encrypt(streamInfo, cipher, contentKey, &nonce)
+	if err != nil {
+		return nil, err
+	}
+	streamMeta.StreamInfoNonce = nonce[:]
+
+	return proto.Marshal(&streamMeta)
 }
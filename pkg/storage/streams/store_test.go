@@ -5,20 +5,25 @@ package streams
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -91,7 +96,7 @@ func TestStreamStoreMeta(t *testing.T) {
 			Meta(gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, storj.AESGCM)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, storj.AESGCM, 1, 0, storj.CompressionScheme{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -119,11 +124,15 @@ func TestStreamStorePut(t *testing.T) {
 		Data:       []byte{},
 	}
 
+	checksum := md5.Sum([]byte("data"))
+
 	streamMeta := Meta{
-		Modified:   segmentMeta.Modified,
-		Expiration: segmentMeta.Expiration,
-		Size:       4,
-		Data:       []byte("metadata"),
+		Modified:     segmentMeta.Modified,
+		Expiration:   segmentMeta.Expiration,
+		Size:         4,
+		SegmentCount: 1,
+		Checksum:     checksum[:],
+		Data:         []byte("metadata"),
 	}
 
 	for i, test := range []struct {
@@ -163,7 +172,7 @@ func TestStreamStorePut(t *testing.T) {
 			Delete(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, 0, 1, 0, storj.CompressionScheme{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -177,6 +186,283 @@ func TestStreamStorePut(t *testing.T) {
 	}
 }
 
+func TestStreamStoreContinue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	staticTime := time.Now()
+	segmentMeta := segments.Meta{
+		Modified:   staticTime,
+		Expiration: staticTime,
+		Size:       10,
+		Data:       []byte{},
+	}
+
+	// two segments were stored by the interrupted upload
+	gomock.InOrder(
+		mockSegmentStore.EXPECT().
+			Meta(gomock.Any(), gomock.Any()).
+			Return(segmentMeta, nil).
+			Times(2),
+		mockSegmentStore.EXPECT().
+			Meta(gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, storage.ErrKeyNotFound.New("")),
+	)
+
+	var lastSegmentPath storj.Path
+	var lastSegmentMeta []byte
+	mockSegmentStore.EXPECT().
+		Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(segmentMeta, nil).
+		Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+			_, err := ioutil.ReadAll(data)
+			assert.NoError(t, err)
+
+			lastSegmentPath, lastSegmentMeta, err = info()
+			assert.NoError(t, err)
+		})
+
+	streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, storj.Unencrypted, 1, 0, storj.CompressionScheme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := streamStore.Continue(ctx, "bucket/file", storj.Unencrypted, strings.NewReader("data"), []byte("metadata"), staticTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "l/bucket/file", lastSegmentPath)
+	assert.EqualValues(t, 24, meta.Size)
+
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(lastSegmentMeta, &streamMeta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamMeta.EncryptedStreamInfo, &streamInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, 3, streamInfo.NumberOfSegments)
+	assert.EqualValues(t, 10, streamInfo.SegmentsSize)
+	assert.EqualValues(t, 4, streamInfo.LastSegmentSize)
+	assert.Nil(t, streamInfo.Checksum)
+	assert.Nil(t, streamInfo.SegmentChecksums)
+}
+
+func TestStreamStorePutInterrupted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	type segment struct {
+		data []byte
+		meta []byte
+	}
+	stored := map[storj.Path]segment{}
+
+	mockSegmentStore.EXPECT().
+		Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) (segments.Meta, error) {
+			content, err := ioutil.ReadAll(data)
+			if err != nil {
+				return segments.Meta{}, err
+			}
+			path, meta, err := info()
+			if err != nil {
+				return segments.Meta{}, err
+			}
+			stored[path] = segment{content, meta}
+			return segments.Meta{Data: meta}, nil
+		})
+	mockSegmentStore.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, path storj.Path) (ranger.Ranger, segments.Meta, error) {
+			segment, ok := stored[path]
+			if !ok {
+				return nil, segments.Meta{}, storage.ErrKeyNotFound.New("%s", path)
+			}
+			return ranger.ByteRanger(segment.data), segments.Meta{Data: segment.meta}, nil
+		})
+	mockSegmentStore.EXPECT().
+		Meta(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, path storj.Path) (segments.Meta, error) {
+			segment, ok := stored[path]
+			if !ok {
+				return segments.Meta{}, storage.ErrKeyNotFound.New("%s", path)
+			}
+			return segments.Meta{Data: segment.meta}, nil
+		})
+	mockSegmentStore.EXPECT().
+		Delete(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, path storj.Path) error {
+			if _, ok := stored[path]; !ok {
+				return storage.ErrKeyNotFound.New("%s", path)
+			}
+			delete(stored, path)
+			return nil
+		})
+
+	streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 64, storj.AESGCM, 1, 0, storj.CompressionScheme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "0123456789abcdefghijklmnopqrstuvwxyz"
+	interrupted := func() io.Reader {
+		return io.MultiReader(strings.NewReader(content[:25]), failingReader{errs.New("connection lost")})
+	}
+
+	// the segments of an upload that is not pending are deleted
+	_, err = streamStore.Put(ctx, "bucket/file", storj.AESGCM, interrupted(), nil, time.Time{})
+	assert.Error(t, err)
+
+	count, _, err := streamStore.Committed(ctx, "bucket/file", storj.AESGCM)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 0, count)
+	}
+
+	// the segments of a pending upload are kept until it is continued
+	_, err = streamStore.PutPending(ctx, "bucket/file", storj.AESGCM, nil, time.Time{}, nil, storj.EncryptionScheme{Cipher: storj.AESGCM, BlockSize: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = streamStore.Put(ctx, "bucket/file", storj.AESGCM, interrupted(), nil, time.Time{})
+	assert.Error(t, err)
+
+	count, size, err := streamStore.Committed(ctx, "bucket/file", storj.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, 2, count)
+	assert.EqualValues(t, 20, size)
+
+	meta, err := streamStore.Continue(ctx, "bucket/file", storj.AESGCM, strings.NewReader(content[size:]), nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, len(content), meta.Size)
+
+	checksum := md5.Sum([]byte(content))
+	assert.Equal(t, checksum[:], meta.Checksum)
+
+	rr, _, err := streamStore.Get(ctx, "bucket/file", storj.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := rr.Range(ctx, 0, rr.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, content, string(data))
+}
+
+func TestStreamStoreContinueChecksum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	key := RootKey(new(storj.Key))
+	streamStore, err := NewStreamStore(mockSegmentStore, 10, key, 1024, storj.AESGCM, 1, 0, storj.CompressionScheme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored []segments.Meta
+	var lastSegmentMeta []byte
+	putSegment := func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+		_, err := ioutil.ReadAll(data)
+		assert.NoError(t, err)
+
+		path, meta, err := info()
+		assert.NoError(t, err)
+		if strings.HasPrefix(path, "l/") {
+			lastSegmentMeta = meta
+		} else {
+			stored = append(stored, segments.Meta{Data: meta})
+		}
+	}
+	committedSegment := func(ctx context.Context, path storj.Path) (segments.Meta, error) {
+		var index int
+		_, err := fmt.Sscanf(path, "s%d/", &index)
+		assert.NoError(t, err)
+		if index < len(stored) {
+			return stored[index], nil
+		}
+		return segments.Meta{}, storage.ErrKeyNotFound.New("")
+	}
+
+	mockSegmentStore.EXPECT().
+		Meta(gomock.Any(), gomock.Any()).
+		DoAndReturn(committedSegment).
+		AnyTimes()
+
+	// the upload is interrupted while storing its last segment
+	gomock.InOrder(
+		mockSegmentStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, nil).
+			Do(putSegment).
+			Times(2),
+		mockSegmentStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, errs.New("interrupted")),
+		mockSegmentStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, nil).
+			Do(putSegment),
+	)
+
+	_, err = streamStore.Continue(ctx, "bucket/file", storj.Unencrypted, strings.NewReader("0123456789abcdefghijdata"), nil, time.Time{})
+	assert.Error(t, err)
+
+	meta, err := streamStore.Continue(ctx, "bucket/file", storj.Unencrypted, strings.NewReader("data"), nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the checksums are continued from the state stored with the segments
+	checksum := md5.Sum([]byte("0123456789abcdefghijdata"))
+	assert.Equal(t, checksum[:], meta.Checksum)
+
+	streamInfoData, err := DecryptStreamInfo(ctx, segments.Meta{Data: lastSegmentMeta}, "bucket/file", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfoData, &streamInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, 3, streamInfo.NumberOfSegments)
+	assert.Equal(t, checksum[:], streamInfo.Checksum)
+	assert.EqualValues(t, 0, streamInfo.ChecksumParts)
+	var segmentChecksums [][]byte
+	for _, content := range []string{"0123456789", "abcdefghij", "data"} {
+		checksum := md5.Sum([]byte(content))
+		segmentChecksums = append(segmentChecksums, checksum[:])
+	}
+	assert.Equal(t, segmentChecksums, streamInfo.SegmentChecksums)
+}
+
 type stubRanger struct {
 	len    int64
 	closer io.ReadCloser
@@ -194,6 +480,10 @@ type readCloserStub struct{}
 func (r readCloserStub) Read(p []byte) (n int, err error) { return 10, nil }
 func (r readCloserStub) Close() error                     { return nil }
 
+type failingReader struct{ err error }
+
+func (r failingReader) Read(p []byte) (n int, err error) { return 0, r.err }
+
 func TestStreamStoreGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -233,10 +523,11 @@ func TestStreamStoreGet(t *testing.T) {
 	streamRanger := ranger.ByteRanger(nil)
 
 	streamMeta := Meta{
-		Modified:   staticTime,
-		Expiration: staticTime,
-		Size:       0,
-		Data:       nil,
+		Modified:     staticTime,
+		Expiration:   staticTime,
+		Size:         0,
+		SegmentCount: 1,
+		Data:         nil,
 	}
 
 	for i, test := range []struct {
@@ -263,7 +554,7 @@ func TestStreamStoreGet(t *testing.T) {
 
 		gomock.InOrder(calls...)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, 0, 1, 0, storj.CompressionScheme{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -312,7 +603,7 @@ func TestStreamStoreDelete(t *testing.T) {
 			Delete(gomock.Any(), gomock.Any()).
 			Return(test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, 0, 1, 0, storj.CompressionScheme{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -356,7 +647,7 @@ func TestStreamStoreList(t *testing.T) {
 			List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(test.segments, test.segmentMore, test.segmentError)
 
-		streamStore, err := NewStreamStore(mockSegmentStore, 10, new(storj.Key), 10, 0)
+		streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, 0, 1, 0, storj.CompressionScheme{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -370,3 +661,270 @@ func TestStreamStoreList(t *testing.T) {
 		assert.Equal(t, test.streamMore, more, errTag)
 	}
 }
+
+func TestPartsChecksum(t *testing.T) {
+	first, second := md5.Sum([]byte("first")), md5.Sum([]byte("second"))
+
+	expected := md5.Sum(first[:])
+	assert.Equal(t, expected[:], PartsChecksum([][]byte{first[:]}))
+
+	expected = md5.Sum(append(first[:], second[:]...))
+	assert.Equal(t, expected[:], PartsChecksum([][]byte{first[:], second[:]}))
+}
+
+func TestChecksumRanger(t *testing.T) {
+	checksum := md5.Sum([]byte("data"))
+
+	for i, test := range []struct {
+		data          string
+		offset        int64
+		length        int64
+		checksumError bool
+	}{
+		{"data", 0, 4, false},
+		{"date", 0, 4, true},
+		{"date", 0, 3, false}, // partial reads are not verified
+		{"date", 1, 3, false},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		rr := &checksumRanger{Ranger: ranger.ByteRanger(test.data), checksum: checksum[:]}
+
+		reader, err := rr.Range(ctx, test.offset, test.length)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(reader)
+		if test.checksumError {
+			assert.True(t, ErrChecksum.Has(err), errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+			assert.Equal(t, test.data[test.offset:test.offset+test.length], string(data), errTag)
+		}
+	}
+}
+
+func TestStreamStorePutConcurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	mockSegmentStore.EXPECT().
+		Meta(gomock.Any(), gomock.Any()).
+		Return(segments.Meta{}, storage.ErrKeyNotFound.New(""))
+
+	var mu sync.Mutex
+	var paths []storj.Path
+	mockSegmentStore.EXPECT().
+		Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(segments.Meta{}, nil).
+		Times(3).
+		Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+			_, err := ioutil.ReadAll(data)
+			assert.NoError(t, err)
+
+			path, _, err := info()
+			assert.NoError(t, err)
+
+			mu.Lock()
+			paths = append(paths, path)
+			mu.Unlock()
+		})
+
+	streamStore, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, storj.Unencrypted, 3, 30, storj.CompressionScheme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := streamStore.Put(ctx, "bucket/file", storj.Unencrypted, strings.NewReader(strings.Repeat("a", 25)), nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, 25, meta.Size)
+	assert.EqualValues(t, 3, meta.SegmentCount)
+
+	// the last segment is put only after the others
+	if assert.Len(t, paths, 3) {
+		assert.ElementsMatch(t, []storj.Path{"s0/bucket/file", "s1/bucket/file"}, paths[:2])
+		assert.Equal(t, "l/bucket/file", paths[2])
+	}
+}
+
+func TestStreamStorePutMemoryLimit(t *testing.T) {
+	for i, tt := range []struct {
+		concurrency int
+		memoryLimit int
+		readAhead   int
+	}{
+		{concurrency: 3, memoryLimit: 30, readAhead: 3},
+		{concurrency: 3, memoryLimit: 100, readAhead: 3},
+		{concurrency: 3, memoryLimit: 25, readAhead: 2},
+		{concurrency: 3, memoryLimit: 5, readAhead: 0},
+		{concurrency: 1, memoryLimit: 100, readAhead: 1},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		ctrl := gomock.NewController(t)
+		mockSegmentStore := segments.NewMockStore(ctrl)
+
+		mockSegmentStore.EXPECT().
+			Meta(gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, storage.ErrKeyNotFound.New(""))
+
+		var mu sync.Mutex
+		var running, maxRunning int
+		mockSegmentStore.EXPECT().
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(segments.Meta{}, nil).
+			Times(6).
+			Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+				mu.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+				_, err := ioutil.ReadAll(data)
+				assert.NoError(t, err, errTag)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+			})
+
+		store, err := NewStreamStore(mockSegmentStore, 10, RootKey(new(storj.Key)), 10, storj.Unencrypted, tt.concurrency, tt.memoryLimit, storj.CompressionScheme{})
+		if !assert.NoError(t, err, errTag) {
+			ctrl.Finish()
+			continue
+		}
+		assert.Equal(t, tt.readAhead, store.(*streamStore).readAhead(), errTag)
+
+		_, err = store.Put(ctx, "bucket/file", storj.Unencrypted, strings.NewReader(strings.Repeat("a", 55)), nil, time.Time{})
+		assert.NoError(t, err, errTag)
+
+		// the segments read ahead are the ones being uploaded at once
+		bound := tt.readAhead
+		if bound < 1 {
+			bound = 1
+		}
+		assert.True(t, maxRunning <= bound, "%s: %d segments uploaded at once", errTag, maxRunning)
+
+		ctrl.Finish()
+	}
+}
+
+func TestStreamStoreCompressed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSegmentStore := segments.NewMockStore(ctrl)
+
+	mockSegmentStore.EXPECT().
+		Meta(gomock.Any(), gomock.Any()).
+		Return(segments.Meta{}, storage.ErrKeyNotFound.New(""))
+
+	stored := map[storj.Path]struct {
+		data []byte
+		meta []byte
+	}{}
+	mockSegmentStore.EXPECT().
+		Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(segments.Meta{}, nil).
+		Times(3).
+		Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+			content, err := ioutil.ReadAll(data)
+			assert.NoError(t, err)
+
+			path, meta, err := info()
+			assert.NoError(t, err)
+
+			stored[path] = struct {
+				data []byte
+				meta []byte
+			}{content, meta}
+		})
+	mockSegmentStore.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, path storj.Path) (ranger.Ranger, segments.Meta, error) {
+			segment, ok := stored[path]
+			if !ok {
+				return nil, segments.Meta{}, storage.ErrKeyNotFound.New("%s", path)
+			}
+			return ranger.ByteRanger(segment.data), segments.Meta{Data: segment.meta}, nil
+		})
+
+	compression := storj.CompressionScheme{Algorithm: storj.Gzip, BlockSize: 100}
+	streamStore, err := NewStreamStore(mockSegmentStore, 1000, RootKey(new(storj.Key)), 64, storj.AESGCM, 1, 0, compression)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := strings.Repeat("compressible content ", 120)
+	meta, err := streamStore.Put(ctx, "bucket/file", storj.AESGCM, strings.NewReader(content), nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, len(content), meta.Size)
+
+	rr, _, err := streamStore.Get(ctx, "bucket/file", storj.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, len(content), rr.Size())
+
+	for i, test := range []struct {
+		offset int64
+		length int64
+	}{
+		{0, int64(len(content))}, {0, 10}, {950, 100}, {1234, 567}, {int64(len(content)) - 1, 1},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		reader, err := rr.Range(ctx, test.offset, test.length)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, content[test.offset:test.offset+test.length], string(data), errTag)
+		assert.NoError(t, reader.Close(), errTag)
+	}
+}
+
+func TestPrefetchRanger(t *testing.T) {
+	rr := concatRangers([]ranger.Ranger{
+		ranger.ByteRanger("abc"),
+		ranger.ByteRanger("defg"),
+		ranger.ByteRanger("h"),
+		ranger.ByteRanger("ijk"),
+	}, 2)
+	assert.EqualValues(t, 11, rr.Size())
+
+	for i, test := range []struct {
+		offset int64
+		length int64
+	}{
+		{0, 11}, {0, 0}, {2, 2}, {3, 4}, {4, 5}, {10, 1},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		reader, err := rr.Range(ctx, test.offset, test.length)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err, errTag)
+		assert.Equal(t, "abcdefghijk"[test.offset:test.offset+test.length], string(data), errTag)
+		assert.NoError(t, reader.Close(), errTag)
+	}
+
+	_, err := rr.Range(ctx, 5, 7)
+	assert.Error(t, err)
+}
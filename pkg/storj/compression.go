@@ -0,0 +1,25 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+// CompressionScheme is the algorithm and parameters used for compressing the
+// content before encrypting it
+type CompressionScheme struct {
+	Algorithm CompressionAlgorithm
+	BlockSize int32
+}
+
+// IsZero returns true if no field in the struct is set to non-zero value
+func (scheme CompressionScheme) IsZero() bool {
+	return scheme == (CompressionScheme{})
+}
+
+// CompressionAlgorithm specifies a compression algorithm
+type CompressionAlgorithm byte
+
+// List of supported compression algorithms
+const (
+	NoCompression = CompressionAlgorithm(iota)
+	Gzip
+)
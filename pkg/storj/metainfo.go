@@ -12,6 +12,8 @@ import (
 type Metainfo interface {
 	// Limits returns limits for this metainfo database
 	Limits() (MetainfoLimits, error)
+	// GetUsage returns the usage of all buckets, or of a single bucket if bucket is not empty
+	GetUsage(ctx context.Context, bucket string) (Usage, error)
 
 	// CreateBucket creates a new bucket with the specified information
 	// Database automatically sets different values in the information
@@ -30,12 +32,18 @@ type Metainfo interface {
 
 	// CreateObject creates a mutable object for uploading stream info
 	CreateObject(ctx context.Context, bucket string, path Path, info *CreateObject) (MutableObject, error)
-	// ModifyObject creates a mutable object for updating a partially uploaded object
+	// ModifyObject creates a mutable object for updating the metadata of a committed object
 	ModifyObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
 	// DeleteObject deletes an object from database
 	DeleteObject(ctx context.Context, bucket string, path Path) error
 	// ListObjects lists objects in bucket based on the ListOptions
 	ListObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
+	// CopyObject copies an object to another path without transferring its data
+	CopyObject(ctx context.Context, srcBucket string, srcPath Path, dstBucket string, dstPath Path) (Object, error)
+	// MoveObject moves an object to another path without transferring its data
+	MoveObject(ctx context.Context, srcBucket string, srcPath Path, dstBucket string, dstPath Path) (Object, error)
+	// ConcatObjects moves the data of objects, in their order, to a new object
+	ConcatObjects(ctx context.Context, srcBucket string, srcPaths []Path, dstBucket string, dstPath Path, info *CreateObject) (Object, error)
 
 	// ModifyPendingObject creates a mutable object for updating a partially uploaded object
 	ModifyPendingObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
@@ -182,6 +190,18 @@ type MetainfoLimits struct {
 	MaximumInlineSegmentSize int64
 }
 
+// Usage is the storage and bandwidth usage known by the satellite
+type Usage struct {
+	// StoredBytes is the total size of the stored segments
+	StoredBytes int64
+	// Objects is the number of committed objects
+	Objects int64
+	// Segments is the number of stored segments
+	Segments int64
+	// EgressBytes is the bandwidth used for downloads in all buckets
+	EgressBytes int64
+}
+
 // ReadOnlyStream is an interface for reading segment information
 type ReadOnlyStream interface {
 	Info() Object
@@ -198,6 +218,8 @@ type ReadOnlyStream interface {
 type MutableObject interface {
 	// Info gets the current information about the object
 	Info() Object
+	// SetMetadata sets the content type and the user defined metadata of the object
+	SetMetadata(contentType string, metadata map[string]string)
 
 	// CreateStream creates a new stream for the object
 	CreateStream(ctx context.Context) (MutableStream, error)
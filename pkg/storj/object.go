@@ -51,8 +51,12 @@ type Object struct {
 type Stream struct {
 	// Size is the total size of the stream in bytes
 	Size int64
-	// Checksum is the checksum of the segment checksums
+	// Checksum is the checksum of the content, or the checksum of the part
+	// checksums of a stream concatenated from parts. It is nil if unknown.
 	Checksum []byte
+	// ChecksumParts is the number of parts of a concatenated stream,
+	// or 0 when the checksum is the one of the content
+	ChecksumParts int64
 
 	// SegmentCount is the number of segments
 	SegmentCount int64
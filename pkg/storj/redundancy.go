@@ -27,4 +27,5 @@ type RedundancyAlgorithm byte
 const (
 	InvalidRedundancyAlgorithm = RedundancyAlgorithm(iota)
 	ReedSolomon
+	Replication
 )
@@ -68,9 +68,15 @@ func (download *Download) Seek(offset int64, whence int) (int64, error) {
 	case io.SeekStart:
 		off = offset
 	case io.SeekEnd:
-		off = download.stream.Info().Size - offset
+		off = download.stream.Info().Size + offset
 	case io.SeekCurrent:
-		off += offset
+		off = download.offset + offset
+	default:
+		return download.offset, Error.New("invalid whence %d", whence)
+	}
+
+	if off < 0 {
+		return download.offset, Error.New("negative offset %d", off)
 	}
 
 	err := download.resetReader(off)
@@ -6,6 +6,7 @@ package stream
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"golang.org/x/sync/errgroup"
@@ -28,6 +29,19 @@ type Upload struct {
 
 // NewUpload creates new stream upload.
 func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.Store) *Upload {
+	return newUpload(ctx, stream, streams, streams.Put)
+}
+
+// NewContinuedUpload creates a stream upload, which continues an interrupted
+// upload after the segments that were already stored. The written data must
+// start at the offset given by the size in stream.Info().
+func NewContinuedUpload(ctx context.Context, stream storj.MutableStream, streams streams.Store) *Upload {
+	return newUpload(ctx, stream, streams, streams.Continue)
+}
+
+type putFunc func(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (streams.Meta, error)
+
+func newUpload(ctx context.Context, stream storj.MutableStream, streams streams.Store, put putFunc) *Upload {
 	reader, writer := io.Pipe()
 
 	upload := Upload{
@@ -49,7 +63,7 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
 
-		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		_, err = put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
 		if err != nil {
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
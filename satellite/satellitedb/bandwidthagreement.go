@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"storj.io/storj/pkg/bwagreement"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
 )
 
@@ -20,6 +22,8 @@ func (b *bandwidthagreement) CreateAgreement(ctx context.Context, agreement bwag
 		ctx,
 		dbx.Bwagreement_Signature(agreement.Signature),
 		dbx.Bwagreement_Data(agreement.Agreement),
+		dbx.Bwagreement_UplinkId(agreement.UplinkID.Bytes()),
+		dbx.Bwagreement_Action(int64(agreement.Action)),
 	)
 	return err
 }
@@ -55,3 +59,23 @@ func (b *bandwidthagreement) GetAgreementsSince(ctx context.Context, since time.
 	}
 	return agreements, nil
 }
+
+func (b *bandwidthagreement) GetUplinkAgreements(ctx context.Context, uplinkID storj.NodeID, action pb.PayerBandwidthAllocation_Action) ([]bwagreement.Agreement, error) {
+	rows, err := b.db.All_Bwagreement_By_UplinkId_And_Action(ctx,
+		dbx.Bwagreement_UplinkId(uplinkID.Bytes()),
+		dbx.Bwagreement_Action(int64(action)))
+	if err != nil {
+		return nil, err
+	}
+
+	agreements := make([]bwagreement.Agreement, len(rows))
+	for i, entry := range rows {
+		agreement := &agreements[i]
+		agreement.Signature = entry.Signature
+		agreement.Agreement = entry.Data
+		agreement.UplinkID = uplinkID
+		agreement.Action = action
+		agreement.CreatedAt = entry.CreatedAt
+	}
+	return agreements, nil
+}
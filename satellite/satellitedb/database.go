@@ -27,7 +27,8 @@ var (
 
 // DB contains access to different database tables
 type DB struct {
-	db *dbx.DB
+	db     *dbx.DB
+	driver string
 }
 
 // New creates instance of database (supports: postgres, sqlite3)
@@ -43,7 +44,7 @@ func New(databaseURL string) (satellite.DB, error) {
 			driver, source, err)
 	}
 
-	core := &DB{db: db}
+	core := &DB{db: db, driver: driver}
 	if driver == "sqlite3" {
 		return newLocked(core), nil
 	}
@@ -92,7 +93,7 @@ func (db *DB) Irreparable() irreparable.DB {
 
 // CreateTables is a method for creating all tables for database
 func (db *DB) CreateTables() error {
-	return migrate.Create("database", db.db)
+	return migrate.CreateOrMigrate("database", db.db, db.migrations()...)
 }
 
 // Close is used to close db connection
@@ -301,9 +301,17 @@ CREATE TABLE accounting_timestamps (
 CREATE TABLE bwagreements (
 	signature bytea NOT NULL,
 	data bytea NOT NULL,
+	uplink_id bytea NOT NULL,
+	action bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE corruption_reports (
+	node_id bytea NOT NULL,
+	reporter_id bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+);
 CREATE TABLE injuredsegments (
 	id bigserial NOT NULL,
 	info bytea NOT NULL,
@@ -334,6 +342,11 @@ CREATE TABLE overlay_cache_nodes (
 	value bytea NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
+);
+CREATE TABLE slow_nodes (
+	node_id bytea NOT NULL,
+	slow_count bigint NOT NULL,
+	PRIMARY KEY ( node_id )
 );`
 }
 
@@ -426,9 +439,17 @@ CREATE TABLE accounting_timestamps (
 CREATE TABLE bwagreements (
 	signature BLOB NOT NULL,
 	data BLOB NOT NULL,
+	uplink_id BLOB NOT NULL,
+	action INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE corruption_reports (
+	node_id BLOB NOT NULL,
+	reporter_id BLOB NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+);
 CREATE TABLE injuredsegments (
 	id INTEGER NOT NULL,
 	info BLOB NOT NULL,
@@ -459,6 +480,11 @@ CREATE TABLE overlay_cache_nodes (
 	value BLOB NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
+);
+CREATE TABLE slow_nodes (
+	node_id BLOB NOT NULL,
+	slow_count INTEGER NOT NULL,
+	PRIMARY KEY ( node_id )
 );`
 }
 
@@ -870,6 +896,8 @@ func (AccountingTimestamps_Value_Field) _Column() string { return "value" }
 type Bwagreement struct {
 	Signature []byte
 	Data      []byte
+	UplinkId  []byte
+	Action    int64
 	CreatedAt time.Time
 }
 
@@ -916,6 +944,44 @@ func (f Bwagreement_Data_Field) value() interface{} {
 
 func (Bwagreement_Data_Field) _Column() string { return "data" }
 
+type Bwagreement_UplinkId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Bwagreement_UplinkId(v []byte) Bwagreement_UplinkId_Field {
+	return Bwagreement_UplinkId_Field{_set: true, _value: v}
+}
+
+func (f Bwagreement_UplinkId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_UplinkId_Field) _Column() string { return "uplink_id" }
+
+type Bwagreement_Action_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Bwagreement_Action(v int64) Bwagreement_Action_Field {
+	return Bwagreement_Action_Field{_set: true, _value: v}
+}
+
+func (f Bwagreement_Action_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Bwagreement_Action_Field) _Column() string { return "action" }
+
 type Bwagreement_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -935,6 +1001,74 @@ func (f Bwagreement_CreatedAt_Field) value() interface{} {
 
 func (Bwagreement_CreatedAt_Field) _Column() string { return "created_at" }
 
+type CorruptionReport struct {
+	NodeId     []byte
+	ReporterId []byte
+	CreatedAt  time.Time
+}
+
+func (CorruptionReport) _Table() string { return "corruption_reports" }
+
+type CorruptionReport_Update_Fields struct {
+}
+
+type CorruptionReport_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func CorruptionReport_NodeId(v []byte) CorruptionReport_NodeId_Field {
+	return CorruptionReport_NodeId_Field{_set: true, _value: v}
+}
+
+func (f CorruptionReport_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (CorruptionReport_NodeId_Field) _Column() string { return "node_id" }
+
+type CorruptionReport_ReporterId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func CorruptionReport_ReporterId(v []byte) CorruptionReport_ReporterId_Field {
+	return CorruptionReport_ReporterId_Field{_set: true, _value: v}
+}
+
+func (f CorruptionReport_ReporterId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (CorruptionReport_ReporterId_Field) _Column() string { return "reporter_id" }
+
+type CorruptionReport_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func CorruptionReport_CreatedAt(v time.Time) CorruptionReport_CreatedAt_Field {
+	return CorruptionReport_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f CorruptionReport_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (CorruptionReport_CreatedAt_Field) _Column() string { return "created_at" }
+
 type Injuredsegment struct {
 	Id   int64
 	Info []byte
@@ -1338,6 +1472,55 @@ func (f OverlayCacheNode_Value_Field) value() interface{} {
 
 func (OverlayCacheNode_Value_Field) _Column() string { return "value" }
 
+type SlowNode struct {
+	NodeId    []byte
+	SlowCount int64
+}
+
+func (SlowNode) _Table() string { return "slow_nodes" }
+
+type SlowNode_Update_Fields struct {
+	SlowCount SlowNode_SlowCount_Field
+}
+
+type SlowNode_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SlowNode_NodeId(v []byte) SlowNode_NodeId_Field {
+	return SlowNode_NodeId_Field{_set: true, _value: v}
+}
+
+func (f SlowNode_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SlowNode_NodeId_Field) _Column() string { return "node_id" }
+
+type SlowNode_SlowCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func SlowNode_SlowCount(v int64) SlowNode_SlowCount_Field {
+	return SlowNode_SlowCount_Field{_set: true, _value: v}
+}
+
+func (f SlowNode_SlowCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SlowNode_SlowCount_Field) _Column() string { return "slow_count" }
+
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
 }
@@ -1512,21 +1695,25 @@ type Value_Row struct {
 
 func (obj *postgresImpl) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
 	bwagreement *Bwagreement, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__signature_val := bwagreement_signature.value()
 	__data_val := bwagreement_data.value()
+	__uplink_id_val := bwagreement_uplink_id.value()
+	__action_val := bwagreement_action.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, created_at ) VALUES ( ?, ?, ? ) RETURNING bwagreements.signature, bwagreements.data, bwagreements.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, uplink_id, action, created_at ) VALUES ( ?, ?, ?, ?, ? ) RETURNING bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __signature_val, __data_val, __created_at_val)
+	obj.logStmt(__stmt, __signature_val, __data_val, __uplink_id_val, __action_val, __created_at_val)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __signature_val, __data_val, __created_at_val).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __signature_val, __data_val, __uplink_id_val, __action_val, __created_at_val).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1675,6 +1862,51 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_CorruptionReport(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field,
+	corruption_report_reporter_id CorruptionReport_ReporterId_Field) (
+	corruption_report *CorruptionReport, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := corruption_report_node_id.value()
+	__reporter_id_val := corruption_report_reporter_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO corruption_reports ( node_id, reporter_id, created_at ) VALUES ( ?, ?, ? ) RETURNING corruption_reports.node_id, corruption_reports.reporter_id, corruption_reports.created_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __reporter_id_val, __created_at_val)
+
+	corruption_report = &CorruptionReport{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __reporter_id_val, __created_at_val).Scan(&corruption_report.NodeId, &corruption_report.ReporterId, &corruption_report.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return corruption_report, nil
+
+}
+
+func (obj *postgresImpl) Create_SlowNode(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	slow_node_slow_count SlowNode_SlowCount_Field) (
+	slow_node *SlowNode, err error) {
+	__node_id_val := slow_node_node_id.value()
+	__slow_count_val := slow_node_slow_count.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO slow_nodes ( node_id, slow_count ) VALUES ( ?, ? ) RETURNING slow_nodes.node_id, slow_nodes.slow_count")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __slow_count_val)
+
+	slow_node = &SlowNode{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __slow_count_val).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+
+}
+
 func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
 	overlay_cache_node_value OverlayCacheNode_Value_Field) (
@@ -1719,7 +1951,7 @@ func (obj *postgresImpl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_signature.value())
@@ -1728,7 +1960,7 @@ func (obj *postgresImpl) Get_Bwagreement_By_Signature(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1740,7 +1972,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -1758,7 +1990,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -1774,7 +2006,7 @@ func (obj *postgresImpl) Limited_Bwagreement(ctx context.Context,
 func (obj *postgresImpl) All_Bwagreement(ctx context.Context) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -1790,7 +2022,7 @@ func (obj *postgresImpl) All_Bwagreement(ctx context.Context) (
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -1807,7 +2039,7 @@ func (obj *postgresImpl) All_Bwagreement_By_CreatedAt_Greater(ctx context.Contex
 	bwagreement_created_at_greater Bwagreement_CreatedAt_Field) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.created_at > ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.created_at > ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_created_at_greater.value())
@@ -1823,7 +2055,41 @@ func (obj *postgresImpl) All_Bwagreement_By_CreatedAt_Greater(ctx context.Contex
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bwagreement)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) All_Bwagreement_By_UplinkId_And_Action(ctx context.Context,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
+	rows []*Bwagreement, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.uplink_id = ? AND bwagreements.action = ?")
+
+	var __values []interface{}
+	__values = append(__values, bwagreement_uplink_id.value(), bwagreement_action.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bwagreement := &Bwagreement{}
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -2010,6 +2276,60 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) All_CorruptionReport_By_NodeId(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field) (
+	rows []*CorruptionReport, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT corruption_reports.node_id, corruption_reports.reporter_id, corruption_reports.created_at FROM corruption_reports WHERE corruption_reports.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, corruption_report_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		corruption_report := &CorruptionReport{}
+		err = __rows.Scan(&corruption_report.NodeId, &corruption_report.ReporterId, &corruption_report.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, corruption_report)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *postgresImpl) Get_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field) (
+	slow_node *SlowNode, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT slow_nodes.node_id, slow_nodes.slow_count FROM slow_nodes WHERE slow_nodes.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, slow_node_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	slow_node = &SlowNode{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+
+}
+
 func (obj *postgresImpl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
@@ -2404,6 +2724,46 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	return node, nil
 }
 
+func (obj *postgresImpl) Update_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	update SlowNode_Update_Fields) (
+	slow_node *SlowNode, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE slow_nodes SET "), __sets, __sqlbundle_Literal(" WHERE slow_nodes.node_id = ? RETURNING slow_nodes.node_id, slow_nodes.slow_count")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.SlowCount._set {
+		__values = append(__values, update.SlowCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("slow_count = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, slow_node_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	slow_node = &SlowNode{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+}
+
 func (obj *postgresImpl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
 	update OverlayCacheNode_Update_Fields) (
@@ -2644,6 +3004,26 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM slow_nodes;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM corruption_reports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -2726,20 +3106,24 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 
 func (obj *sqlite3Impl) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
 	bwagreement *Bwagreement, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
 	__signature_val := bwagreement_signature.value()
 	__data_val := bwagreement_data.value()
+	__uplink_id_val := bwagreement_uplink_id.value()
+	__action_val := bwagreement_action.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, created_at ) VALUES ( ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO bwagreements ( signature, data, uplink_id, action, created_at ) VALUES ( ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __signature_val, __data_val, __created_at_val)
+	obj.logStmt(__stmt, __signature_val, __data_val, __uplink_id_val, __action_val, __created_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __signature_val, __data_val, __created_at_val)
+	__res, err := obj.driver.Exec(__stmt, __signature_val, __data_val, __uplink_id_val, __action_val, __created_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2907,6 +3291,57 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_CorruptionReport(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field,
+	corruption_report_reporter_id CorruptionReport_ReporterId_Field) (
+	corruption_report *CorruptionReport, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := corruption_report_node_id.value()
+	__reporter_id_val := corruption_report_reporter_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO corruption_reports ( node_id, reporter_id, created_at ) VALUES ( ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __reporter_id_val, __created_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __reporter_id_val, __created_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastCorruptionReport(ctx, __pk)
+
+}
+
+func (obj *sqlite3Impl) Create_SlowNode(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	slow_node_slow_count SlowNode_SlowCount_Field) (
+	slow_node *SlowNode, err error) {
+	__node_id_val := slow_node_node_id.value()
+	__slow_count_val := slow_node_slow_count.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO slow_nodes ( node_id, slow_count ) VALUES ( ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __slow_count_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __slow_count_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastSlowNode(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
 	overlay_cache_node_value OverlayCacheNode_Value_Field) (
@@ -2957,7 +3392,7 @@ func (obj *sqlite3Impl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.signature = ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_signature.value())
@@ -2966,7 +3401,7 @@ func (obj *sqlite3Impl) Get_Bwagreement_By_Signature(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2978,7 +3413,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -2996,7 +3431,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3012,7 +3447,7 @@ func (obj *sqlite3Impl) Limited_Bwagreement(ctx context.Context,
 func (obj *sqlite3Impl) All_Bwagreement(ctx context.Context) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -3028,7 +3463,7 @@ func (obj *sqlite3Impl) All_Bwagreement(ctx context.Context) (
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3045,7 +3480,7 @@ func (obj *sqlite3Impl) All_Bwagreement_By_CreatedAt_Greater(ctx context.Context
 	bwagreement_created_at_greater Bwagreement_CreatedAt_Field) (
 	rows []*Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE bwagreements.created_at > ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.created_at > ?")
 
 	var __values []interface{}
 	__values = append(__values, bwagreement_created_at_greater.value())
@@ -3061,7 +3496,41 @@ func (obj *sqlite3Impl) All_Bwagreement_By_CreatedAt_Greater(ctx context.Context
 
 	for __rows.Next() {
 		bwagreement := &Bwagreement{}
-		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, bwagreement)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) All_Bwagreement_By_UplinkId_And_Action(ctx context.Context,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
+	rows []*Bwagreement, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE bwagreements.uplink_id = ? AND bwagreements.action = ?")
+
+	var __values []interface{}
+	__values = append(__values, bwagreement_uplink_id.value(), bwagreement_action.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		bwagreement := &Bwagreement{}
+		err = __rows.Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3248,6 +3717,60 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) All_CorruptionReport_By_NodeId(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field) (
+	rows []*CorruptionReport, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT corruption_reports.node_id, corruption_reports.reporter_id, corruption_reports.created_at FROM corruption_reports WHERE corruption_reports.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, corruption_report_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		corruption_report := &CorruptionReport{}
+		err = __rows.Scan(&corruption_report.NodeId, &corruption_report.ReporterId, &corruption_report.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, corruption_report)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
+func (obj *sqlite3Impl) Get_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field) (
+	slow_node *SlowNode, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT slow_nodes.node_id, slow_nodes.slow_count FROM slow_nodes WHERE slow_nodes.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, slow_node_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	slow_node = &SlowNode{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+
+}
+
 func (obj *sqlite3Impl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
@@ -3692,6 +4215,56 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 	return node, nil
 }
 
+func (obj *sqlite3Impl) Update_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	update SlowNode_Update_Fields) (
+	slow_node *SlowNode, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE slow_nodes SET "), __sets, __sqlbundle_Literal(" WHERE slow_nodes.node_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.SlowCount._set {
+		__values = append(__values, update.SlowCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("slow_count = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, slow_node_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	slow_node = &SlowNode{}
+	_, err = obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT slow_nodes.node_id, slow_nodes.slow_count FROM slow_nodes WHERE slow_nodes.node_id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+}
+
 func (obj *sqlite3Impl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
 	update OverlayCacheNode_Update_Fields) (
@@ -3928,13 +4501,13 @@ func (obj *sqlite3Impl) getLastBwagreement(ctx context.Context,
 	pk int64) (
 	bwagreement *Bwagreement, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.created_at FROM bwagreements WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT bwagreements.signature, bwagreements.data, bwagreements.uplink_id, bwagreements.action, bwagreements.created_at FROM bwagreements WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	bwagreement = &Bwagreement{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.CreatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&bwagreement.Signature, &bwagreement.Data, &bwagreement.UplinkId, &bwagreement.Action, &bwagreement.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4032,6 +4605,42 @@ func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastCorruptionReport(ctx context.Context,
+	pk int64) (
+	corruption_report *CorruptionReport, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT corruption_reports.node_id, corruption_reports.reporter_id, corruption_reports.created_at FROM corruption_reports WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	corruption_report = &CorruptionReport{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&corruption_report.NodeId, &corruption_report.ReporterId, &corruption_report.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return corruption_report, nil
+
+}
+
+func (obj *sqlite3Impl) getLastSlowNode(ctx context.Context,
+	pk int64) (
+	slow_node *SlowNode, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT slow_nodes.node_id, slow_nodes.slow_count FROM slow_nodes WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	slow_node = &SlowNode{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&slow_node.NodeId, &slow_node.SlowCount)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return slow_node, nil
+
+}
+
 func (obj *sqlite3Impl) getLastOverlayCacheNode(ctx context.Context,
 	pk int64) (
 	overlay_cache_node *OverlayCacheNode, err error) {
@@ -4091,6 +4700,26 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM slow_nodes;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM corruption_reports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4252,6 +4881,27 @@ func (rx *Rx) All_Bwagreement_By_CreatedAt_Greater(ctx context.Context,
 	return tx.All_Bwagreement_By_CreatedAt_Greater(ctx, bwagreement_created_at_greater)
 }
 
+func (rx *Rx) All_Bwagreement_By_UplinkId_And_Action(ctx context.Context,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
+	rows []*Bwagreement, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Bwagreement_By_UplinkId_And_Action(ctx, bwagreement_uplink_id, bwagreement_action)
+}
+
+func (rx *Rx) All_CorruptionReport_By_NodeId(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field) (
+	rows []*CorruptionReport, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_CorruptionReport_By_NodeId(ctx, corruption_report_node_id)
+}
+
 func (rx *Rx) Create_AccountingRaw(ctx context.Context,
 	accounting_raw_node_id AccountingRaw_NodeId_Field,
 	accounting_raw_interval_end_time AccountingRaw_IntervalEndTime_Field,
@@ -4294,13 +4944,27 @@ func (rx *Rx) Create_AccountingTimestamps(ctx context.Context,
 
 func (rx *Rx) Create_Bwagreement(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field,
-	bwagreement_data Bwagreement_Data_Field) (
+	bwagreement_data Bwagreement_Data_Field,
+	bwagreement_uplink_id Bwagreement_UplinkId_Field,
+	bwagreement_action Bwagreement_Action_Field) (
 	bwagreement *Bwagreement, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Bwagreement(ctx, bwagreement_signature, bwagreement_data)
+	return tx.Create_Bwagreement(ctx, bwagreement_signature, bwagreement_data, bwagreement_uplink_id, bwagreement_action)
+
+}
+
+func (rx *Rx) Create_CorruptionReport(ctx context.Context,
+	corruption_report_node_id CorruptionReport_NodeId_Field,
+	corruption_report_reporter_id CorruptionReport_ReporterId_Field) (
+	corruption_report *CorruptionReport, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_CorruptionReport(ctx, corruption_report_node_id, corruption_report_reporter_id)
 
 }
 
@@ -4359,6 +5023,18 @@ func (rx *Rx) Create_OverlayCacheNode(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_SlowNode(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	slow_node_slow_count SlowNode_SlowCount_Field) (
+	slow_node *SlowNode, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_SlowNode(ctx, slow_node_node_id, slow_node_slow_count)
+
+}
+
 func (rx *Rx) Delete_AccountingRaw_By_Id(ctx context.Context,
 	accounting_raw_id AccountingRaw_Id_Field) (
 	deleted bool, err error) {
@@ -4508,6 +5184,16 @@ func (rx *Rx) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	return tx.Get_OverlayCacheNode_By_Key(ctx, overlay_cache_node_key)
 }
 
+func (rx *Rx) Get_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field) (
+	slow_node *SlowNode, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_SlowNode_By_NodeId(ctx, slow_node_node_id)
+}
+
 func (rx *Rx) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
@@ -4615,6 +5301,17 @@ func (rx *Rx) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	return tx.Update_OverlayCacheNode_By_Key(ctx, overlay_cache_node_key, update)
 }
 
+func (rx *Rx) Update_SlowNode_By_NodeId(ctx context.Context,
+	slow_node_node_id SlowNode_NodeId_Field,
+	update SlowNode_Update_Fields) (
+	slow_node *SlowNode, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_SlowNode_By_NodeId(ctx, slow_node_node_id, update)
+}
+
 type Methods interface {
 	All_AccountingRaw_By_NodeId(ctx context.Context,
 		accounting_raw_node_id AccountingRaw_NodeId_Field) (
@@ -4631,6 +5328,15 @@ type Methods interface {
 		bwagreement_created_at_greater Bwagreement_CreatedAt_Field) (
 		rows []*Bwagreement, err error)
 
+	All_Bwagreement_By_UplinkId_And_Action(ctx context.Context,
+		bwagreement_uplink_id Bwagreement_UplinkId_Field,
+		bwagreement_action Bwagreement_Action_Field) (
+		rows []*Bwagreement, err error)
+
+	All_CorruptionReport_By_NodeId(ctx context.Context,
+		corruption_report_node_id CorruptionReport_NodeId_Field) (
+		rows []*CorruptionReport, err error)
+
 	Create_AccountingRaw(ctx context.Context,
 		accounting_raw_node_id AccountingRaw_NodeId_Field,
 		accounting_raw_interval_end_time AccountingRaw_IntervalEndTime_Field,
@@ -4652,9 +5358,16 @@ type Methods interface {
 
 	Create_Bwagreement(ctx context.Context,
 		bwagreement_signature Bwagreement_Signature_Field,
-		bwagreement_data Bwagreement_Data_Field) (
+		bwagreement_data Bwagreement_Data_Field,
+		bwagreement_uplink_id Bwagreement_UplinkId_Field,
+		bwagreement_action Bwagreement_Action_Field) (
 		bwagreement *Bwagreement, err error)
 
+	Create_CorruptionReport(ctx context.Context,
+		corruption_report_node_id CorruptionReport_NodeId_Field,
+		corruption_report_reporter_id CorruptionReport_ReporterId_Field) (
+		corruption_report *CorruptionReport, err error)
+
 	Create_Injuredsegment(ctx context.Context,
 		injuredsegment_info Injuredsegment_Info_Field) (
 		injuredsegment *Injuredsegment, err error)
@@ -4682,6 +5395,11 @@ type Methods interface {
 		overlay_cache_node_value OverlayCacheNode_Value_Field) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
+	Create_SlowNode(ctx context.Context,
+		slow_node_node_id SlowNode_NodeId_Field,
+		slow_node_slow_count SlowNode_SlowCount_Field) (
+		slow_node *SlowNode, err error)
+
 	Delete_AccountingRaw_By_Id(ctx context.Context,
 		accounting_raw_id AccountingRaw_Id_Field) (
 		deleted bool, err error)
@@ -4741,6 +5459,10 @@ type Methods interface {
 		overlay_cache_node_key OverlayCacheNode_Key_Field) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
+	Get_SlowNode_By_NodeId(ctx context.Context,
+		slow_node_node_id SlowNode_NodeId_Field) (
+		slow_node *SlowNode, err error)
+
 	Limited_Bwagreement(ctx context.Context,
 		limit int, offset int64) (
 		rows []*Bwagreement, err error)
@@ -4787,6 +5509,11 @@ type Methods interface {
 		overlay_cache_node_key OverlayCacheNode_Key_Field,
 		update OverlayCacheNode_Update_Fields) (
 		overlay_cache_node *OverlayCacheNode, err error)
+
+	Update_SlowNode_By_NodeId(ctx context.Context,
+		slow_node_node_id SlowNode_NodeId_Field,
+		update SlowNode_Update_Fields) (
+		slow_node *SlowNode, err error)
 }
 
 type TxMethods interface {
@@ -28,9 +28,17 @@ CREATE TABLE accounting_timestamps (
 CREATE TABLE bwagreements (
 	signature bytea NOT NULL,
 	data bytea NOT NULL,
+	uplink_id bytea NOT NULL,
+	action bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE corruption_reports (
+	node_id bytea NOT NULL,
+	reporter_id bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+);
 CREATE TABLE injuredsegments (
 	id bigserial NOT NULL,
 	info bytea NOT NULL,
@@ -62,3 +70,8 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
 );
+CREATE TABLE slow_nodes (
+	node_id bytea NOT NULL,
+	slow_count bigint NOT NULL,
+	PRIMARY KEY ( node_id )
+);
@@ -28,9 +28,17 @@ CREATE TABLE accounting_timestamps (
 CREATE TABLE bwagreements (
 	signature BLOB NOT NULL,
 	data BLOB NOT NULL,
+	uplink_id BLOB NOT NULL,
+	action INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( signature )
 );
+CREATE TABLE corruption_reports (
+	node_id BLOB NOT NULL,
+	reporter_id BLOB NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+);
 CREATE TABLE injuredsegments (
 	id INTEGER NOT NULL,
 	info BLOB NOT NULL,
@@ -62,3 +70,8 @@ CREATE TABLE overlay_cache_nodes (
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
 );
+CREATE TABLE slow_nodes (
+	node_id BLOB NOT NULL,
+	slow_count INTEGER NOT NULL,
+	PRIMARY KEY ( node_id )
+);
@@ -142,6 +142,13 @@ func (m *lockedBandwidthAgreement) GetAgreementsSince(ctx context.Context, a1 ti
 	return m.db.GetAgreementsSince(ctx, a1)
 }
 
+// GetUplinkAgreements gets the bandwidth agreements of an uplink for an action.
+func (m *lockedBandwidthAgreement) GetUplinkAgreements(ctx context.Context, a1 storj.NodeID, a2 pb.PayerBandwidthAllocation_Action) ([]bwagreement.Agreement, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetUplinkAgreements(ctx, a1, a2)
+}
+
 // lockedIrreparable implements locking wrapper for irreparable.DB
 type lockedIrreparable struct {
 	sync.Locker
@@ -182,6 +189,13 @@ func (m *lockedOverlayCache) Close() error {
 	return m.db.Close()
 }
 
+// CompareAndSwap replaces the value of key with newValue if it is still oldValue
+func (m *lockedOverlayCache) CompareAndSwap(a0 storage.Key, a1 storage.Value, a2 storage.Value) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CompareAndSwap(a0, a1, a2)
+}
+
 // Delete deletes key and the value
 func (m *lockedOverlayCache) Delete(a0 storage.Key) error {
 	m.Lock()
@@ -292,6 +306,20 @@ func (m *lockedStatDB) Get(ctx context.Context, nodeID storj.NodeID) (stats *sta
 	return m.db.Get(ctx, nodeID)
 }
 
+// ReportCorruption records that reporterID found a corrupted piece on nodeID and returns the number of distinct reporters of nodeID.
+func (m *lockedStatDB) ReportCorruption(ctx context.Context, nodeID storj.NodeID, reporterID storj.NodeID) (reports int64, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ReportCorruption(ctx, nodeID, reporterID)
+}
+
+// ReportSlow records that nodeID was too slow to serve or store a piece and returns the number of times it was.
+func (m *lockedStatDB) ReportSlow(ctx context.Context, nodeID storj.NodeID) (slowCount int64, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ReportSlow(ctx, nodeID)
+}
+
 // Update all parts of single storagenode's stats.
 func (m *lockedStatDB) Update(ctx context.Context, request *statdb.UpdateRequest) (stats *statdb.NodeStats, err error) {
 	m.Lock()
@@ -0,0 +1,267 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"database/sql"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/internal/migrate"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/utils"
+)
+
+// migrations returns the migrations of the databases created with the
+// schemas of the previous releases
+func (db *DB) migrations() []migrate.Migration {
+	switch db.driver {
+	case "postgres":
+		return []migrate.Migration{{
+			Schema: postgresSchemaAgreementsWithoutUplink,
+			Migrate: func(tx *sql.Tx) error {
+				err := db.migrateAgreementsUplink(tx,
+					`ALTER TABLE bwagreements ADD COLUMN uplink_id bytea NOT NULL DEFAULT ''`,
+					`ALTER TABLE bwagreements ADD COLUMN action bigint NOT NULL DEFAULT 0`,
+					`ALTER TABLE bwagreements ALTER COLUMN uplink_id DROP DEFAULT, ALTER COLUMN action DROP DEFAULT`,
+				)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(`CREATE TABLE corruption_reports (
+	node_id bytea NOT NULL,
+	reporter_id bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+)`)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(`CREATE TABLE slow_nodes (
+	node_id bytea NOT NULL,
+	slow_count bigint NOT NULL,
+	PRIMARY KEY ( node_id )
+)`)
+				return err
+			},
+		}}
+	case "sqlite3":
+		// sqlite cannot drop the defaults of the columns
+		return []migrate.Migration{{
+			Schema: sqliteSchemaAgreementsWithoutUplink,
+			Migrate: func(tx *sql.Tx) error {
+				err := db.migrateAgreementsUplink(tx,
+					`ALTER TABLE bwagreements ADD COLUMN uplink_id BLOB NOT NULL DEFAULT x''`,
+					`ALTER TABLE bwagreements ADD COLUMN action INTEGER NOT NULL DEFAULT 0`,
+				)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(`CREATE TABLE corruption_reports (
+	node_id BLOB NOT NULL,
+	reporter_id BLOB NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, reporter_id )
+)`)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(`CREATE TABLE slow_nodes (
+	node_id BLOB NOT NULL,
+	slow_count INTEGER NOT NULL,
+	PRIMARY KEY ( node_id )
+)`)
+				return err
+			},
+		}}
+	}
+	return nil
+}
+
+// migrateAgreementsUplink adds the uplink_id and action columns to the
+// bandwidth agreements, with the statements of the database, and fills
+// them from the payer allocations of the agreements. The statements after
+// the first two are run once the columns are filled.
+func (db *DB) migrateAgreementsUplink(tx *sql.Tx, statements ...string) error {
+	for _, statement := range statements[:2] {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	rows, err := tx.Query(`SELECT signature, data FROM bwagreements`)
+	if err != nil {
+		return err
+	}
+
+	type agreement struct {
+		signature []byte
+		data      []byte
+	}
+	var agreements []agreement
+	for rows.Next() {
+		var a agreement
+		if err := rows.Scan(&a.signature, &a.data); err != nil {
+			return utils.CombineErrors(err, rows.Close())
+		}
+		agreements = append(agreements, a)
+	}
+	if err := utils.CombineErrors(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+
+	for _, a := range agreements {
+		rbad := &pb.RenterBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(a.data, rbad); err != nil {
+			return err
+		}
+		pbad := &pb.PayerBandwidthAllocation_Data{}
+		if err := proto.Unmarshal(rbad.GetPayerAllocation().GetData(), pbad); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(db.db.Rebind(`UPDATE bwagreements SET uplink_id = ?, action = ? WHERE signature = ?`),
+			pbad.UplinkId.Bytes(), int64(pbad.GetAction()), a.signature)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, statement := range statements[2:] {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postgresSchemaAgreementsWithoutUplink is the schema before the bandwidth
+// agreements recorded their uplink and action
+const postgresSchemaAgreementsWithoutUplink = `CREATE TABLE accounting_raws (
+	id bigserial NOT NULL,
+	node_id text NOT NULL,
+	interval_end_time timestamp with time zone NOT NULL,
+	data_total bigint NOT NULL,
+	data_type integer NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE accounting_rollups (
+	id bigserial NOT NULL,
+	node_id text NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	interval bigint NOT NULL,
+	data_type integer NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE accounting_timestamps (
+	name text NOT NULL,
+	value timestamp with time zone NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bwagreements (
+	signature bytea NOT NULL,
+	data bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( signature )
+);
+CREATE TABLE injuredsegments (
+	id bigserial NOT NULL,
+	info bytea NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE irreparabledbs (
+	segmentpath bytea NOT NULL,
+	segmentdetail bytea NOT NULL,
+	pieces_lost_count bigint NOT NULL,
+	seg_damaged_unix_sec bigint NOT NULL,
+	repair_attempt_count bigint NOT NULL,
+	PRIMARY KEY ( segmentpath )
+);
+CREATE TABLE nodes (
+	id bytea NOT NULL,
+	audit_success_count bigint NOT NULL,
+	total_audit_count bigint NOT NULL,
+	audit_success_ratio double precision NOT NULL,
+	uptime_success_count bigint NOT NULL,
+	total_uptime_count bigint NOT NULL,
+	uptime_ratio double precision NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE overlay_cache_nodes (
+	key bytea NOT NULL,
+	value bytea NOT NULL,
+	PRIMARY KEY ( key ),
+	UNIQUE ( key )
+);`
+
+// sqliteSchemaAgreementsWithoutUplink is the schema before the bandwidth
+// agreements recorded their uplink and action
+const sqliteSchemaAgreementsWithoutUplink = `CREATE TABLE accounting_raws (
+	id INTEGER NOT NULL,
+	node_id TEXT NOT NULL,
+	interval_end_time TIMESTAMP NOT NULL,
+	data_total INTEGER NOT NULL,
+	data_type INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE accounting_rollups (
+	id INTEGER NOT NULL,
+	node_id TEXT NOT NULL,
+	start_time TIMESTAMP NOT NULL,
+	interval INTEGER NOT NULL,
+	data_type INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE accounting_timestamps (
+	name TEXT NOT NULL,
+	value TIMESTAMP NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE bwagreements (
+	signature BLOB NOT NULL,
+	data BLOB NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( signature )
+);
+CREATE TABLE injuredsegments (
+	id INTEGER NOT NULL,
+	info BLOB NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE irreparabledbs (
+	segmentpath BLOB NOT NULL,
+	segmentdetail BLOB NOT NULL,
+	pieces_lost_count INTEGER NOT NULL,
+	seg_damaged_unix_sec INTEGER NOT NULL,
+	repair_attempt_count INTEGER NOT NULL,
+	PRIMARY KEY ( segmentpath )
+);
+CREATE TABLE nodes (
+	id BLOB NOT NULL,
+	audit_success_count INTEGER NOT NULL,
+	total_audit_count INTEGER NOT NULL,
+	audit_success_ratio REAL NOT NULL,
+	uptime_success_count INTEGER NOT NULL,
+	total_uptime_count INTEGER NOT NULL,
+	uptime_ratio REAL NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
+CREATE TABLE overlay_cache_nodes (
+	key BLOB NOT NULL,
+	value BLOB NOT NULL,
+	PRIMARY KEY ( key ),
+	UNIQUE ( key )
+);`
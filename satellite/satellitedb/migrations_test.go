@@ -0,0 +1,82 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/migrate"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// oldSchemaDB is a database with the schema of a previous release
+type oldSchemaDB struct {
+	*dbx.DB
+	schema string
+}
+
+func (db *oldSchemaDB) Schema() string { return db.schema }
+
+func TestMigrateAgreementsUplink(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := dbx.Open("sqlite3", "file:TestMigrateAgreementsUplink?mode=memory&cache=shared")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+
+	err = migrate.Create("database", &oldSchemaDB{conn, sqliteSchemaAgreementsWithoutUplink})
+	require.NoError(t, err)
+
+	uplinkID := teststorj.NodeIDFromString("uplink")
+	pbad, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		UplinkId: uplinkID,
+		Action:   pb.PayerBandwidthAllocation_GET,
+	})
+	require.NoError(t, err)
+	rbad, err := proto.Marshal(&pb.RenterBandwidthAllocation_Data{
+		PayerAllocation: &pb.PayerBandwidthAllocation{Data: pbad},
+		Total:           1000,
+	})
+	require.NoError(t, err)
+
+	_, err = conn.Exec(`INSERT INTO bwagreements (signature, data, created_at) VALUES (?, ?, ?)`,
+		[]byte("signature"), rbad, time.Now())
+	require.NoError(t, err)
+
+	db := &DB{db: conn, driver: "sqlite3"}
+	require.NoError(t, db.CreateTables())
+
+	// the uplink and the action are filled from the stored agreement
+	agreements, err := db.BandwidthAgreement().GetUplinkAgreements(ctx, uplinkID, pb.PayerBandwidthAllocation_GET)
+	require.NoError(t, err)
+	if assert.Len(t, agreements, 1) {
+		assert.Equal(t, []byte("signature"), agreements[0].Signature)
+		assert.Equal(t, rbad, agreements[0].Agreement)
+	}
+
+	agreements, err = db.BandwidthAgreement().GetUplinkAgreements(ctx, uplinkID, pb.PayerBandwidthAllocation_PUT)
+	require.NoError(t, err)
+	assert.Len(t, agreements, 0)
+
+	// the corruption reports are created
+	reports, err := db.StatDB().ReportCorruption(ctx, teststorj.NodeIDFromString("node"), uplinkID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reports)
+
+	// the slow nodes are created
+	slowCount, err := db.StatDB().ReportSlow(ctx, teststorj.NodeIDFromString("node"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), slowCount)
+
+	// the database has the current schema now
+	require.NoError(t, db.CreateTables())
+}
@@ -4,6 +4,7 @@
 package satellitedb
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
@@ -70,6 +71,45 @@ func (o *overlaycache) Get(key storage.Key) (storage.Value, error) {
 	return node.Value, nil
 }
 
+func (o *overlaycache) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+	ctx := context.Background() // TODO: fix
+
+	tx, err := o.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	node, err := tx.Get_OverlayCacheNode_By_Key(ctx, dbx.OverlayCacheNode_Key(key))
+	switch {
+	case err == sql.ErrNoRows:
+		if oldValue != nil {
+			return utils.CombineErrors(storage.ErrValueChanged.New("%s", key), tx.Rollback())
+		}
+		if newValue != nil {
+			_, err = tx.Create_OverlayCacheNode(ctx,
+				dbx.OverlayCacheNode_Key(key),
+				dbx.OverlayCacheNode_Value(newValue),
+			)
+		}
+	case err != nil:
+	case oldValue == nil || !bytes.Equal(node.Value, oldValue):
+		return utils.CombineErrors(storage.ErrValueChanged.New("%s", key), tx.Rollback())
+	case newValue == nil:
+		_, err = tx.Delete_OverlayCacheNode_By_Key(ctx, dbx.OverlayCacheNode_Key(key))
+	default:
+		updateFields := dbx.OverlayCacheNode_Update_Fields{}
+		updateFields.Value = dbx.OverlayCacheNode_Value(newValue)
+		_, err = tx.Update_OverlayCacheNode_By_Key(ctx, dbx.OverlayCacheNode_Key(key), updateFields)
+	}
+	if err != nil {
+		return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+	return Error.Wrap(tx.Commit())
+}
+
 func (o *overlaycache) GetAll(keys storage.Keys) (storage.Values, error) {
 	values := make([]storage.Value, len(keys))
 	for i, key := range keys {
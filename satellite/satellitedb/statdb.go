@@ -4,6 +4,7 @@
 package satellitedb
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"strings"
@@ -289,6 +290,67 @@ func (s *statDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, au
 	return nodeStats, Error.Wrap(tx.Commit())
 }
 
+// ReportCorruption records a corruption report of nodeID by reporterID,
+// counting each reporter once, and returns the number of reporters of nodeID
+func (s *statDB) ReportCorruption(ctx context.Context, nodeID, reporterID storj.NodeID) (reports int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tx, err := s.db.Open(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	dbReports, err := tx.All_CorruptionReport_By_NodeId(ctx, dbx.CorruptionReport_NodeId(nodeID.Bytes()))
+	if err != nil {
+		return 0, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+
+	for _, report := range dbReports {
+		if bytes.Equal(report.ReporterId, reporterID.Bytes()) {
+			return int64(len(dbReports)), Error.Wrap(tx.Commit())
+		}
+	}
+
+	_, err = tx.Create_CorruptionReport(ctx,
+		dbx.CorruptionReport_NodeId(nodeID.Bytes()),
+		dbx.CorruptionReport_ReporterId(reporterID.Bytes()),
+	)
+	if err != nil {
+		return 0, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+
+	return int64(len(dbReports)) + 1, Error.Wrap(tx.Commit())
+}
+
+// ReportSlow records nodeID as too slow to serve or store a piece and
+// returns the number of times it was
+func (s *statDB) ReportSlow(ctx context.Context, nodeID storj.NodeID) (slowCount int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tx, err := s.db.Open(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	dbSlow, err := tx.Get_SlowNode_By_NodeId(ctx, dbx.SlowNode_NodeId(nodeID.Bytes()))
+	switch {
+	case err == sql.ErrNoRows:
+		dbSlow, err = tx.Create_SlowNode(ctx,
+			dbx.SlowNode_NodeId(nodeID.Bytes()),
+			dbx.SlowNode_SlowCount(1),
+		)
+	case err == nil:
+		dbSlow, err = tx.Update_SlowNode_By_NodeId(ctx, dbx.SlowNode_NodeId(nodeID.Bytes()), dbx.SlowNode_Update_Fields{
+			SlowCount: dbx.SlowNode_SlowCount(dbSlow.SlowCount + 1),
+		})
+	}
+	if err != nil {
+		return 0, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+
+	return dbSlow.SlowCount, Error.Wrap(tx.Commit())
+}
+
 // UpdateBatch for updating multiple storage nodes' stats in the db
 func (s *statDB) UpdateBatch(ctx context.Context, updateReqList []*statdb.UpdateRequest) (
 	statsList []*statdb.NodeStats, failedUpdateReqs []*statdb.UpdateRequest, err error) {
@@ -120,6 +120,29 @@ func (client *Client) Put(key storage.Key, value storage.Value) error {
 	})
 }
 
+// CompareAndSwap replaces the value of key with newValue if it is still oldValue.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	return client.update(func(bucket *bolt.Bucket) error {
+		data := bucket.Get([]byte(key))
+		if len(data) == 0 {
+			if oldValue != nil {
+				return storage.ErrValueChanged.New("%s", key)
+			}
+		} else if oldValue == nil || !bytes.Equal(data, oldValue) {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+
+		if newValue == nil {
+			return bucket.Delete(key)
+		}
+		return bucket.Put(key, newValue)
+	})
+}
+
 // Get looks up the provided key from boltdb returning either an error or the result.
 func (client *Client) Get(key storage.Key) (storage.Value, error) {
 	if key.IsZero() {
@@ -19,6 +19,9 @@ var ErrKeyNotFound = errs.Class("key not found")
 // ErrEmptyKey is returned when an empty key is used in Put
 var ErrEmptyKey = errs.Class("empty key")
 
+// ErrValueChanged is returned when the current value of the key does not match the old value in CompareAndSwap
+var ErrValueChanged = errs.Class("value changed")
+
 // ErrEmptyQueue is returned when attempting to Dequeue from an empty queue
 var ErrEmptyQueue = errs.Class("empty queue")
 
@@ -58,6 +61,10 @@ type KeyValueStore interface {
 	Get(Key) (Value, error)
 	// GetAll gets all values from the store
 	GetAll(Keys) (Values, error)
+	// CompareAndSwap replaces the value of key with newValue if it is still
+	// oldValue, or returns ErrValueChanged. A nil oldValue requires the key
+	// not to exist and a nil newValue deletes the key.
+	CompareAndSwap(key Key, oldValue, newValue Value) error
 	// Delete deletes key and the value
 	Delete(Key) error
 	// List lists all keys starting from start and upto limit items
@@ -4,6 +4,7 @@
 package redis
 
 import (
+	"bytes"
 	"net/url"
 	"sort"
 	"strconv"
@@ -99,6 +100,44 @@ func (client *Client) Put(key storage.Key, value storage.Value) error {
 	return nil
 }
 
+// CompareAndSwap replaces the value of key with newValue if it is still oldValue.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	err := client.db.Watch(func(tx *redis.Tx) error {
+		value, err := tx.Get(key.String()).Bytes()
+		if err == redis.Nil {
+			if oldValue != nil {
+				return storage.ErrValueChanged.New("%s", key)
+			}
+		} else if err != nil {
+			return err
+		} else if oldValue == nil || !bytes.Equal(value, oldValue) {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+
+		_, err = tx.Pipelined(func(pipe redis.Pipeliner) error {
+			if newValue == nil {
+				pipe.Del(key.String())
+			} else {
+				pipe.Set(key.String(), []byte(newValue), client.TTL)
+			}
+			return nil
+		})
+		return err
+	}, key.String())
+	if err == redis.TxFailedErr {
+		// the key was changed since it was read
+		return storage.ErrValueChanged.New("%s", key)
+	}
+	if err != nil && !storage.ErrValueChanged.Has(err) {
+		return Error.New("compare and swap error: %v", err)
+	}
+	return err
+}
+
 // List returns either a list of keys for which boltdb has values or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
@@ -45,6 +45,12 @@ func (store *Logger) GetAll(keys storage.Keys) (storage.Values, error) {
 	return store.store.GetAll(keys)
 }
 
+// CompareAndSwap replaces the value of key with newValue if it is still oldValue
+func (store *Logger) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	store.log.Debug("CompareAndSwap", zap.String("key", string(key)), zap.Binary("old value", []byte(oldValue)), zap.Binary("new value", []byte(newValue)))
+	return store.store.CompareAndSwap(key, oldValue, newValue)
+}
+
 // Delete deletes key and the value
 func (store *Logger) Delete(key storage.Key) error {
 	store.log.Debug("Delete", zap.String("key", string(key)))
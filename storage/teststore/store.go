@@ -26,6 +26,7 @@ type Client struct {
 		Put         int
 		List        int
 		GetAll      int
+		CAS         int
 		ReverseList int
 		Delete      int
 		Close       int
@@ -116,6 +117,51 @@ func (store *Client) Get(key storage.Key) (storage.Value, error) {
 	return storage.CloneValue(store.Items[keyIndex].Value), nil
 }
 
+// CompareAndSwap replaces the value of key with newValue if it is still oldValue
+func (store *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	defer store.locked()()
+
+	store.version++
+	store.CallCount.CAS++
+	if store.forcedError() {
+		return errInternal
+	}
+
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	keyIndex, found := store.indexOf(key)
+	if !found {
+		if oldValue != nil {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+		if newValue == nil {
+			return nil
+		}
+
+		store.Items = append(store.Items, storage.ListItem{})
+		copy(store.Items[keyIndex+1:], store.Items[keyIndex:])
+		store.Items[keyIndex] = storage.ListItem{
+			Key:   storage.CloneKey(key),
+			Value: storage.CloneValue(newValue),
+		}
+		return nil
+	}
+
+	kv := &store.Items[keyIndex]
+	if oldValue == nil || !bytes.Equal(kv.Value, oldValue) {
+		return storage.ErrValueChanged.New("%s", key)
+	}
+
+	if newValue == nil {
+		store.Items = append(store.Items[:keyIndex], store.Items[keyIndex+1:]...)
+		return nil
+	}
+	kv.Value = storage.CloneValue(newValue)
+	return nil
+}
+
 // GetAll gets all values from the store
 func (store *Client) GetAll(keys storage.Keys) (storage.Values, error) {
 	defer store.locked()()
@@ -0,0 +1,74 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"bytes"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+func testCompareAndSwap(t *testing.T, store storage.KeyValueStore) {
+	key := storage.Key("cas/key")
+	defer cleanupItems(store, storage.Items{{Key: key}})
+
+	expect := func(t *testing.T, value storage.Value) {
+		t.Helper()
+		got, err := store.Get(key)
+		if value == nil {
+			if !storage.ErrKeyNotFound.Has(err) {
+				t.Fatalf("expected %q to be missing: got %v, %v", key, got, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to get %q: %v", key, err)
+		}
+		if !bytes.Equal(got, value) {
+			t.Fatalf("invalid value for %q = %v: got %v", key, value, got)
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := store.CompareAndSwap(key, storage.Value("old"), storage.Value("new")); !storage.ErrValueChanged.Has(err) {
+			t.Fatalf("swapped a missing key: %v", err)
+		}
+		expect(t, nil)
+
+		if err := store.CompareAndSwap(key, nil, storage.Value("first")); err != nil {
+			t.Fatalf("failed to create %q: %v", key, err)
+		}
+		expect(t, storage.Value("first"))
+
+		if err := store.CompareAndSwap(key, nil, storage.Value("second")); !storage.ErrValueChanged.Has(err) {
+			t.Fatalf("created an existing key: %v", err)
+		}
+		expect(t, storage.Value("first"))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		if err := store.CompareAndSwap(key, storage.Value("other"), storage.Value("second")); !storage.ErrValueChanged.Has(err) {
+			t.Fatalf("swapped a changed value: %v", err)
+		}
+		expect(t, storage.Value("first"))
+
+		if err := store.CompareAndSwap(key, storage.Value("first"), storage.Value("second")); err != nil {
+			t.Fatalf("failed to swap %q: %v", key, err)
+		}
+		expect(t, storage.Value("second"))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := store.CompareAndSwap(key, storage.Value("first"), nil); !storage.ErrValueChanged.Has(err) {
+			t.Fatalf("deleted a changed value: %v", err)
+		}
+		expect(t, storage.Value("second"))
+
+		if err := store.CompareAndSwap(key, storage.Value("second"), nil); err != nil {
+			t.Fatalf("failed to delete %q: %v", key, err)
+		}
+		expect(t, nil)
+	})
+}